@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -52,6 +53,10 @@ type BaseApp struct {
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 
+	txPriorityFunc sdk.TxPriorityFunc     // overrides the priority returned by the ante handler
+	mempoolPolicy  sdk.MempoolPolicy      // local mempool admission policy for CheckTx
+	pendingTxs     map[string][]pendingTx // txs admitted by the mempool policy since the last commit, by sender
+
 	// may be nil
 	initChainer      sdk.InitChainer  // initialize state with validators and state blob
 	beginBlocker     sdk.BeginBlocker // logic to run before any txs
@@ -71,6 +76,17 @@ type BaseApp struct {
 	// minimum fees for spam prevention
 	minimumFees sdk.Coins
 
+	// metrics of the txs and blocks, and gauges registered by the modules
+	metrics  *Metrics
+	gauges   []gauge
+	blockGas sdk.Gas // gas used by the txs delivered in the current block
+
+	// the app halts after committing the block at haltHeight, or the first
+	// block with a time past haltTime (in unix seconds); zero disables
+	haltHeight uint64
+	haltTime   uint64
+	halted     chan struct{} // closed once the app halted
+
 	// flag for sealing
 	sealed bool
 }
@@ -96,6 +112,8 @@ func NewBaseApp(name string, logger log.Logger, db dbm.DB, txDecoder sdk.TxDecod
 		queryRouter: NewQueryRouter(),
 		codespacer:  sdk.NewCodespacer(),
 		txDecoder:   txDecoder,
+		metrics:     NopMetrics(),
+		halted:      make(chan struct{}),
 	}
 
 	// Register the undefined & root codespaces, which should not be used by
@@ -411,6 +429,12 @@ func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res
 
 // BeginBlock implements the ABCI application interface.
 func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeginBlock) {
+	// Tendermint may reach the next height before the server stops it, the
+	// halted app must not apply it
+	if app.isHalted() {
+		panic(fmt.Sprintf("BeginBlock at height %d on halted BaseApp", req.Header.Height))
+	}
+
 	if app.cms.TracingEnabled() {
 		app.cms.ResetTraceContext()
 		app.cms.WithTracingContext(sdk.TraceContext(
@@ -436,6 +460,7 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	// set the signed validators for addition to context in deliverTx
 	// TODO: communicate this result to the address to pubkey map in slashing
 	app.voteInfos = req.LastCommitInfo.GetVotes()
+	app.blockGas = 0
 	return
 }
 
@@ -454,13 +479,20 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 		result = app.runTx(runTxModeCheck, txBytes, tx)
 	}
 
+	// Tendermint has no priority field yet, so expose it as a tag for
+	// mempool implementations and clients.
+	tags := result.Tags
+	if result.IsOK() {
+		tags = tags.AppendTag(sdk.TagPriority, []byte(strconv.FormatInt(result.Priority, 10)))
+	}
+
 	return abci.ResponseCheckTx{
 		Code:      uint32(result.Code),
 		Data:      result.Data,
 		Log:       result.Log,
 		GasWanted: result.GasWanted,
 		GasUsed:   result.GasUsed,
-		Tags:      result.Tags,
+		Tags:      tags,
 	}
 }
 
@@ -474,6 +506,7 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 	} else {
 		result = app.runTx(runTxModeDeliver, txBytes, tx)
 	}
+	app.recordTxMetrics(tx, result)
 
 	// Even though the Result.Code is not OK, there are still effects,
 	// namely fee deductions and sequence incrementing.
@@ -519,11 +552,12 @@ func (app *BaseApp) getContextForAnte(mode runTxMode, txBytes []byte) (ctx sdk.C
 	return
 }
 
-// Iterates through msgs and executes them
+// Iterates through msgs and executes them. The result data holds the
+// encoded per-message results (see sdk.MsgResults).
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (result sdk.Result) {
 	// accumulate results
 	logs := make([]string, 0, len(msgs))
-	var data []byte   // NOTE: we just append them all (?!)
+	msgResults := make(sdk.MsgResults, 0, len(msgs))
 	var tags sdk.Tags // also just append them all
 	var code sdk.ABCICodeType
 	for msgIdx, msg := range msgs {
@@ -531,10 +565,11 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		msgRoute := msg.Route()
 		handler := app.router.Route(msgRoute)
 		if handler == nil {
-			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgRoute).Result()
+			return sdk.ErrUnknownRequest(fmt.Sprintf("Msg %d: unrecognized Msg type: %s", msgIdx, msgRoute)).Result()
 		}
 
 		var msgResult sdk.Result
+		gasBefore := ctx.GasMeter().GasConsumed()
 		// Skip actual execution for CheckTx
 		if mode != runTxModeCheck {
 			msgResult = handler(ctx, msg)
@@ -544,13 +579,21 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		// NOTE: GasWanted is determined by ante handler and
 		// GasUsed by the GasMeter
 
-		// Append Data and Tags
-		data = append(data, msgResult.Data...)
+		// Record the result of this message and append its tags.
+		msgResults = append(msgResults, sdk.MsgResult{
+			MsgIndex: msgIdx,
+			Route:    msgRoute,
+			Code:     msgResult.Code,
+			Data:     msgResult.Data,
+			Log:      msgResult.Log,
+			GasUsed:  ctx.GasMeter().GasConsumed() - gasBefore,
+			Tags:     msgResult.Tags,
+		})
 		tags = append(tags, msgResult.Tags...)
 
 		// Stop execution and return on first failed message.
 		if !msgResult.IsOK() {
-			logs = append(logs, fmt.Sprintf("Msg %d failed: %s", msgIdx, msgResult.Log))
+			logs = append(logs, fmt.Sprintf("Msg %d (%s) failed: %s", msgIdx, msgRoute, msgResult.Log))
 			code = msgResult.Code
 			break
 		}
@@ -559,6 +602,12 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		logs = append(logs, fmt.Sprintf("Msg %d: %s", msgIdx, msgResult.Log))
 	}
 
+	// Messages aren't executed in CheckTx, so there is nothing to report.
+	var data []byte
+	if mode != runTxModeCheck {
+		data = msgResults.Bytes()
+	}
+
 	// Set the final gas values.
 	result = sdk.Result{
 		Code:    code,
@@ -597,10 +646,32 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
 	var gasWanted int64
+	var priority int64
 	var msCache sdk.CacheMultiStore
 	ctx := app.getContextForAnte(mode, txBytes)
 	ctx = app.initializeContext(ctx, mode)
 
+	// With a mempool policy the ante handler runs on a branch of the check
+	// state recording its changes, which is only written once the tx is
+	// admitted. A replacement competes with a pending tx for the same slot, so
+	// it is checked against the check state without the changes of the txs of
+	// its sender from that slot on.
+	var anteCache, rewound sdk.CacheMultiStore
+	var delta stateDelta
+	var sender string
+	replaced := -1
+	if mode == runTxModeCheck && app.mempoolPolicy != nil {
+		sender = app.mempoolPolicy.Sender(tx)
+		replaced = app.pendingTxIndex(sender, app.mempoolPolicy.Replaced(tx))
+		if replaced >= 0 {
+			rewound = app.rewindCheckState(sender, replaced)
+			anteCache = newDeltaMultiStore(rewound.CacheMultiStore(), &delta)
+		} else {
+			anteCache = newDeltaMultiStore(getState(app, mode).CacheMultiStore(), &delta)
+		}
+		ctx = ctx.WithMultiStore(anteCache)
+	}
+
 	defer func() {
 		if r := recover(); r != nil {
 			switch rType := r.(type) {
@@ -615,6 +686,9 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 		result.GasWanted = gasWanted
 		result.GasUsed = ctx.GasMeter().GasConsumed()
+		if mode == runTxModeCheck {
+			result.Priority = priority
+		}
 	}()
 
 	var msgs = tx.GetMsgs()
@@ -633,6 +707,30 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 		}
 
 		gasWanted = result.GasWanted
+		priority = result.Priority
+	}
+
+	if mode == runTxModeCheck {
+		switch {
+		case app.txPriorityFunc != nil:
+			priority = app.txPriorityFunc(ctx, tx)
+		case priority == 0:
+			// the ante handler didn't prioritize the tx
+			priority = sdk.DefaultTxPriority(ctx, tx)
+		}
+		if anteCache != nil {
+			txHash := tmhash.Sum(txBytes)
+			if err := app.mempoolPolicy.Admit(tx, txHash, priority); err != nil {
+				return err.Result()
+			}
+			anteCache.Write()
+			admitted := pendingTx{tx: tx, txBytes: txBytes, hash: txHash, delta: delta}
+			if replaced >= 0 {
+				app.replacePendingTx(sender, replaced, admitted, rewound)
+			} else {
+				app.addPendingTx(sender, admitted)
+			}
+		}
 	}
 
 	if mode == runTxModeSimulate {
@@ -671,6 +769,7 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	if app.endBlocker != nil {
 		res = app.endBlocker(app.deliverState.ctx, req)
 	}
+	app.recordBlockMetrics(app.deliverState.ctx)
 
 	return
 }
@@ -703,7 +802,63 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// Empty the Deliver state
 	app.deliverState = nil
 
+	// Pending txs are rechecked against the new check state.
+	app.pendingTxs = nil
+	if app.mempoolPolicy != nil {
+		app.mempoolPolicy.Reset()
+	}
+
+	if app.shouldHalt(header) {
+		app.halt(header, commitID)
+	}
+
 	return abci.ResponseCommit{
 		Data: commitID.Hash,
 	}
 }
+
+// whether the process halts after committing the block with the header
+func (app *BaseApp) shouldHalt(header abci.Header) bool {
+	switch {
+	case app.haltHeight > 0 && header.Height >= int64(app.haltHeight):
+		return true
+	case app.haltTime > 0 && header.Time.Unix() >= int64(app.haltTime):
+		return true
+	default:
+		return false
+	}
+}
+
+// halt flushes the committed state to disk, and signals the server through
+// Halted that the node must be stopped. The ResponseCommit is still returned,
+// so that Tendermint records the commit and the node can be restarted, e.g.
+// with an upgraded binary, once the halt height or time is removed from its
+// configuration.
+func (app *BaseApp) halt(header abci.Header, commitID sdk.CommitID) {
+	// a synchronous write flushes all the previous writes of the DB
+	headerBytes, err := header.Marshal()
+	if err != nil {
+		panic(err)
+	}
+	app.db.SetSync(dbHeaderKey, headerBytes)
+
+	app.Logger.Info("Halting node per configuration",
+		"height", header.Height, "time", header.Time, "appHash", cmn.HexBytes(commitID.Hash))
+	close(app.halted)
+}
+
+// Halted returns a channel which is closed once the app halted after
+// committing the halt height or time. The server running the app stops the
+// node then, the app refusing to begin any other block.
+func (app *BaseApp) Halted() <-chan struct{} {
+	return app.halted
+}
+
+func (app *BaseApp) isHalted() bool {
+	select {
+	case <-app.halted:
+		return true
+	default:
+		return false
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -585,6 +586,62 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 	}
 }
 
+// DeliverTx returns the result of each message, up to the first failing one.
+func TestMultiMsgResults(t *testing.T) {
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.GasMeter().ConsumeGas(10, "counter")
+			return sdk.Result{Data: i2b(msg.(*msgCounter).Counter), Log: "counted"}
+		})
+		bapp.Router().AddRoute(routeMsgCounter2, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			return sdk.ErrUnauthorized("counter2 failed").Result()
+		})
+	}
+	app := setupBaseApp(t, routerOpt)
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	app.BeginBlock(abci.RequestBeginBlock{})
+
+	// all messages succeed
+	tx := newTxCounter(0, 1, 2)
+	txBytes, err := codec.MarshalBinary(tx)
+	require.NoError(t, err)
+	res := app.DeliverTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+
+	results, err := sdk.DecodeMsgResults(res.Data)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for i, msgResult := range results {
+		require.True(t, msgResult.IsOK())
+		require.Equal(t, i, msgResult.MsgIndex)
+		require.Equal(t, routeMsgCounter, msgResult.Route)
+		require.Equal(t, i2b(int64(i+1)), msgResult.Data)
+		require.Equal(t, "counted", msgResult.Log)
+		require.Equal(t, int64(10), msgResult.GasUsed)
+	}
+
+	// the second message fails and the third isn't executed
+	tx = newTxCounter(1, 1)
+	tx.Msgs = append(tx.Msgs, msgCounter2{0}, msgCounter{2})
+	txBytes, err = codec.MarshalBinary(tx)
+	require.NoError(t, err)
+	res = app.DeliverTx(txBytes)
+	require.False(t, res.IsOK())
+	require.Contains(t, res.Log, "Msg 1 (msgCounter2) failed")
+
+	results, err = sdk.DecodeMsgResults(res.Data)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.True(t, results[0].IsOK())
+	require.Equal(t, 1, results[1].MsgIndex)
+	require.Equal(t, routeMsgCounter2, results[1].Route)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), results[1].Code)
+	require.Equal(t, sdk.ABCICodeType(res.Code), results[1].Code)
+}
+
 // Interleave calls to Check and Deliver and ensure
 // that there is no cross-talk. Check sees results of the previous Check calls
 // and Deliver sees that of the previous Deliver calls, but they don't see eachother.
@@ -823,3 +880,243 @@ func TestTxGasLimits(t *testing.T) {
 		}
 	}
 }
+
+// rejects txs below a minimum priority, and tracks the pending txs by counter
+type testMempoolPolicy struct {
+	minPriority int64
+	admitted    int
+	pending     map[int64][]byte
+}
+
+// all txs are sent by the same sender
+const testSender = "sender"
+
+func (mp *testMempoolPolicy) Sender(tx sdk.Tx) string   { return testSender }
+func (mp *testMempoolPolicy) Replaced(tx sdk.Tx) []byte { return mp.pending[tx.(txTest).Counter] }
+func (mp *testMempoolPolicy) Reset()                    { mp.admitted, mp.pending = 0, nil }
+func (mp *testMempoolPolicy) Admit(tx sdk.Tx, txHash []byte, priority int64) sdk.Error {
+	if priority < mp.minPriority {
+		return sdk.ErrInsufficientFee("priority too low")
+	}
+	if mp.pending == nil {
+		mp.pending = make(map[int64][]byte)
+	}
+	mp.pending[tx.(txTest).Counter] = txHash
+	mp.admitted++
+	return nil
+}
+
+// Test that CheckTx reports the priority returned by the ante handler or the
+// TxPriorityFunc, and that txs rejected by the mempool policy leave no trace
+// in the check state.
+func TestCheckTxPriority(t *testing.T) {
+	counterKey := []byte("counter-key")
+	anteOpt := func(bapp *BaseApp) {
+		anteHandler := anteHandlerTxTest(t, capKey1, counterKey)
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+			newCtx, res, abort := anteHandler(ctx, tx, simulate)
+			res.Priority = tx.(txTest).Counter * 10
+			return newCtx, res, abort
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	policy := &testMempoolPolicy{minPriority: 10}
+
+	app := setupBaseApp(t, anteOpt, routerOpt, SetMempoolPolicy(policy))
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	// priority 0 is rejected and the counter is not incremented
+	txBytes, err := codec.MarshalBinary(newTxCounter(0, 0))
+	require.NoError(t, err)
+	res := app.CheckTx(txBytes)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientFee), sdk.ABCICodeType(res.Code))
+	require.Equal(t, int64(0), getIntFromStore(app.checkState.ctx.KVStore(capKey1), counterKey))
+
+	// the same tx is admitted once the priority func raises its priority
+	app.txPriorityFunc = func(ctx sdk.Context, tx sdk.Tx) int64 { return 100 }
+	res = app.CheckTx(txBytes)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Equal(t, sdk.MakeTag(sdk.TagPriority, []byte("100")), res.Tags[len(res.Tags)-1])
+	require.Equal(t, int64(1), getIntFromStore(app.checkState.ctx.KVStore(capKey1), counterKey))
+	require.Equal(t, 1, policy.admitted)
+
+	// the policy is reset on commit
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Equal(t, 0, policy.admitted)
+}
+
+// Test that a replacement is checked against the check state without the
+// changes of the txs of its sender from the slot of the tx it replaces on, and
+// that the txs admitted after it are checked again on top of the replacement.
+func TestCheckTxReplacement(t *testing.T) {
+	// the counter of a tx acts as the sequence of an account, and the number
+	// of its msgs as its fee
+	seqKey, feeKey := []byte("seq-key"), []byte("fee-key")
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+			store := ctx.KVStore(capKey1)
+			testTx := tx.(txTest)
+			if testTx.Counter != getIntFromStore(store, seqKey) {
+				return ctx, sdk.ErrInvalidSequence("").Result(), true
+			}
+			setIntOnStore(store, seqKey, testTx.Counter+1)
+			setIntOnStore(store, feeKey, getIntFromStore(store, feeKey)+int64(len(testTx.Msgs)))
+			return ctx, sdk.Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	policy := &testMempoolPolicy{}
+
+	app := setupBaseApp(t, anteOpt, routerOpt, SetMempoolPolicy(policy))
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	checkTx := func(tx *txTest) abci.ResponseCheckTx {
+		txBytes, err := codec.MarshalBinary(tx)
+		require.NoError(t, err)
+		return app.CheckTx(txBytes)
+	}
+	checkStore := func() sdk.KVStore { return app.checkState.ctx.KVStore(capKey1) }
+
+	for i := int64(0); i < 3; i++ {
+		res := checkTx(newTxCounter(i, 1))
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	}
+	require.Equal(t, int64(3), getIntFromStore(checkStore(), seqKey))
+	require.Equal(t, int64(3), getIntFromStore(checkStore(), feeKey))
+
+	// the second tx is replaced, not only the first pending one
+	res := checkTx(newTxCounter(1, 1, 2, 3))
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	require.Len(t, app.pendingTxs[testSender], 3)
+	require.Len(t, app.pendingTxs[testSender][1].tx.GetMsgs(), 3)
+	require.Equal(t, int64(3), getIntFromStore(checkStore(), seqKey))
+	require.Equal(t, int64(5), getIntFromStore(checkStore(), feeKey))
+
+	// the next sequence is still accepted, and a wrong one rejected
+	require.True(t, checkTx(newTxCounter(3, 1)).IsOK())
+	require.False(t, checkTx(newTxCounter(5, 1)).IsOK())
+	require.Equal(t, int64(4), getIntFromStore(checkStore(), seqKey))
+
+	// the pending txs are forgotten on commit
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.Empty(t, app.pendingTxs)
+	require.Equal(t, int64(0), getIntFromStore(checkStore(), seqKey))
+}
+
+// Test that reverting a state delta restores the values it overwrote, except
+// for the keys changed again since.
+func TestStateDeltaRevert(t *testing.T) {
+	app := setupBaseApp(t)
+	app.InitChain(abci.RequestInitChain{})
+	ms := app.checkState.CacheMultiStore()
+	ms.GetKVStore(capKey1).Set([]byte("updated"), []byte("old"))
+	ms.GetKVStore(capKey1).Set([]byte("deleted"), []byte("old"))
+	ms.GetKVStore(capKey1).Set([]byte("shared"), []byte("old"))
+
+	var delta stateDelta
+	dms := newDeltaMultiStore(ms.CacheMultiStore(), &delta)
+	kvs := dms.GetKVStore(capKey1)
+	kvs.Set([]byte("updated"), []byte("new"))
+	kvs.Set([]byte("updated"), []byte("newer"))
+	kvs.Delete([]byte("deleted"))
+	kvs.Set([]byte("shared"), []byte("new"))
+	kvs.Prefix([]byte("prefix/")).Set([]byte("created"), []byte("new"))
+	dms.Write()
+	require.Equal(t, []byte("new"), ms.GetKVStore(capKey1).Get([]byte("prefix/created")))
+
+	// e.g. the tx of another sender
+	ms.GetKVStore(capKey1).Set([]byte("shared"), []byte("other"))
+
+	delta.revert(ms)
+	store := ms.GetKVStore(capKey1)
+	require.Equal(t, []byte("old"), store.Get([]byte("updated")))
+	require.Equal(t, []byte("old"), store.Get([]byte("deleted")))
+	require.Equal(t, []byte("other"), store.Get([]byte("shared")))
+	require.False(t, store.Has([]byte("prefix/created")))
+}
+
+func TestHaltHeight(t *testing.T) {
+	db := dbm.NewMemDB()
+	name := t.Name()
+	capKey := sdk.NewKVStoreKey("main")
+	app := NewBaseApp(name, defaultLogger(), db, nil, SetHaltHeight(2))
+	app.MountStoresIAVL(capKey)
+	err := app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+
+	// the node runs until the halt height
+	header := abci.Header{Height: 1}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.Commit()
+	require.False(t, isClosed(app.Halted()))
+
+	// the block at the halt height is committed before halting
+	header = abci.Header{Height: 2}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	res := app.Commit()
+	require.True(t, isClosed(app.Halted()))
+	commitID2 := sdk.CommitID{2, res.Data}
+	testLoadVersionHelper(t, app, int64(2), commitID2)
+
+	// the next block isn't applied even if Tendermint reaches it before
+	// being stopped
+	require.Panics(t, func() {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 3}})
+	})
+	testLoadVersionHelper(t, app, int64(2), commitID2)
+
+	// the last header is flushed to the db
+	headerBytes, err := header.Marshal()
+	require.NoError(t, err)
+	require.Equal(t, headerBytes, db.Get(dbHeaderKey))
+
+	// the committed state is loaded on restart
+	app = NewBaseApp(name, defaultLogger(), db, nil)
+	app.MountStoresIAVL(capKey)
+	err = app.LoadLatestVersion(capKey)
+	require.Nil(t, err)
+	testLoadVersionHelper(t, app, int64(2), commitID2)
+}
+
+func TestHaltTime(t *testing.T) {
+	haltTime := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	app := setupBaseApp(t, SetHaltTime(uint64(haltTime.Unix())))
+	app.InitChain(abci.RequestInitChain{})
+
+	// blocks before the halt time are committed without halting
+	header := abci.Header{Height: 1, Time: haltTime.Add(-time.Second)}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.False(t, isClosed(app.Halted()))
+
+	// the node halts after the first block past the halt time
+	header = abci.Header{Height: 2, Time: haltTime.Add(5 * time.Second)}
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	require.True(t, isClosed(app.Halted()))
+	require.Equal(t, int64(2), app.LastBlockHeight())
+}
+
+func isClosed(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
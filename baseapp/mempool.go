@@ -0,0 +1,216 @@
+package baseapp
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/yukimochizuki/cosmos-sdk/store"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// a tx admitted by the mempool policy since the last commit
+type pendingTx struct {
+	tx      sdk.Tx
+	txBytes []byte
+	hash    []byte
+	delta   stateDelta // changes of its ante handler to the check state
+}
+
+// returns the index of the pending tx of sender with the given hash, or -1
+func (app *BaseApp) pendingTxIndex(sender string, txHash []byte) int {
+	if txHash == nil {
+		return -1
+	}
+	for i, ptx := range app.pendingTxs[sender] {
+		if bytes.Equal(ptx.hash, txHash) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (app *BaseApp) addPendingTx(sender string, ptx pendingTx) {
+	if app.pendingTxs == nil {
+		app.pendingTxs = make(map[string][]pendingTx)
+	}
+	app.pendingTxs[sender] = append(app.pendingTxs[sender], ptx)
+}
+
+// rewindCheckState returns a branch of the check state without the changes
+// of the pending txs of sender from the i-th one on, i.e. the check state in
+// which a replacement of the i-th one is checked. The txs of other senders
+// are left untouched.
+func (app *BaseApp) rewindCheckState(sender string, i int) sdk.CacheMultiStore {
+	ms := app.checkState.CacheMultiStore()
+	ptxs := app.pendingTxs[sender]
+	for j := len(ptxs) - 1; j >= i; j-- {
+		ptxs[j].delta.revert(ms)
+	}
+	return ms
+}
+
+// replacePendingTx replaces the i-th pending tx of sender by a replacement
+// admitted on the rewound check state, and replays the later txs of sender on
+// top of the replacement before writing the rewound state to the check state.
+// The txs which no longer pass the ante handler, e.g. because the replacement
+// pays a higher fee, are left out of the check state until they are rechecked
+// after the next commit.
+func (app *BaseApp) replacePendingTx(sender string, i int, replacement pendingTx, rewound sdk.CacheMultiStore) {
+	ptxs := app.pendingTxs[sender]
+	ptxs[i] = replacement
+	for j := i + 1; j < len(ptxs); j++ {
+		delta, err := app.replayPendingTx(rewound, ptxs[j])
+		if err != nil {
+			app.Logger.Info("Pending tx left out of the check state",
+				"hash", fmt.Sprintf("%X", ptxs[j].hash), "err", err)
+		}
+		ptxs[j].delta = delta
+	}
+	rewound.Write()
+}
+
+// replayPendingTx runs the ante handler of a pending tx on a branch of ms,
+// which is written if the tx still passes it. It returns the changes made to
+// ms, or the error the ante handler returned or panicked with.
+func (app *BaseApp) replayPendingTx(ms sdk.CacheMultiStore, ptx pendingTx) (delta stateDelta, err error) {
+	if app.anteHandler == nil {
+		return nil, nil
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				err = sdk.ErrOutOfGas(fmt.Sprintf("out of gas in location: %v", rType.Descriptor))
+			default:
+				err = sdk.ErrInternal(fmt.Sprintf("recovered: %v", r))
+			}
+			delta = nil
+		}
+	}()
+
+	msCache := newDeltaMultiStore(ms.CacheMultiStore(), &delta)
+	ctx := app.checkState.ctx.WithMultiStore(msCache).WithTxBytes(ptx.txBytes)
+	if _, result, abort := app.anteHandler(ctx, ptx.tx, false); abort {
+		return nil, fmt.Errorf("ante handler failed with code %d: %s", result.Code, result.Log)
+	}
+	msCache.Write()
+	return delta, nil
+}
+
+//----------------------------------------
+// stateDelta
+
+// a change made to a KVStore, with the value it overwrote; a nil value is a
+// deletion and a nil prev an insertion
+type kvChange struct {
+	storeKey    sdk.StoreKey
+	key         []byte
+	prev, value []byte
+}
+
+// stateDelta records the changes made to a multistore in order.
+type stateDelta []kvChange
+
+// revert restores the values overwritten by the delta in ms. A key changed
+// again since, e.g. by another sender's tx, keeps its current value.
+func (delta stateDelta) revert(ms sdk.MultiStore) {
+	for i := len(delta) - 1; i >= 0; i-- {
+		change := delta[i]
+		kvs := ms.GetKVStore(change.storeKey)
+		if !bytes.Equal(kvs.Get(change.key), change.value) {
+			continue
+		}
+		if change.prev == nil {
+			kvs.Delete(change.key)
+		} else {
+			kvs.Set(change.key, change.prev)
+		}
+	}
+}
+
+// deltaMultiStore records the changes made through its KVStores in a delta.
+// The changes made through a branch of it returned by CacheMultiStore are not
+// recorded.
+type deltaMultiStore struct {
+	cacheMultiStore
+	delta *stateDelta
+}
+
+// embedded under another name than its CacheMultiStore method
+type cacheMultiStore = sdk.CacheMultiStore
+
+func newDeltaMultiStore(ms sdk.CacheMultiStore, delta *stateDelta) deltaMultiStore {
+	return deltaMultiStore{ms, delta}
+}
+
+// GetKVStore implements sdk.MultiStore.
+func (ms deltaMultiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
+	return deltaKVStore{ms.cacheMultiStore.GetKVStore(key), key, nil, ms.delta}
+}
+
+// deltaKVStore records the changes made to its parent. Keys are recorded
+// with the prefix of the store, so that they can be reverted on the store of
+// storeKey.
+type deltaKVStore struct {
+	sdk.KVStore
+	storeKey sdk.StoreKey
+	prefix   []byte
+	delta    *stateDelta
+}
+
+func (kvs deltaKVStore) record(key, value []byte) {
+	*kvs.delta = append(*kvs.delta, kvChange{
+		storeKey: kvs.storeKey,
+		key:      append(append([]byte{}, kvs.prefix...), key...),
+		prev:     kvs.KVStore.Get(key),
+		value:    value,
+	})
+}
+
+// Set implements sdk.KVStore.
+func (kvs deltaKVStore) Set(key, value []byte) {
+	kvs.record(key, value)
+	kvs.KVStore.Set(key, value)
+}
+
+// Delete implements sdk.KVStore.
+func (kvs deltaKVStore) Delete(key []byte) {
+	kvs.record(key, nil)
+	kvs.KVStore.Delete(key)
+}
+
+// DeleteRange implements sdk.KVStore.
+func (kvs deltaKVStore) DeleteRange(start, end []byte) {
+	iter := kvs.KVStore.Iterator(start, end)
+	for ; iter.Valid(); iter.Next() {
+		kvs.record(iter.Key(), nil)
+	}
+	iter.Close()
+	kvs.KVStore.DeleteRange(start, end)
+}
+
+// Prefix implements sdk.KVStore.
+func (kvs deltaKVStore) Prefix(prefix []byte) sdk.KVStore {
+	return deltaKVStore{
+		KVStore:  kvs.KVStore.Prefix(prefix),
+		storeKey: kvs.storeKey,
+		prefix:   append(append([]byte{}, kvs.prefix...), prefix...),
+		delta:    kvs.delta,
+	}
+}
+
+// Gas implements sdk.KVStore.
+func (kvs deltaKVStore) Gas(meter sdk.GasMeter, config sdk.GasConfig) sdk.KVStore {
+	return store.NewGasKVStore(meter, config, kvs)
+}
+
+// CacheWrap implements sdk.KVStore.
+func (kvs deltaKVStore) CacheWrap() sdk.CacheWrap {
+	return store.NewCacheKVStore(kvs)
+}
+
+// CacheWrapWithTrace implements sdk.KVStore.
+func (kvs deltaKVStore) CacheWrapWithTrace(w io.Writer, tc sdk.TraceContext) sdk.CacheWrap {
+	return store.NewCacheKVStore(store.NewTraceKVStore(kvs, w, tc))
+}
@@ -0,0 +1,119 @@
+package baseapp
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// MetricsSubsystem is the subsystem of the metrics of the BaseApp.
+const MetricsSubsystem = "app"
+
+// Metrics contains the metrics exposed by the BaseApp.
+type Metrics struct {
+	// Number of delivered txs, by route of their first msg and by result
+	// ("ok" or "failed").
+	Txs metrics.Counter
+	// Gas used by the delivered txs, by route of their first msg.
+	TxGas metrics.Histogram
+	// Gas used by all the txs of a block.
+	BlockGas metrics.Histogram
+
+	// creates the gauges registered by the modules, nil if the metrics are
+	// discarded
+	newGauge func(subsystem, name, help string) metrics.Gauge
+}
+
+// PrometheusMetrics returns Metrics built using the Prometheus client library,
+// registered in its default registry. Gauges registered by the modules are
+// created in the same namespace.
+func PrometheusMetrics(namespace string) *Metrics {
+	return &Metrics{
+		Txs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "txs",
+			Help:      "Number of delivered transactions.",
+		}, []string{"route", "result"}),
+		TxGas: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "tx_gas",
+			Help:      "Gas used by the delivered transactions.",
+			Buckets:   stdprometheus.ExponentialBuckets(1000, 2, 15),
+		}, []string{"route"}),
+		BlockGas: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_gas",
+			Help:      "Gas used by the transactions of a block.",
+			Buckets:   stdprometheus.ExponentialBuckets(10000, 2, 15),
+		}, []string{}),
+		newGauge: func(subsystem, name, help string) metrics.Gauge {
+			return prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      name,
+				Help:      help,
+			}, []string{})
+		},
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Txs:      discard.NewCounter(),
+		TxGas:    discard.NewHistogram(),
+		BlockGas: discard.NewHistogram(),
+	}
+}
+
+// gauge of a module, set from the state at the end of every block
+type gauge struct {
+	gauge metrics.Gauge
+	value sdk.GaugeFunc
+}
+
+var _ sdk.GaugeRegistry = (*BaseApp)(nil)
+
+// RegisterGauge implements sdk.GaugeRegistry. The gauges are ignored if the
+// metrics of the app are discarded.
+func (app *BaseApp) RegisterGauge(subsystem, name, help string, value sdk.GaugeFunc) {
+	if app.sealed {
+		panic("RegisterGauge() on sealed BaseApp")
+	}
+	if app.metrics.newGauge == nil {
+		return
+	}
+	app.gauges = append(app.gauges, gauge{app.metrics.newGauge(subsystem, name, help), value})
+}
+
+// records the route, the result and the gas of a delivered tx
+func (app *BaseApp) recordTxMetrics(tx sdk.Tx, result sdk.Result) {
+	route := "unknown"
+	if tx != nil {
+		if msgs := tx.GetMsgs(); len(msgs) > 0 {
+			route = msgs[0].Route()
+		}
+	}
+	status := "ok"
+	if !result.IsOK() {
+		status = "failed"
+	}
+	app.metrics.Txs.With("route", route, "result", status).Add(1)
+	app.metrics.TxGas.With("route", route).Observe(float64(result.GasUsed))
+	app.blockGas += result.GasUsed
+}
+
+// records the gas of the block and sets the gauges of the modules from the
+// state at the end of the block
+func (app *BaseApp) recordBlockMetrics(ctx sdk.Context) {
+	app.metrics.BlockGas.Observe(float64(app.blockGas))
+	for _, g := range app.gauges {
+		g.gauge.Set(g.value(ctx))
+	}
+}
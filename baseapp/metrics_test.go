@@ -0,0 +1,127 @@
+package baseapp
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// metrics recording their values by label values, the labels being passed as
+// name and value pairs
+type testCounter struct {
+	lvs    []string
+	values map[string]float64
+}
+
+// appends the values of the label name and value pairs to lvs
+func withLabelValues(lvs []string, labelValues []string) []string {
+	lvs = lvs[:len(lvs):len(lvs)]
+	for i := 1; i < len(labelValues); i += 2 {
+		lvs = append(lvs, labelValues[i])
+	}
+	return lvs
+}
+
+func (c testCounter) With(lvs ...string) metrics.Counter {
+	return testCounter{withLabelValues(c.lvs, lvs), c.values}
+}
+func (c testCounter) Add(delta float64) { c.values[strings.Join(c.lvs, ",")] += delta }
+
+type testHistogram struct {
+	lvs          []string
+	observations map[string][]float64
+}
+
+func (h testHistogram) With(lvs ...string) metrics.Histogram {
+	return testHistogram{withLabelValues(h.lvs, lvs), h.observations}
+}
+func (h testHistogram) Observe(value float64) {
+	key := strings.Join(h.lvs, ",")
+	h.observations[key] = append(h.observations[key], value)
+}
+
+type testGauge struct{ value *float64 }
+
+func (g testGauge) With(lvs ...string) metrics.Gauge { return g }
+func (g testGauge) Set(value float64)                { *g.value = value }
+func (g testGauge) Add(delta float64)                { *g.value += delta }
+
+func TestMetrics(t *testing.T) {
+	txs := testCounter{values: make(map[string]float64)}
+	txGas := testHistogram{observations: make(map[string][]float64)}
+	blockGas := testHistogram{observations: make(map[string][]float64)}
+	gauges := make(map[string]*float64)
+	testMetrics := &Metrics{
+		Txs:      txs,
+		TxGas:    txGas,
+		BlockGas: blockGas,
+		newGauge: func(subsystem, name, help string) metrics.Gauge {
+			value := new(float64)
+			gauges[subsystem+"_"+name] = value
+			return testGauge{value}
+		},
+	}
+
+	// every tx has its own gas meter
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, sdk.Result, bool) {
+			return ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), sdk.Result{}, false
+		})
+	}
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			ctx.GasMeter().ConsumeGas(10, "test")
+			setIntOnStore(ctx.KVStore(capKey1), deliverKey, getIntFromStore(ctx.KVStore(capKey1), deliverKey)+1)
+			return sdk.Result{}
+		})
+	}
+	gaugeOpt := func(bapp *BaseApp) {
+		bapp.RegisterGauge("test", "delivered", "Number of delivered msgs.", func(ctx sdk.Context) float64 {
+			return float64(getIntFromStore(ctx.KVStore(capKey1), deliverKey))
+		})
+	}
+	app := setupBaseApp(t, SetMetrics(testMetrics), anteOpt, routerOpt, gaugeOpt)
+	require.Panics(t, func() { app.RegisterGauge("test", "sealed", "", nil) })
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+
+	app.BeginBlock(abci.RequestBeginBlock{})
+	for i := int64(0); i < 2; i++ {
+		txBytes, err := codec.MarshalBinary(newTxCounter(i, i))
+		require.NoError(t, err)
+		require.True(t, app.DeliverTx(txBytes).IsOK())
+	}
+	txBytes, err := codec.MarshalBinary(&txTest{Msgs: []sdk.Msg{msgNoRoute{}}})
+	require.NoError(t, err)
+	require.False(t, app.DeliverTx(txBytes).IsOK())
+	require.False(t, app.DeliverTx([]byte("invalid")).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	require.Equal(t, map[string]float64{
+		routeMsgCounter + ",ok": 2,
+		"noroute,failed":        1,
+		"unknown,failed":        1,
+	}, txs.values)
+	gasUsed := txGas.observations[routeMsgCounter]
+	require.Len(t, gasUsed, 2)
+	require.True(t, gasUsed[0] >= 10)
+	blockGasUsed := gasUsed[0] + gasUsed[1] + txGas.observations["noroute"][0] + txGas.observations["unknown"][0]
+	require.Equal(t, []float64{blockGasUsed}, blockGas.observations[""])
+	require.Equal(t, 2.0, *gauges["test_delivered"])
+}
+
+func TestNopMetricsIgnoreGauges(t *testing.T) {
+	app := newBaseApp(t.Name())
+	app.RegisterGauge("test", "ignored", "", func(ctx sdk.Context) float64 { return 0 })
+	require.Empty(t, app.gauges)
+}
@@ -39,6 +39,31 @@ func SetMinimumFees(minFees string) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.SetMinimumFees(fees) }
 }
 
+// SetMempoolPolicy returns an option that sets the local mempool admission
+// policy on the app.
+func SetMempoolPolicy(mp sdk.MempoolPolicy) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.SetMempoolPolicy(mp) }
+}
+
+// SetMetrics returns an option that sets the metrics exposed by the app, and
+// in which the gauges of the modules are registered.
+func SetMetrics(metrics *Metrics) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.metrics = metrics }
+}
+
+// SetHaltHeight returns an option that halts the app after committing the
+// block at the given height, zero meaning never. See BaseApp.Halted.
+func SetHaltHeight(height uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.haltHeight = height }
+}
+
+// SetHaltTime returns an option that halts the app after committing the
+// first block with a time past the given unix time in seconds, zero meaning
+// never. See BaseApp.Halted.
+func SetHaltTime(haltTime uint64) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.haltTime = haltTime }
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -88,6 +113,23 @@ func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	app.anteHandler = ah
 }
 
+// SetTxPriorityFunc sets the function computing the mempool priority of txs in
+// CheckTx. Without one, the priority reported by the ante handler is used, or
+// sdk.DefaultTxPriority if it reports none.
+func (app *BaseApp) SetTxPriorityFunc(pf sdk.TxPriorityFunc) {
+	if app.sealed {
+		panic("SetTxPriorityFunc() on sealed BaseApp")
+	}
+	app.txPriorityFunc = pf
+}
+
+func (app *BaseApp) SetMempoolPolicy(mp sdk.MempoolPolicy) {
+	if app.sealed {
+		panic("SetMempoolPolicy() on sealed BaseApp")
+	}
+	app.mempoolPolicy = mp
+}
+
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
@@ -46,6 +46,7 @@ type CLIContext struct {
 	JSON          bool
 	PrintResponse bool
 	Verifier      tmlite.Verifier
+	Sequences     *SequenceManager
 	DryRun        bool
 	GenerateOnly  bool
 	fromAddress   types.AccAddress
@@ -208,6 +209,14 @@ func (ctx CLIContext) WithNodeURI(nodeURI string) CLIContext {
 	return ctx
 }
 
+// WithSequenceManager returns a copy of the context whose txs take their
+// sequences from a new SequenceManager, instead of reading them from the node
+// for each tx.
+func (ctx CLIContext) WithSequenceManager() CLIContext {
+	ctx.Sequences = NewSequenceManager(ctx.GetAccountSequence)
+	return ctx
+}
+
 // WithClient returns a copy of the context with an updated RPC client
 // instance.
 func (ctx CLIContext) WithClient(client rpcclient.Client) CLIContext {
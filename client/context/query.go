@@ -1,6 +1,7 @@
 package context
 
 import (
+	"bytes"
 	"fmt"
 
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
@@ -171,12 +172,12 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 		return res, errors.Errorf(resp.Log)
 	}
 
-	// data from trusted node or subspace query doesn't need verification
+	// data from trusted node or custom query doesn't need verification
 	if ctx.TrustNode || !isQueryStoreWithProof(path) {
 		return resp.Value, nil
 	}
 
-	err = ctx.verifyProof(path, resp)
+	err = ctx.verifyProof(path, key, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -197,12 +198,20 @@ func (ctx CLIContext) Verify(height int64) (tmtypes.SignedHeader, error) {
 	return check, nil
 }
 
-// verifyProof perform response proof verification.
-func (ctx CLIContext) verifyProof(_ string, resp abci.ResponseQuery) error {
+// verifyProof perform response proof verification. The proof must be for the
+// queried key, in the queried store and at the queried height.
+func (ctx CLIContext) verifyProof(path string, key []byte, resp abci.ResponseQuery) error {
 	if ctx.Verifier == nil {
 		return fmt.Errorf("missing valid certifier to verify data from distrusted node")
 	}
 
+	if !bytes.Equal(resp.Key, key) {
+		return errors.Errorf("the node answered for key %X instead of %X", resp.Key, key)
+	}
+	if ctx.Height != 0 && resp.Height != ctx.Height {
+		return errors.Errorf("the node answered for height %d instead of %d", resp.Height, ctx.Height)
+	}
+
 	// the AppHash for height H is in header H+1
 	commit, err := ctx.Verify(resp.Height + 1)
 	if err != nil {
@@ -217,20 +226,10 @@ func (ctx CLIContext) verifyProof(_ string, resp abci.ResponseQuery) error {
 		return errors.Wrap(err, "failed to unmarshalBinary rangeProof")
 	}
 
-	// verify the substore commit hash against trusted appHash
-	substoreCommitHash, err := store.VerifyMultiStoreCommitInfo(
-		multiStoreProof.StoreName, multiStoreProof.StoreInfos, commit.Header.AppHash,
-	)
-	if err != nil {
-		return errors.Wrap(err, "failed in verifying the proof against appHash")
-	}
-
-	err = store.VerifyRangeProof(resp.Key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
-	if err != nil {
-		return errors.Wrap(err, "failed in the range proof verification")
-	}
-
-	return nil
+	// the path was checked by isQueryStoreWithProof
+	paths := strings.SplitN(path[1:], "/", 3)
+	storeName, subpath := paths[1], "/"+paths[2]
+	return store.VerifyMultiStoreProof(storeName, subpath, key, resp.Value, commit.Header.AppHash, multiStoreProof)
 }
 
 // queryStore performs a query from a Tendermint node with the provided a store
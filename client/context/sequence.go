@@ -0,0 +1,66 @@
+package context
+
+import (
+	"sync"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// SequenceManager hands out the sequences of the txs signed by a client, so
+// that an account can send txs in a row without waiting for each of them to be
+// committed. The sequence of an account is read from the node once, then the
+// following ones are reserved locally.
+type SequenceManager struct {
+	mtx   sync.Mutex
+	next  map[string]int64
+	fetch func(address []byte) (int64, error)
+}
+
+// NewSequenceManager returns a SequenceManager reading the sequences of
+// accounts with fetch, e.g. CLIContext.GetAccountSequence.
+func NewSequenceManager(fetch func(address []byte) (int64, error)) *SequenceManager {
+	return &SequenceManager{
+		next:  make(map[string]int64),
+		fetch: fetch,
+	}
+}
+
+// Next reserves the next sequence of the account.
+func (sm *SequenceManager) Next(addr sdk.AccAddress) (int64, error) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+
+	key := string(addr)
+	seq, ok := sm.next[key]
+	if !ok {
+		var err error
+		seq, err = sm.fetch(addr)
+		if err != nil {
+			return 0, err
+		}
+	}
+	sm.next[key] = seq + 1
+	return seq, nil
+}
+
+// Release gives back a reserved sequence that won't be used, e.g. because its
+// tx was rejected by CheckTx for another reason than its sequence. It has no
+// effect unless it is the last reserved sequence of the account.
+func (sm *SequenceManager) Release(addr sdk.AccAddress, seq int64) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+
+	key := string(addr)
+	if next, ok := sm.next[key]; ok && next == seq+1 {
+		sm.next[key] = seq
+	}
+}
+
+// Resync drops the reserved sequences of the account, its next sequence is
+// read from the node again. Use it once the node rejected a sequence.
+func (sm *SequenceManager) Resync(addr sdk.AccAddress) {
+	sm.mtx.Lock()
+	defer sm.mtx.Unlock()
+
+	delete(sm.next, string(addr))
+}
@@ -0,0 +1,48 @@
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestSequenceManager(t *testing.T) {
+	addr1, addr2 := sdk.AccAddress([]byte("addr1")), sdk.AccAddress([]byte("addr2"))
+	onChain := map[string]int64{string(addr1): 5, string(addr2): 0}
+	fetches := 0
+	sm := NewSequenceManager(func(address []byte) (int64, error) {
+		fetches++
+		return onChain[string(address)], nil
+	})
+
+	// sequences are reserved locally once read
+	for i := int64(5); i < 8; i++ {
+		seq, err := sm.Next(addr1)
+		require.NoError(t, err)
+		require.Equal(t, i, seq)
+	}
+	seq, err := sm.Next(addr2)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), seq)
+	require.Equal(t, 2, fetches)
+
+	// only the last reserved sequence can be released
+	sm.Release(addr1, 6)
+	seq, err = sm.Next(addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), seq)
+	sm.Release(addr1, 8)
+	seq, err = sm.Next(addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), seq)
+
+	// resyncing reads the sequence from the node again
+	onChain[string(addr1)] = 7
+	sm.Resync(addr1)
+	seq, err = sm.Next(addr1)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), seq)
+	require.Equal(t, 3, fetches)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
 )
 
 // nolint
@@ -36,6 +38,7 @@ const (
 	FlagDryRun         = "dry-run"
 	FlagGenerateOnly   = "generate-only"
 	FlagIndentResponse = "indent"
+	FlagKeyringBackend = "keyring-backend"
 )
 
 // LineBreak can be included in a command list to provide a blank line
@@ -81,6 +84,7 @@ func PostCommands(cmds ...*cobra.Command) []*cobra.Command {
 		c.Flags().Bool(FlagTrustNode, true, "Trust connected full node (don't verify proofs for responses)")
 		c.Flags().Bool(FlagDryRun, false, "ignore the --gas flag and perform a simulation of a transaction, but don't broadcast it")
 		c.Flags().Bool(FlagGenerateOnly, false, "build an unsigned transaction and write it to STDOUT")
+		c.Flags().String(FlagKeyringBackend, keys.BackendLevelDB, fmt.Sprintf("keyring backend to sign with, one of %v", keys.Backends))
 		// --gas can accept integers and "simulate"
 		c.Flags().Var(&GasFlagVar, "gas", fmt.Sprintf(
 			"gas limit to set per-transaction; set to %q to calculate required gas automatically (default %d)", GasFlagSimulate, DefaultGasLimit))
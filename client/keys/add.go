@@ -18,12 +18,15 @@ import (
 )
 
 const (
-	flagType     = "type"
-	flagRecover  = "recover"
-	flagNoBackup = "no-backup"
-	flagDryRun   = "dry-run"
-	flagAccount  = "account"
-	flagIndex    = "index"
+	flagType      = "type"
+	flagAlgo      = "algo"
+	flagRecover   = "recover"
+	flagNoBackup  = "no-backup"
+	flagDryRun    = "dry-run"
+	flagAccount   = "account"
+	flagIndex     = "index"
+	flagRemote    = "remote"
+	flagRemoteKey = "remote-key"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -31,17 +34,22 @@ func addKeyCommand() *cobra.Command {
 		Use:   "add <name>",
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
-If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+If you select --recover you can recover a key from the seed
+phrase, otherwise, a new key will be generated. Keys are
+secp256k1 keys unless another --algo is given.`,
 		RunE: runAddCmd,
 	}
-	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().String(flagAlgo, string(keys.Secp256k1), "Signing algorithm of the key (secp256k1|ed25519)")
+	cmd.Flags().StringP(flagType, "t", string(keys.Secp256k1), "Type of private key (secp256k1|ed25519)")
+	cmd.Flags().MarkDeprecated(flagType, fmt.Sprintf("use --%s instead", flagAlgo))
 	cmd.Flags().Bool(client.FlagUseLedger, false, "Store a local reference to a private key on a Ledger device")
 	cmd.Flags().Bool(flagRecover, false, "Provide seed phrase to recover existing key instead of creating")
 	cmd.Flags().Bool(flagNoBackup, false, "Don't print out seed phrase (if others are watching the terminal)")
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().String(flagRemote, "", "Store a local reference to a key of the remote signer at this address (unix://<path> or tcp://<host>:<port>)")
+	cmd.Flags().String(flagRemoteKey, "", "Name of the key on the remote signer, defaults to <name>")
 	return cmd
 }
 
@@ -78,7 +86,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		}
 
 		// ask for a password when generating a local key
-		if !viper.GetBool(client.FlagUseLedger) {
+		if !viper.GetBool(client.FlagUseLedger) && viper.GetString(flagRemote) == "" {
 			pass, err = client.GetCheckPassword(
 				"Enter a passphrase for your key:",
 				"Repeat the passphrase:", buf)
@@ -92,19 +100,30 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		account := uint32(viper.GetInt(flagAccount))
 		index := uint32(viper.GetInt(flagIndex))
 		path := ccrypto.DerivationPath{44, 118, account, 0, index}
-		algo := keys.SigningAlgo(viper.GetString(flagType))
-		info, err := kb.CreateLedger(name, path, algo)
+		info, err := kb.CreateLedger(name, path, getSigningAlgo(cmd))
 		if err != nil {
 			return err
 		}
 		printCreate(info, "")
+	} else if signerAddr := viper.GetString(flagRemote); signerAddr != "" {
+		remoteName := viper.GetString(flagRemoteKey)
+		if remoteName == "" {
+			remoteName = name
+		}
+		info, err := kb.CreateRemote(name, signerAddr, remoteName)
+		if err != nil {
+			return err
+		}
+		// there is no seed phrase to print
+		viper.Set(flagNoBackup, true)
+		printCreate(info, "")
 	} else if viper.GetBool(flagRecover) {
 		seed, err := client.GetSeed(
 			"Enter your recovery seed phrase:", buf)
 		if err != nil {
 			return err
 		}
-		info, err := kb.CreateKey(name, seed, pass)
+		info, err := kb.CreateKey(name, seed, pass, getSigningAlgo(cmd))
 		if err != nil {
 			return err
 		}
@@ -112,8 +131,7 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 		viper.Set(flagNoBackup, true)
 		printCreate(info, "")
 	} else {
-		algo := keys.SigningAlgo(viper.GetString(flagType))
-		info, seed, err := kb.CreateMnemonic(name, keys.English, pass, algo)
+		info, seed, err := kb.CreateMnemonic(name, keys.English, pass, getSigningAlgo(cmd))
 		if err != nil {
 			return err
 		}
@@ -122,6 +140,15 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// getSigningAlgo returns the algorithm selected by --algo, or by the
+// deprecated --type flag if it is used.
+func getSigningAlgo(cmd *cobra.Command) keys.SigningAlgo {
+	if cmd.Flags().Changed(flagType) {
+		return keys.SigningAlgo(viper.GetString(flagType))
+	}
+	return keys.SigningAlgo(viper.GetString(flagAlgo))
+}
+
 func printCreate(info keys.Info, seed string) {
 	output := viper.Get(cli.OutputFlag)
 	switch output {
@@ -166,6 +193,7 @@ type NewKeyBody struct {
 	Name     string `json:"name"`
 	Password string `json:"password"`
 	Seed     string `json:"seed"`
+	Algo     string `json:"algo"` // defaults to secp256k1
 }
 
 // add new key REST handler
@@ -218,11 +246,12 @@ func AddNewKeyRequestHandler(indent bool) http.HandlerFunc {
 		}
 
 		// create account
+		algo := restSigningAlgo(m.Algo)
 		seed := m.Seed
 		if seed == "" {
-			seed = getSeed(keys.Secp256k1)
+			seed = getSeed(algo)
 		}
-		info, err := kb.CreateKey(m.Name, seed, m.Password)
+		info, err := kb.CreateKey(m.Name, seed, m.Password, algo)
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(err.Error()))
@@ -242,6 +271,15 @@ func AddNewKeyRequestHandler(indent bool) http.HandlerFunc {
 	}
 }
 
+// restSigningAlgo returns the algorithm of a REST request, secp256k1 if it
+// is not set.
+func restSigningAlgo(algo string) keys.SigningAlgo {
+	if algo == "" {
+		return keys.Secp256k1
+	}
+	return keys.SigningAlgo(algo)
+}
+
 // function to just a new seed to display in the UI before actually persisting it in the keybase
 func getSeed(algo keys.SigningAlgo) string {
 	kb := client.MockKeyBase()
@@ -254,12 +292,7 @@ func getSeed(algo keys.SigningAlgo) string {
 // Seed REST request handler
 func SeedRequestHandler(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
-	algoType := vars["type"]
-	// algo type defaults to secp256k1
-	if algoType == "" {
-		algoType = "secp256k1"
-	}
-	algo := keys.SigningAlgo(algoType)
+	algo := restSigningAlgo(vars["type"])
 
 	seed := getSeed(algo)
 
@@ -271,6 +304,7 @@ func SeedRequestHandler(w http.ResponseWriter, r *http.Request) {
 type RecoverKeyBody struct {
 	Password string `json:"password"`
 	Seed     string `json:"seed"`
+	Algo     string `json:"algo"` // defaults to secp256k1
 }
 
 // RecoverRequestHandler performs key recover request
@@ -328,7 +362,7 @@ func RecoverRequestHandler(indent bool) http.HandlerFunc {
 			}
 		}
 
-		info, err := kb.CreateKey(name, m.Seed, m.Password)
+		info, err := kb.CreateKey(name, m.Seed, m.Password, restSigningAlgo(m.Algo))
 		if err != nil {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(err.Error()))
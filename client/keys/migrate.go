@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/yukimochizuki/cosmos-sdk/client"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
+)
+
+func migrateKeyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate <backend>",
+		Short: "Copy all keys to another keyring backend",
+		Long: fmt.Sprintf(`Copy all keys of the keyring selected by --%s to the given keyring
+backend, one of %v. Keys stay encrypted with their passphrases and the
+source keyring is left untouched. Nothing is copied if a key already
+exists in the destination keyring.`, client.FlagKeyringBackend, keys.Backends),
+		RunE: runMigrateCmd,
+		Args: cobra.ExactArgs(1),
+	}
+	return cmd
+}
+
+func runMigrateCmd(cmd *cobra.Command, args []string) error {
+	rootDir := viper.GetString(cli.HomeFlag)
+	from := viper.GetString(client.FlagKeyringBackend)
+	if from == "" {
+		from = keys.BackendLevelDB
+	}
+	to := args[0]
+	if from == to {
+		return fmt.Errorf("keys are already stored in the %s keyring", to)
+	}
+
+	src, err := openKeyringDB(from, rootDir, &opt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := openKeyringDB(to, rootDir, nil)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	n, err := keys.Migrate(src, dst)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Migrated %d keys from the %s keyring to the %s keyring\n", n, from, to)
+	return nil
+}
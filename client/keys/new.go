@@ -25,11 +25,14 @@ func newKeyCommand() *cobra.Command {
 		Long: `Derive a new private key using an interactive command that will prompt you for each input.
 Optionally specify a bip39 mnemonic, a bip39 passphrase to further secure the mnemonic,
 and a bip32 HD path to derive a specific account. The key will be stored under the given name 
-and encrypted with the given password. The only input that is required is the encryption password.`,
+and encrypted with the given password. The only input that is required is the encryption password.
+Keys are secp256k1 keys unless another --algo is given; ed25519 keys are derived with SLIP-0010,
+which hardens every level of the path.`,
 		Args: cobra.ExactArgs(1),
 		RunE: runNewCmd,
 	}
 	cmd.Flags().Bool(flagNewDefault, false, "Skip the prompts and just use the default values for everything")
+	cmd.Flags().String(flagAlgo, string(keys.Secp256k1), "Signing algorithm of the key (secp256k1|ed25519)")
 	cmd.Flags().Bool(client.FlagUseLedger, false, "Store a local reference to a private key on a Ledger device")
 	cmd.Flags().String(flagBIP44Path, "44'/118'/0'/0/0", "BIP44 path from which to derive a private key")
 	return cmd
@@ -64,6 +67,10 @@ func runNewCmd(cmd *cobra.Command, args []string) error {
 
 	flags := cmd.Flags()
 	useDefaults, _ := flags.GetBool(flagNewDefault)
+	algo := getSigningAlgo(cmd)
+	if algo != keys.Secp256k1 && algo != keys.Ed25519 {
+		return errors.Wrap(keys.ErrUnsupportedSigningAlgo, string(algo))
+	}
 	bipFlag := flags.Lookup(flagBIP44Path)
 
 	bip44Params, err := getBIP44ParamsAndPath(bipFlag.Value.String(), bipFlag.Changed || useDefaults)
@@ -74,7 +81,6 @@ func runNewCmd(cmd *cobra.Command, args []string) error {
 	// If we're using ledger, only thing we need is the path. So generate key and
 	// we're done.
 	if viper.GetBool(client.FlagUseLedger) {
-		algo := keys.Secp256k1
 		path := bip44Params.DerivationPath() // ccrypto.DerivationPath{44, 118, account, 0, index}
 
 		info, err := kb.CreateLedger(name, path, algo)
@@ -142,7 +148,7 @@ func runNewCmd(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	info, err := kb.Derive(name, mnemonic, bip39Passphrase, encryptPassword, *bip44Params)
+	info, err := kb.Derive(name, mnemonic, bip39Passphrase, encryptPassword, *bip44Params, algo)
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,12 @@
 package keys
 
 import (
-	"github.com/yukimochizuki/cosmos-sdk/client"
-	"github.com/gorilla/mux"
+	"fmt"
+
 	"github.com/spf13/cobra"
+	"github.com/yukimochizuki/cosmos-sdk/client"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
 )
 
 // Commands registers a sub-tree of commands to interact with
@@ -27,17 +30,61 @@ func Commands() *cobra.Command {
 		client.LineBreak,
 		deleteKeyCommand(),
 		updateKeyCommand(),
+		migrateKeyCommand(),
+		serveSignerCommand(),
 	)
+	cmd.PersistentFlags().String(client.FlagKeyringBackend, keys.BackendLevelDB,
+		fmt.Sprintf("keyring backend to store keys in, one of %v", keys.Backends))
 	return cmd
 }
 
 // resgister REST routes
-func RegisterRoutes(r *mux.Router, indent bool) {
-	r.HandleFunc("/keys", QueryKeysRequestHandler(indent)).Methods("GET")
-	r.HandleFunc("/keys", AddNewKeyRequestHandler(indent)).Methods("POST")
-	r.HandleFunc("/keys/seed", SeedRequestHandler).Methods("GET")
-	r.HandleFunc("/keys/{name}/recover", RecoverRequestHandler(indent)).Methods("POST")
-	r.HandleFunc("/keys/{name}", GetKeyRequestHandler(indent)).Methods("GET")
-	r.HandleFunc("/keys/{name}", UpdateKeyRequestHandler).Methods("PUT")
-	r.HandleFunc("/keys/{name}", DeleteKeyRequestHandler).Methods("DELETE")
+func RegisterRoutes(r *openapi.Router, indent bool) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/keys",
+		Summary:  "List the local keys",
+		Response: []KeyOutput{},
+	}, QueryKeysRequestHandler(indent))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/keys",
+		Summary:  "Create a new key",
+		Request:  NewKeyBody{},
+		Response: KeyOutput{},
+	}, AddNewKeyRequestHandler(indent))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/keys/seed",
+		Summary:  "Generate a seed phrase",
+		Response: "",
+	}, SeedRequestHandler)
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/keys/{name}/recover",
+		Summary:  "Recover a key from its seed phrase",
+		Request:  RecoverKeyBody{},
+		Response: KeyOutput{},
+	}, RecoverRequestHandler(indent))
+	r.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/keys/{name}",
+		Summary: "Get a key",
+		Query: []openapi.Param{
+			{Name: FlagBechPrefix, Description: "Bech32 prefix of the address and public key: acc, val or cons"},
+		},
+		Response: KeyOutput{},
+	}, GetKeyRequestHandler(indent))
+	r.HandleFunc(openapi.Route{
+		Method:  "PUT",
+		Path:    "/keys/{name}",
+		Summary: "Update the password of a key",
+		Request: UpdateKeyBody{},
+	}, UpdateKeyRequestHandler)
+	r.HandleFunc(openapi.Route{
+		Method:  "DELETE",
+		Path:    "/keys/{name}",
+		Summary: "Delete a key",
+		Request: DeleteKeyBody{},
+	}, DeleteKeyRequestHandler)
 }
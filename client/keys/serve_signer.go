@@ -0,0 +1,117 @@
+package keys
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/yukimochizuki/cosmos-sdk/client"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/remotesigner"
+)
+
+const (
+	flagLaddr = "laddr"
+	flagAllow = "allow"
+)
+
+func serveSignerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve-signer",
+		Short: "Sign transactions for remote keys with keys of the keystore",
+		Long: fmt.Sprintf(`Serve keys of the keystore to remote key references, as created by
+'keys add <name> --remote <address>'. Only the keys given with --%s are
+served, each with the message types it may sign, e.g.
+
+    --%s validator=cosmos-sdk/MsgUnjail,cosmos-sdk/MsgEditValidator
+
+A key may sign any message with '<name>=%s'. The passphrases of local keys
+are asked once at startup.
+
+The signer only listens on a unix socket, in a directory which only its owner
+can access. Clients on other hosts reach it through an SSH forwarding of the
+socket to a local unix socket, which ssh only makes accessible to its owner,
+e.g.
+
+    ssh -N -L <client home>/signer.sock:<home>/signer/signer.sock signer-host
+
+and reference its keys with '--remote unix://<client home>/signer.sock'.`, flagAllow, flagAllow, remotesigner.AllowAllMsgTypes),
+		RunE: runServeSignerCmd,
+		Args: cobra.NoArgs,
+	}
+	cmd.Flags().String(flagLaddr, "", "Unix socket to listen on (unix://<path>), defaults to unix://<home>/signer/signer.sock")
+	cmd.Flags().StringArray(flagAllow, nil, "Key to serve and the message types it may sign, as <name>=<type>[,<type>...]")
+	return cmd
+}
+
+func runServeSignerCmd(cmd *cobra.Command, args []string) error {
+	// viper would split the comma separated message types of a flag
+	allows, err := cmd.Flags().GetStringArray(flagAllow)
+	if err != nil {
+		return err
+	}
+	if len(allows) == 0 {
+		return fmt.Errorf("no key to serve, use --%s", flagAllow)
+	}
+
+	kb, err := GetKeyBase()
+	if err != nil {
+		return err
+	}
+
+	buf := client.BufferStdin()
+	policies := make(map[string]remotesigner.KeyPolicy, len(allows))
+	for _, allow := range allows {
+		parts := strings.SplitN(allow, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid --%s %q, expected <name>=<type>[,<type>...]", flagAllow, allow)
+		}
+		name := parts[0]
+
+		info, err := kb.Get(name)
+		if err != nil {
+			return err
+		}
+		var passphrase string
+		switch info.GetType() {
+		case keys.TypeLocal:
+			passphrase, err = client.GetPassword(fmt.Sprintf("Password to sign with '%s':", name), buf)
+			if err != nil {
+				return err
+			}
+			// fail now rather than on the first request
+			if _, err := kb.ExportPrivateKeyObject(name, passphrase); err != nil {
+				return err
+			}
+		case keys.TypeLedger:
+		default:
+			return fmt.Errorf("key %s is a %s key, only local and Ledger keys can be served", name, info.GetType())
+		}
+
+		policies[name] = remotesigner.KeyPolicy{
+			PubKey:          info.GetPubKey(),
+			Passphrase:      passphrase,
+			AllowedMsgTypes: strings.Split(parts[1], ","),
+		}
+	}
+
+	laddr := viper.GetString(flagLaddr)
+	if laddr == "" {
+		laddr = "unix://" + filepath.Join(viper.GetString(cli.HomeFlag), "signer", "signer.sock")
+	}
+	ln, err := remotesigner.Listen(laddr)
+	if err != nil {
+		return err
+	}
+	defer ln.Close()
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "signer")
+	logger.Info("serving keys", "laddr", laddr, "keys", len(policies))
+	return remotesigner.NewServer(kb, policies, logger).Serve(ln)
+}
@@ -3,8 +3,10 @@ package keys
 import (
 	"fmt"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"os"
 	"path/filepath"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/viper"
 
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
@@ -21,6 +23,10 @@ import (
 // KeyDBName is the directory under root where we store the keys
 const KeyDBName = "keys"
 
+// EnvKeyringPassword is the environment variable holding the master password
+// of the file keyring backend
+const EnvKeyringPassword = "KEYRING_PASSWORD"
+
 // keybase is used to make GetKeyBase a singleton
 var keybase keys.Keybase
 
@@ -101,7 +107,7 @@ func GetKeyBaseFromDir(rootDir string) (keys.Keybase, error) {
 
 func getKeyBaseFromDirWithOpts(rootDir string, o *opt.Options) (keys.Keybase, error) {
 	if keybase == nil {
-		db, err := dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+		db, err := openKeyringDB(viper.GetString(client.FlagKeyringBackend), rootDir, o)
 		if err != nil {
 			return nil, err
 		}
@@ -110,6 +116,41 @@ func getKeyBaseFromDirWithOpts(rootDir string, o *opt.Options) (keys.Keybase, er
 	return keybase, nil
 }
 
+// openKeyringDB opens the storage of the given keyring backend under rootDir.
+// The options only apply to the LevelDB backend.
+func openKeyringDB(backend string, rootDir string, o *opt.Options) (dbm.DB, error) {
+	switch backend {
+	case "", keys.BackendLevelDB:
+		return dbm.NewGoLevelDBWithOpts(KeyDBName, filepath.Join(rootDir, "keys"), o)
+	case keys.BackendFile:
+		password, err := readKeyringPassword()
+		if err != nil {
+			return nil, err
+		}
+		return keys.NewFileDB(filepath.Join(rootDir, "keyring-file", KeyDBName), password)
+	case keys.BackendPass:
+		return keys.NewPassDB(filepath.Join(rootDir, "keyring-pass"))
+	case keys.BackendMemory:
+		return dbm.NewMemDB(), nil
+	default:
+		return nil, fmt.Errorf("unknown keyring backend %q, expected one of %v", backend, keys.Backends)
+	}
+}
+
+// readKeyringPassword returns the master password of the file keyring
+// backend from the KEYRING_PASSWORD environment variable, or else prompts
+// for it on a terminal. STDIN isn't read otherwise, as it may carry the
+// passphrases of the keys.
+func readKeyringPassword() (string, error) {
+	if password, ok := os.LookupEnv(EnvKeyringPassword); ok {
+		return password, nil
+	}
+	if !isatty.IsTerminal(os.Stdin.Fd()) && !isatty.IsCygwinTerminal(os.Stdin.Fd()) {
+		return "", fmt.Errorf("set %s to open the file keyring non-interactively", EnvKeyringPassword)
+	}
+	return client.GetPassword("Keyring password:", client.BufferStdin())
+}
+
 // used to set the keybase manually in test
 func SetKeyBase(kb keys.Keybase) {
 	keybase = kb
@@ -7,9 +7,12 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 
@@ -18,8 +21,10 @@ import (
 
 	client "github.com/yukimochizuki/cosmos-sdk/client"
 	keys "github.com/yukimochizuki/cosmos-sdk/client/keys"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/rpc"
 	"github.com/yukimochizuki/cosmos-sdk/client/tx"
+	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	cryptoKeys "github.com/yukimochizuki/cosmos-sdk/crypto/keys"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/mintkey"
@@ -74,7 +79,7 @@ func TestKeys(t *testing.T) {
 	require.NoError(t, err, "Failed to return a correct bech32 address")
 
 	// test if created account is the correct account
-	expectedInfo, _ := GetKeyBase(t).CreateKey(newName, seed, newPassword)
+	expectedInfo, _ := GetKeyBase(t).CreateKey(newName, seed, newPassword, cryptoKeys.Secp256k1)
 	expectedAccount := sdk.AccAddress(expectedInfo.GetPubKey().Address().Bytes())
 	require.Equal(t, expectedAccount.String(), addr2Bech32)
 
@@ -150,6 +155,41 @@ func TestVersion(t *testing.T) {
 	require.True(t, match, body)
 }
 
+func TestSwagger(t *testing.T) {
+	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{})
+	defer cleanup()
+
+	res, body := Request(t, port, "GET", "/swagger", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var doc openapi.Document
+	require.Nil(t, json.Unmarshal([]byte(body), &doc))
+	require.Equal(t, "2.0", doc.Swagger)
+
+	send := doc.Paths["/bank/accounts/{address}/transfers"]["post"]
+	require.NotNil(t, send)
+	require.Equal(t, []string{"ICS20"}, send.Tags)
+	require.Contains(t, doc.Paths["/stake/validators/{validatorAddr}"], "get")
+	require.NotEmpty(t, doc.Definitions)
+
+	// all the routes of the LCD are documented
+	err := createHandler(cdc).Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || path == "/swagger" {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		for _, method := range methods {
+			require.Contains(t, doc.Paths[path], strings.ToLower(method), "%s %s is not documented", method, path)
+		}
+		return nil
+	})
+	require.Nil(t, err)
+}
+
 func TestNodeStatus(t *testing.T) {
 	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{})
 	defer cleanup()
@@ -235,6 +275,56 @@ func TestValidators(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
 }
 
+func TestEvents(t *testing.T) {
+	name, password := "test", "1234567890"
+	addr, seed := CreateAddr(t, "test", password, GetKeyBase(t))
+	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+
+	conn, _, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://localhost:%s/websocket", port), nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// new blocks
+	res := doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgSubscribe, ID: "blocks", Event: rpc.EventNewBlock})
+	require.Equal(t, rpc.MsgSubscribed, res.Type, res.Error)
+	res = readEvent(t, conn, "blocks")
+	var block struct {
+		Block struct {
+			Header struct {
+				Height int64 `json:"height,string"`
+			} `json:"header"`
+		} `json:"block"`
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &block))
+	require.NotZero(t, block.Block.Header.Height)
+
+	res = doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgUnsubscribe, ID: "blocks"})
+	require.Equal(t, rpc.MsgUnsubscribed, res.Type, res.Error)
+
+	// invalid subscriptions
+	res = doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgSubscribe, ID: "unknown", Event: "unknown"})
+	require.Equal(t, rpc.MsgError, res.Type)
+	res = doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgSubscribe, ID: "tagged",
+		Event: rpc.EventNewBlock, Tags: map[string]string{"sender": addr.String()}})
+	require.Equal(t, rpc.MsgError, res.Type)
+
+	// txs of a sender, decoded like the txs routes
+	res = doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgSubscribe, ID: "sends",
+		Event: rpc.EventTx, Tags: map[string]string{"sender": addr.String(), "action": "send"}})
+	require.Equal(t, rpc.MsgSubscribed, res.Type, res.Error)
+	res = doEventRequest(t, conn, rpc.EventRequest{Type: rpc.MsgSubscribe, ID: "sends-again",
+		Event: rpc.EventTx, Tags: map[string]string{"action": "send", "sender": addr.String()}})
+	require.Equal(t, rpc.MsgError, res.Type)
+
+	_, resultTx := doSend(t, port, seed, name, password, addr)
+	res = readEvent(t, conn, "sends")
+	var info tx.Info
+	require.NoError(t, cdc.UnmarshalJSON(res.Data, &info))
+	require.Equal(t, resultTx.Hash, info.Hash)
+	require.Equal(t, resultTx.Height, info.Height)
+}
+
 func TestCoinSend(t *testing.T) {
 	name, password := "test", "1234567890"
 	addr, seed := CreateAddr(t, "test", password, GetKeyBase(t))
@@ -303,6 +393,52 @@ func TestCoinSend(t *testing.T) {
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 }
 
+func TestCoinSendBaseReqOptions(t *testing.T) {
+	name, password := "test", "1234567890"
+	addr, _ := CreateAddr(t, "test", password, GetKeyBase(t))
+	cleanup, _, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+	sequence := getAccount(t, port, addr).GetSequence()
+
+	// simulate without password
+	res, body := doSendWithBaseReq(t, port, name, "", addr, sequence, `"simulate": true`)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var simulation utils.SimulationResponse
+	require.Nil(t, json.Unmarshal([]byte(body), &simulation))
+	require.True(t, simulation.GasEstimate > 0)
+
+	// generate without password
+	res, body = doSendWithBaseReq(t, port, name, "", addr, sequence, `"generate_only": true`)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var stdTx auth.StdTx
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &stdTx))
+	require.Equal(t, 1, len(stdTx.Msgs))
+	require.Equal(t, 0, len(stdTx.Signatures))
+
+	// invalid options are rejected with a JSON error
+	res, body = doSendWithBaseReq(t, port, name, password, addr, sequence, `"generate_only": true, "simulate": true`)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	res, body = doSendWithBaseReq(t, port, name, password, addr, sequence, `"broadcast_mode": "commit"`)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	var errRes utils.ErrorResponse
+	require.Nil(t, json.Unmarshal([]byte(body), &errRes))
+	require.Contains(t, errRes.Error, "commit")
+
+	// broadcast returning after CheckTx
+	res, body = doSendWithBaseReq(t, port, name, password, addr, sequence, `"broadcast_mode": "sync"`)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var resultTx ctypes.ResultBroadcastTx
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &resultTx))
+	require.Equal(t, uint32(0), resultTx.Code)
+	require.NotEmpty(t, resultTx.Hash)
+
+	// broadcast returning right away
+	res, body = doSendWithBaseReq(t, port, name, password, addr, sequence+1, `"broadcast_mode": "async"`)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &resultTx))
+	require.NotEmpty(t, resultTx.Hash)
+}
+
 func DisabledTestIBCTransfer(t *testing.T) {
 	name, password := "test", "1234567890"
 	addr, seed := CreateAddr(t, "test", password, GetKeyBase(t))
@@ -403,9 +539,9 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
 
 	// query empty
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=sender_bech32='%s'", "cosmos1jawd35d9aq4u76sr3fjalmcqc8hqygs90d0g0v"), nil)
-	require.Equal(t, http.StatusOK, res.StatusCode, body)
-	require.Equal(t, "[]", body)
+	searchResult := doSearchTxs(t, port, fmt.Sprintf("tag=sender_bech32='%s'", "cosmos1jawd35d9aq4u76sr3fjalmcqc8hqygs90d0g0v"))
+	require.Equal(t, 0, searchResult.TotalCount)
+	require.Empty(t, searchResult.Txs)
 
 	// create TX
 	receiveAddr, resultTx := doSend(t, port, seed, name, password, addr)
@@ -416,39 +552,65 @@ func TestTxs(t *testing.T) {
 	res, body = Request(t, port, "GET", fmt.Sprintf("/txs/%s", resultTx.Hash), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
-	var indexedTxs []tx.Info
-
 	// check if tx is queryable
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=tx.hash='%s'", resultTx.Hash), nil)
-	require.Equal(t, http.StatusOK, res.StatusCode, body)
-	require.NotEqual(t, "[]", body)
-
-	err := cdc.UnmarshalJSON([]byte(body), &indexedTxs)
-	require.NoError(t, err)
+	searchResult = doSearchTxs(t, port, fmt.Sprintf("tag=tx.hash='%s'", resultTx.Hash))
+	indexedTxs := searchResult.Txs
+	require.Equal(t, 1, searchResult.TotalCount)
 	require.Equal(t, 1, len(indexedTxs))
 
 	// XXX should this move into some other testfile for txs in general?
 	// test if created TX hash is the correct hash
 	require.Equal(t, resultTx.Hash, indexedTxs[0].Hash)
 
+	// the result of the send msg is decoded
+	require.Equal(t, 1, len(indexedTxs[0].MsgResults))
+	require.Equal(t, "bank", indexedTxs[0].MsgResults[0].Route)
+	require.True(t, indexedTxs[0].MsgResults[0].IsOK())
+
 	// query sender
 	// also tests url decoding
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=sender_bech32=%%27%s%%27", addr), nil)
-	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
-	require.NoError(t, err)
+	indexedTxs = doSearchTxs(t, port, fmt.Sprintf("tag=sender_bech32=%%27%s%%27", addr)).Txs
 	require.Equal(t, 1, len(indexedTxs), "%v", indexedTxs) // there are 2 txs created with doSend
 	require.Equal(t, resultTx.Height, indexedTxs[0].Height)
 
 	// query recipient
-	res, body = Request(t, port, "GET", fmt.Sprintf("/txs?tag=recipient_bech32='%s'", receiveAddr), nil)
-	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
-	require.NoError(t, err)
+	indexedTxs = doSearchTxs(t, port, fmt.Sprintf("tag=recipient_bech32='%s'", receiveAddr)).Txs
 	require.Equal(t, 1, len(indexedTxs))
 	require.Equal(t, resultTx.Height, indexedTxs[0].Height)
+
+	// page through the sends in both orders
+	_, resultTx2 := doSend(t, port, seed, name, password, addr)
+	tests.WaitForHeight(resultTx2.Height+1, port)
+	senderTag := fmt.Sprintf("tag=sender_bech32='%s'", addr)
+
+	searchResult = doSearchTxs(t, port, senderTag+"&limit=1&page=1")
+	require.Equal(t, 2, searchResult.TotalCount)
+	require.Equal(t, 2, searchResult.PageTotal)
+	require.Equal(t, 1, len(searchResult.Txs))
+	require.Equal(t, resultTx.Hash, searchResult.Txs[0].Hash)
+
+	searchResult = doSearchTxs(t, port, senderTag+"&limit=1&page=1&order=desc")
+	require.Equal(t, 1, len(searchResult.Txs))
+	require.Equal(t, resultTx2.Hash, searchResult.Txs[0].Hash)
+
+	searchResult = doSearchTxs(t, port, senderTag+"&limit=1&page=3")
+	require.Equal(t, 2, searchResult.TotalCount)
+	require.Empty(t, searchResult.Txs)
+
+	// height bounds
+	searchResult = doSearchTxs(t, port, fmt.Sprintf("%s&min_height=%d", senderTag, resultTx2.Height))
+	require.Equal(t, 1, searchResult.TotalCount)
+	require.Equal(t, resultTx2.Hash, searchResult.Txs[0].Hash)
+
+	searchResult = doSearchTxs(t, port, fmt.Sprintf("%s&max_height=%d", senderTag, resultTx.Height))
+	require.Equal(t, 1, searchResult.TotalCount)
+	require.Equal(t, resultTx.Hash, searchResult.Txs[0].Hash)
+
+	// invalid parameters
+	for _, query := range []string{"&limit=0", "&limit=101", "&page=0", "&order=random", "&min_height=2&max_height=1", "&page=one"} {
+		res, body = Request(t, port, "GET", "/txs?"+senderTag+query, nil)
+		require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	}
 }
 
 func TestPoolParamsQuery(t *testing.T) {
@@ -628,8 +790,7 @@ func TestSubmitProposal(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -650,8 +811,7 @@ func TestDeposit(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -684,8 +844,7 @@ func TestVote(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -732,18 +891,15 @@ func TestProposalsQuery(t *testing.T) {
 
 	// Addr1 proposes (and deposits) proposals #1 and #2
 	resultTx := doSubmitProposal(t, port, seeds[0], names[0], passwords[0], addrs[0], 5)
-	var proposalID1 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID1)
+	proposalID1 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 	resultTx = doSubmitProposal(t, port, seeds[0], names[0], passwords[0], addrs[0], 5)
-	var proposalID2 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID2)
+	proposalID2 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 
 	// Addr2 proposes (and deposits) proposals #3
 	resultTx = doSubmitProposal(t, port, seeds[1], names[1], passwords[1], addrs[1], 5)
-	var proposalID3 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID3)
+	proposalID3 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 
 	// Addr2 deposits on proposals #2 & #3
@@ -894,6 +1050,34 @@ func doSendWithGas(t *testing.T, port, seed, name, password string, addr sdk.Acc
 	return
 }
 
+// doSendWithBaseReq sends a coin to a new address with additional base_req
+// fields.
+func doSendWithBaseReq(t *testing.T, port, name, password string, addr sdk.AccAddress, sequence int64, fields string) (res *http.Response, body string) {
+	kb := client.MockKeyBase()
+	receiveInfo, _, err := kb.CreateMnemonic("receive_address", cryptoKeys.English, "1234567890", cryptoKeys.SigningAlgo("secp256k1"))
+	require.Nil(t, err)
+	receiveAddr := sdk.AccAddress(receiveInfo.GetPubKey().Address())
+
+	accnum := getAccount(t, port, addr).GetAccountNumber()
+	chainID := viper.GetString(client.FlagChainID)
+	coinbz, err := cdc.MarshalJSON(sdk.NewInt64Coin("steak", 1))
+	require.Nil(t, err)
+
+	jsonStr := []byte(fmt.Sprintf(`{
+		"amount":[%s],
+		"base_req": {
+			%s,
+			"name": "%s",
+			"password": "%s",
+			"chain_id": "%s",
+			"account_number":"%d",
+			"sequence":"%d"
+		}
+	}`, coinbz, fields, name, password, chainID, accnum, sequence))
+
+	return Request(t, port, "POST", fmt.Sprintf("/bank/accounts/%s/transfers", receiveAddr), jsonStr)
+}
+
 func doRecoverKey(t *testing.T, port, recoverName, recoverPassword, seed string) {
 	jsonStr := []byte(fmt.Sprintf(`{"password":"%s", "seed":"%s"}`, recoverPassword, seed))
 	res, body := Request(t, port, "POST", fmt.Sprintf("/keys/%s/recover", recoverName), jsonStr)
@@ -1325,6 +1509,15 @@ func getProposalsFilterStatus(t *testing.T, port string, status gov.ProposalStat
 	return proposals
 }
 
+// getProposalID returns the id of the proposal submitted by resultTx.
+func getProposalID(t *testing.T, resultTx ctypes.ResultBroadcastTxCommit) (proposalID int64) {
+	data, err := sdk.DecodeMsgData(resultTx.DeliverTx.GetData())
+	require.NoError(t, err)
+	require.Len(t, data, 1)
+	require.NoError(t, cdc.UnmarshalBinaryBare(data[0], &proposalID))
+	return proposalID
+}
+
 func doSubmitProposal(t *testing.T, port, seed, name, password string, proposerAddr sdk.AccAddress, amount int64) (resultTx ctypes.ResultBroadcastTxCommit) {
 
 	acc := getAccount(t, port, proposerAddr)
@@ -1417,3 +1610,38 @@ func doVote(t *testing.T, port, seed, name, password string, proposerAddr sdk.Ac
 
 	return results
 }
+
+func doEventRequest(t *testing.T, conn *websocket.Conn, req rpc.EventRequest) rpc.EventResponse {
+	require.NoError(t, conn.WriteJSON(req))
+	// events of other subscriptions may arrive before the reply
+	for {
+		var res rpc.EventResponse
+		require.NoError(t, conn.ReadJSON(&res))
+		if res.Type != rpc.MsgEvent {
+			require.Equal(t, req.ID, res.ID)
+			return res
+		}
+	}
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn, id string) rpc.EventResponse {
+	conn.SetReadDeadline(time.Now().Add(30 * time.Second))
+	defer conn.SetReadDeadline(time.Time{})
+	for {
+		var res rpc.EventResponse
+		require.NoError(t, conn.ReadJSON(&res))
+		require.NotEqual(t, rpc.MsgError, res.Type, res.Error)
+		if res.ID == id {
+			return res
+		}
+	}
+}
+
+func doSearchTxs(t *testing.T, port, query string) tx.SearchTxsResult {
+	res, body := Request(t, port, "GET", "/txs?"+query, nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+
+	var result tx.SearchTxsResult
+	require.NoError(t, cdc.UnmarshalJSON([]byte(body), &result))
+	return result
+}
@@ -2,21 +2,27 @@ package lcd
 
 import (
 	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/yukimochizuki/cosmos-sdk/client"
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
 	"github.com/yukimochizuki/cosmos-sdk/client/keys"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/rpc"
 	"github.com/yukimochizuki/cosmos-sdk/client/tx"
+	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
+	crkeys "github.com/yukimochizuki/cosmos-sdk/crypto/keys"
 	auth "github.com/yukimochizuki/cosmos-sdk/x/auth/client/rest"
 	bank "github.com/yukimochizuki/cosmos-sdk/x/bank/client/rest"
 	gov "github.com/yukimochizuki/cosmos-sdk/x/gov/client/rest"
 	slashing "github.com/yukimochizuki/cosmos-sdk/x/slashing/client/rest"
 	stake "github.com/yukimochizuki/cosmos-sdk/x/stake/client/rest"
+	"github.com/yukimochizuki/cosmos-sdk/version"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cobra"
@@ -30,6 +36,7 @@ const (
 	flagListenAddr         = "laddr"
 	flagCORS               = "cors"
 	flagMaxOpenConnections = "max-open"
+	flagMaxSubscriptions   = "max-subscriptions"
 	flagInsecure           = "insecure"
 	flagSSLHosts           = "ssl-hosts"
 	flagSSLCertFile        = "ssl-certfile"
@@ -118,11 +125,13 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagSSLHosts, "", "Comma-separated hostnames and IPs to generate a certificate for")
 	cmd.Flags().String(flagSSLCertFile, "", "Path to a SSL certificate file. If not supplied, a self-signed certificate will be generated.")
 	cmd.Flags().String(flagSSLKeyFile, "", "Path to a key file; ignored if a certificate file is not supplied.")
-	cmd.Flags().String(flagCORS, "", "Set the domains that can make CORS requests (* for all)")
+	cmd.Flags().String(flagCORS, "", "Set the comma-separated domains that can make CORS requests and open websocket connections (* for all)")
 	cmd.Flags().String(client.FlagChainID, "", "Chain ID of Tendermint node")
 	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "Address of the node to connect to")
 	cmd.Flags().Int(flagMaxOpenConnections, 1000, "The number of maximum open connections")
+	cmd.Flags().Int(flagMaxSubscriptions, rpc.DefaultMaxSubscriptions, "The number of maximum event subscriptions per websocket connection")
 	cmd.Flags().Bool(client.FlagTrustNode, false, "Trust connected full node (don't verify proofs for responses)")
+	cmd.Flags().String(client.FlagKeyringBackend, crkeys.BackendLevelDB, fmt.Sprintf("keyring backend to sign with, one of %v", crkeys.Backends))
 	cmd.Flags().Bool(client.FlagIndentResponse, false, "Add indent to JSON response")
 	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
 	viper.BindPFlag(client.FlagChainID, cmd.Flags().Lookup(client.FlagChainID))
@@ -132,29 +141,76 @@ func ServeCommand(cdc *codec.Codec) *cobra.Command {
 }
 
 func createHandler(cdc *codec.Codec) *mux.Router {
-	r := mux.NewRouter()
+	return NewHandler(context.NewCLIContext().WithCodec(cdc))
+}
+
+// NewHandler returns the router of the LCD, which queries and broadcasts to
+// the node of the given context. The codec of the context must be set.
+func NewHandler(cliCtx context.CLIContext) *mux.Router {
+	r := openapi.NewRouter(mux.NewRouter())
+	cdc := cliCtx.Codec
 
 	kb, err := keys.GetKeyBase() //XXX
 	if err != nil {
 		panic(err)
 	}
 
-	cliCtx := context.NewCLIContext().WithCodec(cdc)
-
-	// TODO: make more functional? aka r = keys.RegisterRoutes(r)
-	r.HandleFunc("/version", CLIVersionRequestHandler).Methods("GET")
-	r.HandleFunc("/node_version", NodeVersionRequestHandler(cliCtx)).Methods("GET")
-
-	keys.RegisterRoutes(r, cliCtx.Indent)
-	rpc.RegisterRoutes(cliCtx, r)
-	tx.RegisterRoutes(cliCtx, r, cdc)
-	auth.RegisterRoutes(cliCtx, r, cdc, "acc")
-	bank.RegisterRoutes(cliCtx, r, cdc, kb)
-	stake.RegisterRoutes(cliCtx, r, cdc, kb)
-	slashing.RegisterRoutes(cliCtx, r, cdc, kb)
-	gov.RegisterRoutes(cliCtx, r, cdc)
+	maxSubscriptions := viper.GetInt(flagMaxSubscriptions)
+	if maxSubscriptions == 0 {
+		maxSubscriptions = rpc.DefaultMaxSubscriptions
+	}
+	allowedOrigins := parseCORSOrigins(viper.GetString(flagCORS))
+
+	versionRoutes := r.WithTag("version", "Query app version")
+	versionRoutes.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/version",
+		Summary:  "Version of Gaia-lite",
+		Response: "",
+	}, CLIVersionRequestHandler)
+	versionRoutes.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/node_version",
+		Summary: "Version of the connected node",
+	}, NodeVersionRequestHandler(cliCtx))
+
+	keys.RegisterRoutes(r.WithTag("ICS1", "Key management APIs"), cliCtx.Indent)
+
+	// the errors of the routes below are written with utils.WriteErrorResponse
+	r = r.WithErrorResponse(utils.ErrorResponse{})
+
+	tmRoutes := r.WithTag("ICS0", "Tendermint APIs, such as query blocks, transactions and validatorset")
+	rpc.RegisterRoutes(cliCtx, tmRoutes)
+	rpc.RegisterEventsRoute(cliCtx, tmRoutes, maxSubscriptions, allowedOrigins)
+	tx.RegisterRoutes(cliCtx, tmRoutes, cdc)
+
+	txRoutes := r.WithTag("ICS20", "Create, sign and broadcast transactions")
+	auth.RegisterRoutes(cliCtx, txRoutes, cdc, "acc")
+	bank.RegisterRoutes(cliCtx, txRoutes, cdc, kb)
+
+	stake.RegisterRoutes(cliCtx, r.WithTag("ICS21", "Stake module APIs"), cdc, kb)
+	gov.RegisterRoutes(cliCtx, r.WithTag("ICS22", "Governance module APIs"), cdc)
+	slashing.RegisterRoutes(cliCtx, r.WithTag("ICS23", "Slashing module APIs"), cdc, kb)
+
+	// the document is generated from the routes registered above
+	doc := r.Document(openapi.Info{
+		Title:       "Gaia-Lite for Cosmos",
+		Description: "A REST interface for state queries, transaction generation, signing, and broadcast.",
+		Version:     version.GetVersion(),
+	})
+	r.Mux().HandleFunc("/swagger", openapi.DocumentHandler(doc)).Methods("GET")
+
+	return r.Mux()
+}
 
-	return r
+// parseCORSOrigins splits the comma-separated origins of the --cors flag
+func parseCORSOrigins(flag string) (origins []string) {
+	for _, origin := range strings.Split(flag, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
 }
 
 func registerSwaggerUI(r *mux.Router) {
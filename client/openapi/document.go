@@ -0,0 +1,147 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Document is a swagger 2.0 document.
+type Document struct {
+	Swagger     string                           `json:"swagger"`
+	Info        Info                             `json:"info"`
+	Tags        []Tag                            `json:"tags,omitempty"`
+	Paths       map[string]map[string]*Operation `json:"paths"`
+	Definitions map[string]*Schema               `json:"definitions,omitempty"`
+}
+
+// Info describes the API of a document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Operation documents a method of a path.
+type Operation struct {
+	Tags        []string             `json:"tags,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Produces    []string             `json:"produces,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter documents a path, query or body parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Type        string  `json:"type,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Response documents a response of an operation.
+type Response struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// path variables of mux routes, which may be followed by a pattern
+var pathVariable = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+
+// Document generates the document of the routes registered on the router.
+func (r *Router) Document(info Info) *Document {
+	doc := &Document{
+		Swagger: "2.0",
+		Info:    info,
+		Tags:    r.routes.tags,
+		Paths:   make(map[string]map[string]*Operation),
+	}
+	schemas := newSchemas()
+
+	for _, route := range r.routes.routes {
+		errorSchema := &Schema{Type: "string"}
+		if route.errorResponse != nil {
+			errorSchema = schemas.schemaOf(route.errorResponse)
+		}
+		op := &Operation{
+			Summary:     route.Summary,
+			Description: route.Description,
+			Responses: map[string]*Response{
+				"default": {Description: "Error message", Schema: errorSchema},
+			},
+		}
+		if route.tag != "" {
+			op.Tags = []string{route.tag}
+		}
+
+		for _, match := range pathVariable.FindAllStringSubmatch(route.Path, -1) {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     match[1],
+				In:       "path",
+				Required: true,
+				Type:     "string",
+			})
+		}
+		for _, param := range route.Query {
+			typ := param.Type
+			if typ == "" {
+				typ = "string"
+			}
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:        param.Name,
+				In:          "query",
+				Description: param.Description,
+				Required:    param.Required,
+				Type:        typ,
+			})
+		}
+		if route.Request != nil {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:     "body",
+				In:       "body",
+				Required: true,
+				Schema:   schemas.schemaOf(route.Request),
+			})
+		}
+
+		ok := &Response{Description: "OK"}
+		if route.Response != nil {
+			if reflect.TypeOf(route.Response).Kind() == reflect.String {
+				op.Produces = []string{"text/plain"}
+			} else {
+				op.Produces = []string{"application/json"}
+			}
+			ok.Schema = schemas.schemaOf(route.Response)
+		}
+		op.Responses["200"] = ok
+
+		path := pathVariable.ReplaceAllString(route.Path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = make(map[string]*Operation)
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	if len(schemas.definitions) > 0 {
+		doc.Definitions = schemas.definitions
+	}
+	return doc
+}
+
+// DocumentHandler serves a document as JSON.
+func DocumentHandler(doc *Document) http.HandlerFunc {
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(output)
+	}
+}
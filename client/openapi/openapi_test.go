@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+type address []byte
+
+func (a address) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(a))
+}
+
+type coin struct {
+	Denom  string `json:"denom"`
+	Amount int64  `json:"amount"`
+}
+
+// validator can't encode its zero value
+type validator struct {
+	power *int
+}
+
+func (v validator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]int{"power": *v.power})
+}
+
+type tree struct {
+	Children []tree `json:"children"`
+}
+
+type sendReq struct {
+	From     address     `json:"from"`
+	Amount   []coin      `json:"amount"`
+	Memo     string      `json:"memo,omitempty"`
+	Sequence uint32      `json:"sequence"`
+	Data     []byte      `json:"data"`
+	Time     time.Time   `json:"time"`
+	Key      fmtStringer `json:"key"`
+	Tree     *tree       `json:"tree"`
+	Ignored  string      `json:"-"`
+	Untagged bool
+	private  string
+}
+
+type fmtStringer interface {
+	String() string
+}
+
+func TestSchema(t *testing.T) {
+	schemas := newSchemas()
+	schema := schemas.schemaOf(sendReq{})
+	require.Equal(t, &Schema{Ref: "#/definitions/openapi.sendReq"}, schema)
+
+	req := schemas.definitions["openapi.sendReq"]
+	require.NotNil(t, req)
+	require.Equal(t, map[string]*Schema{
+		"from":     {Type: "string"},
+		"amount":   {Type: "array", Items: &Schema{Ref: "#/definitions/openapi.coin"}},
+		"memo":     {Type: "string"},
+		"sequence": {Type: "integer", Format: "int32"},
+		"data":     {Type: "string", Format: "byte"},
+		"time":     {Type: "string", Format: "date-time"},
+		"key": {Type: "object", Properties: map[string]*Schema{
+			"type":  {Type: "string"},
+			"value": {},
+		}},
+		"tree":     {Ref: "#/definitions/openapi.tree"},
+		"Untagged": {Type: "boolean"},
+	}, req.Properties)
+
+	require.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{
+		"denom":  {Type: "string"},
+		"amount": {Type: "string", Format: "int64"},
+	}}, schemas.definitions["openapi.coin"])
+
+	require.Equal(t, &Schema{Type: "object"}, schemas.schemaOf(validator{}))
+	require.Equal(t, &Schema{Type: "string"}, schemas.schemaOf(address{}))
+
+	// recursive types reference their own definition
+	require.Equal(t, &Schema{Type: "object", Properties: map[string]*Schema{
+		"children": {Type: "array", Items: &Schema{Ref: "#/definitions/openapi.tree"}},
+	}}, schemas.definitions["openapi.tree"])
+}
+
+func TestDocument(t *testing.T) {
+	m := mux.NewRouter()
+	root := NewRouter(m)
+	r := root.WithErrorResponse(coin{}).WithTag("bank", "Bank module APIs")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}
+
+	r.HandleFunc(Route{
+		Method:   "POST",
+		Path:     "/bank/accounts/{address}/transfers",
+		Summary:  "Send coins",
+		Request:  sendReq{},
+		Response: coin{},
+	}, handler)
+	root.HandleFunc(Route{
+		Method:   "GET",
+		Path:     "/blocks/{height:[0-9]+}",
+		Query:    []Param{{Name: "limit", Type: "integer"}},
+		Response: "",
+	}, handler)
+	require.Len(t, root.Routes(), 2)
+
+	// the routes are served
+	res := httptest.NewRecorder()
+	m.ServeHTTP(res, httptest.NewRequest("POST", "/bank/accounts/cosmos1/transfers", nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	res = httptest.NewRecorder()
+	m.ServeHTTP(res, httptest.NewRequest("GET", "/bank/accounts/cosmos1/transfers", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, res.Code)
+
+	doc := root.Document(Info{Title: "test", Version: "1"})
+	require.Equal(t, []Tag{{Name: "bank", Description: "Bank module APIs"}}, doc.Tags)
+
+	send := doc.Paths["/bank/accounts/{address}/transfers"]["post"]
+	require.NotNil(t, send)
+	require.Equal(t, []string{"bank"}, send.Tags)
+	require.Equal(t, []Parameter{
+		{Name: "address", In: "path", Required: true, Type: "string"},
+		{Name: "body", In: "body", Required: true, Schema: &Schema{Ref: "#/definitions/openapi.sendReq"}},
+	}, send.Parameters)
+	require.Equal(t, &Schema{Ref: "#/definitions/openapi.coin"}, send.Responses["200"].Schema)
+	require.Equal(t, &Schema{Ref: "#/definitions/openapi.coin"}, send.Responses["default"].Schema)
+	require.Contains(t, doc.Definitions, "openapi.sendReq")
+
+	block := doc.Paths["/blocks/{height}"]["get"]
+	require.NotNil(t, block)
+	require.Equal(t, []string{"text/plain"}, block.Produces)
+	require.Equal(t, &Schema{Type: "string"}, block.Responses["default"].Schema)
+	require.Equal(t, []Parameter{
+		{Name: "height", In: "path", Required: true, Type: "string"},
+		{Name: "limit", In: "query", Type: "integer"},
+	}, block.Parameters)
+
+	// the document is served as JSON
+	res = httptest.NewRecorder()
+	DocumentHandler(doc)(res, httptest.NewRequest("GET", "/swagger", nil))
+	var served map[string]interface{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &served))
+	require.Equal(t, "2.0", served["swagger"])
+}
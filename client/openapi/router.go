@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Route documents a REST route of the LCD. Request and Response hold values of
+// the types of the request and response bodies, their schemas are derived
+// from their amino JSON encoding. A string Response documents a plain text
+// response.
+type Route struct {
+	Method      string
+	Path        string
+	Summary     string
+	Description string
+	Query       []Param
+	Request     interface{}
+	Response    interface{}
+
+	tag           string
+	errorResponse interface{}
+}
+
+// Param documents a query parameter. Type is a swagger primitive type,
+// string by default.
+type Param struct {
+	Name        string
+	Description string
+	Type        string
+	Required    bool
+}
+
+// Tag groups the routes of a module in the document.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// routes are shared by a router and the routers derived from it
+type routes struct {
+	tags   []Tag
+	routes []Route
+}
+
+// Router registers the routes of the LCD on a mux.Router along with their
+// documentation, so that the document served by the LCD can't drift from the
+// routes it serves.
+type Router struct {
+	mux           *mux.Router
+	tag           string
+	errorResponse interface{}
+	routes        *routes
+}
+
+// NewRouter returns a Router registering routes on r.
+func NewRouter(r *mux.Router) *Router {
+	return &Router{
+		mux:    r,
+		routes: &routes{},
+	}
+}
+
+// WithTag returns a router registering routes on the same mux.Router, which
+// are grouped under the given tag in the document.
+func (r *Router) WithTag(name, description string) *Router {
+	found := false
+	for _, tag := range r.routes.tags {
+		if tag.Name == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		r.routes.tags = append(r.routes.tags, Tag{Name: name, Description: description})
+	}
+
+	return &Router{
+		mux:           r.mux,
+		tag:           name,
+		errorResponse: r.errorResponse,
+		routes:        r.routes,
+	}
+}
+
+// WithErrorResponse returns a router registering routes on the same
+// mux.Router, whose error responses are documented with the type of v rather
+// than as plain text.
+func (r *Router) WithErrorResponse(v interface{}) *Router {
+	return &Router{
+		mux:           r.mux,
+		tag:           r.tag,
+		errorResponse: v,
+		routes:        r.routes,
+	}
+}
+
+// HandleFunc registers the handler of a route and records its documentation.
+func (r *Router) HandleFunc(route Route, handler http.HandlerFunc) {
+	r.mux.HandleFunc(route.Path, handler).Methods(route.Method)
+
+	route.tag = r.tag
+	route.errorResponse = r.errorResponse
+	r.routes.routes = append(r.routes.routes, route)
+}
+
+// Mux returns the underlying mux.Router, e.g. to register routes which are
+// not part of the API such as static files.
+func (r *Router) Mux() *mux.Router {
+	return r.mux
+}
+
+// Routes returns the documented routes, in the order they were registered.
+func (r *Router) Routes() []Route {
+	return r.routes.routes
+}
@@ -0,0 +1,208 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is a swagger 2.0 schema object.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// schemas derives the schemas of the amino JSON encoding of Go types. Named
+// struct types are recorded as definitions and referenced.
+type schemas struct {
+	names       map[reflect.Type]string
+	definitions map[string]*Schema
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		names:       make(map[reflect.Type]string),
+		definitions: make(map[string]*Schema),
+	}
+}
+
+// schemaOf returns the schema of the amino JSON encoding of the type of v.
+func (s *schemas) schemaOf(v interface{}) *Schema {
+	return s.schema(reflect.TypeOf(v))
+}
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+
+	case t.Kind() == reflect.Interface:
+		if t.NumMethod() == 0 {
+			return &Schema{}
+		}
+		// registered concrete types are wrapped with their amino name
+		return &Schema{
+			Type: "object",
+			Properties: map[string]*Schema{
+				"type":  {Type: "string"},
+				"value": {},
+			},
+		}
+
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		return marshalerSchema(t)
+	}
+
+	// types encoded through an amino representation
+	if m, ok := reflect.PtrTo(t).MethodByName("MarshalAmino"); ok && m.Type.NumOut() == 2 {
+		return s.schema(m.Type.Out(0))
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+
+	case reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		// amino encodes 64 bit integers as strings
+		return &Schema{Type: "string", Format: "int64"}
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+
+	case reflect.String:
+		return &Schema{Type: "string"}
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+
+	case reflect.Struct:
+		if t.Name() == "" {
+			return s.structSchema(t)
+		}
+		return &Schema{Ref: "#/definitions/" + s.define(t)}
+	}
+
+	return &Schema{}
+}
+
+// define records the schema of a named struct type and returns its name.
+func (s *schemas) define(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+
+	name := s.definitionName(t)
+	s.names[t] = name
+	// the name is recorded first for recursive types
+	s.definitions[name] = s.structSchema(t)
+	return name
+}
+
+// definitionName names a type after its package, prefixed with the parent
+// directories of the package as long as the name is taken by another type.
+func (s *schemas) definitionName(t reflect.Type) string {
+	dirs := strings.Split(t.PkgPath(), "/")
+	name := t.Name()
+	for i := len(dirs) - 1; i >= 0; i-- {
+		name = dirs[i] + "." + name
+		if !s.taken(name) {
+			break
+		}
+	}
+	return name
+}
+
+func (s *schemas) taken(name string) bool {
+	for _, n := range s.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// structSchema returns the schema of the fields of a struct. As amino does,
+// embedded structs are encoded as regular fields rather than flattened.
+func (s *schemas) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		schema.Properties[name] = s.schema(field.Type)
+	}
+	return schema
+}
+
+// marshalerSchema returns the schema of a type implementing json.Marshaler,
+// guessed from the encoding of its zero value. Most of them, e.g. addresses,
+// sdk.Int or sdk.Dec, are encoded as strings.
+func marshalerSchema(t reflect.Type) (schema *Schema) {
+	// the zero value may not be encoded, e.g. a validator without public key
+	schema = &Schema{Type: "string"}
+	if t.Kind() == reflect.Struct {
+		schema = &Schema{Type: "object"}
+	}
+	defer func() {
+		// the default schema is returned on panics
+		_ = recover()
+	}()
+
+	v := reflect.New(t)
+	var m json.Marshaler
+	if t.Implements(jsonMarshalerType) {
+		m = v.Elem().Interface().(json.Marshaler)
+	} else {
+		m = v.Interface().(json.Marshaler)
+	}
+	bz, err := m.MarshalJSON()
+	if err != nil || len(bz) == 0 {
+		return schema
+	}
+
+	switch bz[0] {
+	case '{':
+		return &Schema{Type: "object"}
+	case '[':
+		return &Schema{Type: "array", Items: &Schema{}}
+	case '"':
+		return &Schema{Type: "string"}
+	case 't', 'f':
+		return &Schema{Type: "boolean"}
+	case 'n':
+		return schema
+	}
+	return &Schema{Type: "number"}
+}
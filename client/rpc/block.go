@@ -13,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	tmliteProxy "github.com/tendermint/tendermint/lite/proxy"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 //BlockCommand returns the verified block data for a given heights
@@ -63,6 +64,11 @@ func getBlock(cliCtx context.CLIContext, height *int64) ([]byte, error) {
 		}
 	}
 
+	return marshalBlock(cliCtx, res)
+}
+
+// marshalBlock encodes a block as the blocks routes return it
+func marshalBlock(cliCtx context.CLIContext, res *ctypes.ResultBlock) ([]byte, error) {
 	if cliCtx.Indent {
 		return cdc.MarshalJSONIndent(res, "", "  ")
 	}
@@ -127,8 +133,7 @@ func BlockRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 		}
 		output, err := getBlock(cliCtx, &height)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 		utils.PostProcessResponse(w, cdc, output, cliCtx.Indent)
@@ -140,14 +145,12 @@ func LatestBlockRequestHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		height, err := GetChainHeight(cliCtx)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 		output, err := getBlock(cliCtx, &height)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 		utils.PostProcessResponse(w, cdc, output, cliCtx.Indent)
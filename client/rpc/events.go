@@ -0,0 +1,430 @@
+package rpc
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/pkg/errors"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
+	"github.com/yukimochizuki/cosmos-sdk/client/tx"
+)
+
+// Events that can be subscribed to over the websocket
+const (
+	EventNewBlock            = "new_block"
+	EventTx                  = "tx"
+	EventValidatorSetUpdates = "validator_set_updates"
+)
+
+// Types of the messages exchanged over the websocket
+const (
+	MsgSubscribe    = "subscribe"
+	MsgUnsubscribe  = "unsubscribe"
+	MsgSubscribed   = "subscribed"
+	MsgUnsubscribed = "unsubscribed"
+	MsgEvent        = "event"
+	MsgError        = "error"
+)
+
+// DefaultMaxSubscriptions is the default limit of subscriptions per websocket
+// connection
+const DefaultMaxSubscriptions = 10
+
+const (
+	eventsSubscriber  = "lcd"
+	eventsBufferSize  = 100
+	eventsWriteWait   = 10 * time.Second
+	eventsPongWait    = 60 * time.Second
+	eventsPingPeriod  = eventsPongWait * 9 / 10
+	eventsReadLimit   = 4096
+	eventsNodeTimeout = 10 * time.Second
+)
+
+var eventTagRegexp = regexp.MustCompile(`^[a-zA-Z0-9_.\-]+$`)
+
+// EventRequest is sent by websocket clients to subscribe to events, or to
+// cancel a subscription. Tags filter the transactions of tx subscriptions,
+// e.g. {"action": "send", "recipient": "cosmos1..."}.
+type EventRequest struct {
+	Type  string            `json:"type"`
+	ID    string            `json:"id"`
+	Event string            `json:"event,omitempty"`
+	Tags  map[string]string `json:"tags,omitempty"`
+}
+
+// EventResponse is sent to websocket clients to acknowledge their requests
+// and to deliver the events of their subscriptions. Data holds the block,
+// the transaction or the validator updates, encoded as the REST routes do.
+type EventResponse struct {
+	Type  string          `json:"type"`
+	ID    string          `json:"id,omitempty"`
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// RegisterEventsRoute registers the websocket endpoint to subscribe to
+// events of the node. Browsers may only open connections from the origins
+// allowed to make CORS requests, "*" allowing all of them.
+func RegisterEventsRoute(cliCtx context.CLIContext, r *openapi.Router, maxSubscriptions int, allowedOrigins []string) {
+	r.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/websocket",
+		Summary: "Subscribe to events of the node over a websocket connection",
+		Description: "Clients send subscribe and unsubscribe requests to the new_block, tx and " +
+			"validator_set_updates events as JSON messages, the events are delivered as JSON " +
+			"messages of type event.",
+	}, EventsRequestHandlerFn(cliCtx, maxSubscriptions, allowedOrigins))
+}
+
+// EventsRequestHandlerFn upgrades requests to websocket connections, on which
+// events of the node are delivered for the subscriptions of the client.
+func EventsRequestHandlerFn(cliCtx context.CLIContext, maxSubscriptions int, allowedOrigins []string) http.HandlerFunc {
+	upgrader := websocket.Upgrader{CheckOrigin: originChecker(allowedOrigins)}
+	return func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader already replied with an error
+			return
+		}
+		defer conn.Close()
+
+		// subscriptions of a websocket client to the node are keyed by query,
+		// so each connection uses its own
+		node := rpcclient.NewHTTP(cliCtx.NodeURI, "/websocket")
+		if err := node.Start(); err != nil {
+			conn.WriteJSON(EventResponse{Type: MsgError, Error: err.Error()})
+			return
+		}
+		defer node.Stop()
+
+		s := &eventsSession{
+			cliCtx:           cliCtx,
+			node:             node,
+			conn:             conn,
+			maxSubscriptions: maxSubscriptions,
+			subscriptions:    make(map[string]*eventSubscription),
+			send:             make(chan EventResponse, eventsBufferSize),
+			quit:             make(chan struct{}),
+		}
+		s.run()
+	}
+}
+
+// originChecker accepts the requests without an Origin header, which are not
+// sent by browsers, the requests from the host of the LCD itself and the
+// requests from the allowed origins, given as domains or as origins with a
+// scheme.
+func originChecker(allowedOrigins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		u, err := url.Parse(origin)
+		if err != nil {
+			return false
+		}
+		if strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if allowed == "*" || strings.EqualFold(allowed, origin) || strings.EqualFold(allowed, u.Host) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+type eventSubscription struct {
+	event string
+	query string
+	quit  chan struct{}
+}
+
+// eventsSession serves the subscriptions of one websocket connection
+type eventsSession struct {
+	cliCtx           context.CLIContext
+	node             rpcclient.Client
+	conn             *websocket.Conn
+	maxSubscriptions int
+
+	mtx           sync.Mutex
+	subscriptions map[string]*eventSubscription
+
+	send chan EventResponse
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+func (s *eventsSession) run() {
+	s.wg.Add(1)
+	go s.writeLoop()
+
+	s.readLoop()
+
+	close(s.quit)
+	s.mtx.Lock()
+	for id, sub := range s.subscriptions {
+		s.unsubscribe(id, sub)
+	}
+	s.mtx.Unlock()
+	s.wg.Wait()
+}
+
+func (s *eventsSession) readLoop() {
+	s.conn.SetReadLimit(eventsReadLimit)
+	s.conn.SetReadDeadline(time.Now().Add(eventsPongWait))
+	s.conn.SetPongHandler(func(string) error {
+		return s.conn.SetReadDeadline(time.Now().Add(eventsPongWait))
+	})
+
+	for {
+		_, bz, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var req EventRequest
+		if err := json.Unmarshal(bz, &req); err != nil {
+			s.reply(EventResponse{Type: MsgError, Error: fmt.Sprintf("invalid request: %v", err)})
+			continue
+		}
+		s.reply(s.handleRequest(req))
+	}
+}
+
+// writeLoop serializes the writes to the connection and pings the client
+func (s *eventsSession) writeLoop() {
+	defer s.wg.Done()
+	ticker := time.NewTicker(eventsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case res := <-s.send:
+			s.conn.SetWriteDeadline(time.Now().Add(eventsWriteWait))
+			if err := s.conn.WriteJSON(res); err != nil {
+				s.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			s.conn.SetWriteDeadline(time.Now().Add(eventsWriteWait))
+			if err := s.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				s.conn.Close()
+				return
+			}
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+func (s *eventsSession) reply(res EventResponse) {
+	select {
+	case s.send <- res:
+	case <-s.quit:
+	}
+}
+
+func (s *eventsSession) handleRequest(req EventRequest) EventResponse {
+	if req.ID == "" {
+		return EventResponse{Type: MsgError, Error: "request has no id"}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	switch req.Type {
+	case MsgSubscribe:
+		if err := s.subscribe(req); err != nil {
+			return EventResponse{Type: MsgError, ID: req.ID, Error: err.Error()}
+		}
+		return EventResponse{Type: MsgSubscribed, ID: req.ID, Event: req.Event}
+
+	case MsgUnsubscribe:
+		sub, ok := s.subscriptions[req.ID]
+		if !ok {
+			return EventResponse{Type: MsgError, ID: req.ID, Error: fmt.Sprintf("no subscription %s", req.ID)}
+		}
+		if err := s.unsubscribe(req.ID, sub); err != nil {
+			return EventResponse{Type: MsgError, ID: req.ID, Error: err.Error()}
+		}
+		return EventResponse{Type: MsgUnsubscribed, ID: req.ID, Event: sub.event}
+
+	default:
+		return EventResponse{Type: MsgError, ID: req.ID, Error: fmt.Sprintf("unknown request type %q", req.Type)}
+	}
+}
+
+func (s *eventsSession) subscribe(req EventRequest) error {
+	if _, ok := s.subscriptions[req.ID]; ok {
+		return fmt.Errorf("subscription %s already exists", req.ID)
+	}
+	if len(s.subscriptions) >= s.maxSubscriptions {
+		return fmt.Errorf("too many subscriptions, at most %d are allowed", s.maxSubscriptions)
+	}
+
+	query, err := EventQuery(req.Event, req.Tags)
+	if err != nil {
+		return err
+	}
+	for id, sub := range s.subscriptions {
+		if sub.query == query {
+			return fmt.Errorf("subscription %s already has the same query", id)
+		}
+	}
+	q, err := tmquery.New(query)
+	if err != nil {
+		return err
+	}
+
+	out := make(chan interface{}, eventsBufferSize)
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), eventsNodeTimeout)
+	defer cancel()
+	if err := s.node.Subscribe(ctx, eventsSubscriber, q, out); err != nil {
+		return errors.Wrap(err, "failed to subscribe to the node")
+	}
+
+	sub := &eventSubscription{event: req.Event, query: query, quit: make(chan struct{})}
+	s.subscriptions[req.ID] = sub
+	s.wg.Add(1)
+	go s.forward(req.ID, sub, out)
+	return nil
+}
+
+func (s *eventsSession) unsubscribe(id string, sub *eventSubscription) error {
+	delete(s.subscriptions, id)
+	close(sub.quit)
+
+	ctx, cancel := gocontext.WithTimeout(gocontext.Background(), eventsNodeTimeout)
+	defer cancel()
+	return s.node.Unsubscribe(ctx, eventsSubscriber, tmquery.MustParse(sub.query))
+}
+
+// forward delivers the events of a subscription until it is cancelled
+func (s *eventsSession) forward(id string, sub *eventSubscription, out <-chan interface{}) {
+	defer s.wg.Done()
+	for {
+		select {
+		case data, ok := <-out:
+			if !ok {
+				return
+			}
+			res := EventResponse{Type: MsgEvent, ID: id, Event: sub.event}
+			bz, err := s.encodeEvent(data)
+			if err != nil {
+				res = EventResponse{Type: MsgError, ID: id, Event: sub.event, Error: err.Error()}
+			} else {
+				res.Data = bz
+			}
+			select {
+			case s.send <- res:
+			case <-sub.quit:
+				return
+			case <-s.quit:
+				return
+			}
+		case <-sub.quit:
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// encodeEvent encodes event data like the blocks, txs and validatorsets
+// routes encode their results
+func (s *eventsSession) encodeEvent(data interface{}) (json.RawMessage, error) {
+	switch data := data.(type) {
+	case tmtypes.EventDataNewBlock:
+		parts := data.Block.MakePartSet(tmtypes.BlockPartSizeBytes)
+		return marshalBlock(s.cliCtx, &ctypes.ResultBlock{
+			BlockMeta: tmtypes.NewBlockMeta(data.Block, parts),
+			Block:     data.Block,
+		})
+
+	case tmtypes.EventDataTx:
+		res := &ctypes.ResultTx{
+			Hash:     data.Tx.Hash(),
+			Height:   data.Height,
+			Index:    data.Index,
+			TxResult: data.Result,
+			Tx:       data.Tx,
+		}
+		infos, err := tx.FormatTxResults(s.cliCtx.Codec, []*ctypes.ResultTx{res})
+		if err != nil {
+			return nil, err
+		}
+		return s.cliCtx.Codec.MarshalJSON(infos[0])
+
+	case tmtypes.EventDataValidatorSetUpdates:
+		outputs := make([]ValidatorOutput, len(data.ValidatorUpdates))
+		for i, validator := range data.ValidatorUpdates {
+			output, err := bech32ValidatorOutput(validator)
+			if err != nil {
+				return nil, err
+			}
+			outputs[i] = output
+		}
+		return s.cliCtx.Codec.MarshalJSON(outputs)
+
+	default:
+		return nil, fmt.Errorf("unexpected event data %T", data)
+	}
+}
+
+// EventQuery returns the Tendermint query of the given event. Tags may only
+// filter tx events, and all of them must match.
+func EventQuery(event string, tags map[string]string) (string, error) {
+	var tmEvent string
+	switch event {
+	case EventNewBlock:
+		tmEvent = tmtypes.EventNewBlock
+	case EventTx:
+		tmEvent = tmtypes.EventTx
+	case EventValidatorSetUpdates:
+		tmEvent = tmtypes.EventValidatorSetUpdates
+	default:
+		return "", fmt.Errorf("unknown event %q, expected one of %s, %s or %s",
+			event, EventNewBlock, EventTx, EventValidatorSetUpdates)
+	}
+	if len(tags) > 0 && event != EventTx {
+		return "", fmt.Errorf("only %s events can be filtered by tags", EventTx)
+	}
+
+	conditions := []string{fmt.Sprintf("%s='%s'", tmtypes.EventTypeKey, tmEvent)}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	// a subscription is identified by its query, which must not depend on
+	// the order of the tags
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := tags[key]
+		if !eventTagRegexp.MatchString(key) || key == tmtypes.EventTypeKey {
+			return "", fmt.Errorf("invalid tag %q", key)
+		}
+		if value == "" || strings.ContainsAny(value, `'\`) {
+			return "", fmt.Errorf("invalid value %q of tag %s", value, key)
+		}
+		conditions = append(conditions, fmt.Sprintf("%s='%s'", key, value))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
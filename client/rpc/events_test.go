@@ -0,0 +1,32 @@
+package rpc
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginChecker(t *testing.T) {
+	cases := []struct {
+		allowed []string
+		origin  string
+		ok      bool
+	}{
+		{nil, "", true},
+		{nil, "http://localhost:1317", true},
+		{nil, "https://evil.com", false},
+		{[]string{"wallet.io"}, "https://wallet.io", true},
+		{[]string{"https://wallet.io"}, "https://wallet.io", true},
+		{[]string{"https://wallet.io"}, "http://wallet.io", false},
+		{[]string{"wallet.io"}, "https://evil.com", false},
+		{[]string{"*"}, "https://evil.com", true},
+	}
+	for i, tc := range cases {
+		r := httptest.NewRequest("GET", "http://localhost:1317/websocket", nil)
+		if tc.origin != "" {
+			r.Header.Set("Origin", tc.origin)
+		}
+		require.Equal(t, tc.ok, originChecker(tc.allowed)(r), "case %d", i)
+	}
+}
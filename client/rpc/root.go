@@ -1,13 +1,15 @@
 package rpc
 
 import (
-	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/yukimochizuki/cosmos-sdk/client"
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 const (
@@ -48,11 +50,41 @@ func initClientCommand() *cobra.Command {
 }
 
 // Register REST endpoints
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
-	r.HandleFunc("/node_info", NodeInfoRequestHandlerFn(cliCtx)).Methods("GET")
-	r.HandleFunc("/syncing", NodeSyncingRequestHandlerFn(cliCtx)).Methods("GET")
-	r.HandleFunc("/blocks/latest", LatestBlockRequestHandlerFn(cliCtx)).Methods("GET")
-	r.HandleFunc("/blocks/{height}", BlockRequestHandlerFn(cliCtx)).Methods("GET")
-	r.HandleFunc("/validatorsets/latest", LatestValidatorSetRequestHandlerFn(cliCtx)).Methods("GET")
-	r.HandleFunc("/validatorsets/{height}", ValidatorSetRequestHandlerFn(cliCtx)).Methods("GET")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/node_info",
+		Summary:  "Information of the connected node",
+		Response: p2p.NodeInfo{},
+	}, NodeInfoRequestHandlerFn(cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/syncing",
+		Summary:  "Whether the connected node is catching up with the chain, true or false",
+		Response: "",
+	}, NodeSyncingRequestHandlerFn(cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/blocks/latest",
+		Summary:  "Get the latest block",
+		Response: ctypes.ResultBlock{},
+	}, LatestBlockRequestHandlerFn(cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/blocks/{height}",
+		Summary:  "Get a block at a certain height",
+		Response: ctypes.ResultBlock{},
+	}, BlockRequestHandlerFn(cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/validatorsets/latest",
+		Summary:  "Get the latest validator set",
+		Response: ResultValidatorsOutput{},
+	}, LatestValidatorSetRequestHandlerFn(cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/validatorsets/{height}",
+		Summary:  "Get the validator set at a certain height",
+		Response: ResultValidatorsOutput{},
+	}, ValidatorSetRequestHandlerFn(cliCtx))
 }
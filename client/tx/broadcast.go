@@ -9,16 +9,8 @@ import (
 	"io/ioutil"
 )
 
-const (
-	// Returns with the response from CheckTx.
-	flagSync = "sync"
-	// Returns right away, with no response
-	flagAsync = "async"
-	// Only returns error if mempool.BroadcastTx errs (ie. problem with the app) or if we timeout waiting for tx to commit.
-	flagBlock = "block"
-)
-
-// BroadcastBody Tx Broadcast Body
+// BroadcastBody Tx Broadcast Body. Return is the broadcast mode of the tx,
+// one of block, sync and async.
 type BroadcastBody struct {
 	TxBytes []byte `json:"tx"`
 	Return  string `json:"return"`
@@ -39,18 +31,11 @@ func BroadcastTxRequest(cliCtx context.CLIContext, cdc *codec.Codec) http.Handle
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
-		var res interface{}
-		switch m.Return {
-		case flagBlock:
-			res, err = cliCtx.BroadcastTx(m.TxBytes)
-		case flagSync:
-			res, err = cliCtx.BroadcastTxSync(m.TxBytes)
-		case flagAsync:
-			res, err = cliCtx.BroadcastTxAsync(m.TxBytes)
-		default:
-			utils.WriteErrorResponse(w, http.StatusInternalServerError, "unsupported return type. supported types: block, sync, async")
+		if err := utils.ValidateBroadcastMode(m.Return); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		res, err := utils.BroadcastTx(cliCtx, m.Return, m.TxBytes)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
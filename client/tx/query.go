@@ -106,20 +106,31 @@ func formatTxResult(cdc *codec.Codec, res *ctypes.ResultTx) (Info, error) {
 		return Info{}, err
 	}
 
+	// txs that fail before their messages run carry no message results, and
+	// txs committed before message results were introduced carry raw data
+	var msgResults sdk.MsgResults
+	if len(res.TxResult.Data) > 0 {
+		if decoded, err := sdk.DecodeMsgResults(res.TxResult.Data); err == nil {
+			msgResults = decoded
+		}
+	}
+
 	return Info{
-		Hash:   res.Hash,
-		Height: res.Height,
-		Tx:     tx,
-		Result: res.TxResult,
+		Hash:       res.Hash,
+		Height:     res.Height,
+		Tx:         tx,
+		Result:     res.TxResult,
+		MsgResults: msgResults,
 	}, nil
 }
 
 // Info is used to prepare info to display
 type Info struct {
-	Hash   common.HexBytes        `json:"hash"`
-	Height int64                  `json:"height"`
-	Tx     sdk.Tx                 `json:"tx"`
-	Result abci.ResponseDeliverTx `json:"result"`
+	Hash       common.HexBytes        `json:"hash"`
+	Height     int64                  `json:"height"`
+	Tx         sdk.Tx                 `json:"tx"`
+	Result     abci.ResponseDeliverTx `json:"result"`
+	MsgResults sdk.MsgResults         `json:"msg_results,omitempty"`
 }
 
 func parseTx(cdc *codec.Codec, txBytes []byte) (sdk.Tx, error) {
@@ -1,10 +1,11 @@
 package tx
 
 import (
-	"github.com/gorilla/mux"
 	"github.com/spf13/cobra"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 )
 
@@ -17,8 +18,32 @@ func AddCommands(cmd *cobra.Command, cdc *codec.Codec) {
 }
 
 // register REST routes
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
-	r.HandleFunc("/txs/{hash}", QueryTxRequestHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc("/txs", SearchTxRequestHandlerFn(cliCtx, cdc)).Methods("GET")
-	r.HandleFunc("/txs", BroadcastTxRequest(cliCtx, cdc)).Methods("POST")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/txs/{hash}",
+		Summary:  "Get a tx by its hash",
+		Response: Info{},
+	}, QueryTxRequestHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/txs",
+		Summary: "Search txs by their tags",
+		Query: []openapi.Param{
+			{Name: "tag", Required: true, Description: "key=value tag the txs match, may be repeated. Postfix the key with _bech32 to search bech32 addresses"},
+			{Name: "page", Type: "integer", Description: "Page of the results, 1 by default"},
+			{Name: "limit", Type: "integer", Description: "Number of txs per page"},
+			{Name: "order", Description: "asc or desc order of the txs by height"},
+			{Name: "min_height", Type: "integer", Description: "Minimum height of the txs"},
+			{Name: "max_height", Type: "integer", Description: "Maximum height of the txs"},
+		},
+		Response: SearchTxsResult{},
+	}, SearchTxRequestHandlerFn(cliCtx, cdc))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/txs",
+		Summary:  "Broadcast a signed tx",
+		Request:  BroadcastBody{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, BroadcastTxRequest(cliCtx, cdc))
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/yukimochizuki/cosmos-sdk/client"
@@ -17,13 +19,73 @@ import (
 
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 const (
-	flagTags = "tag"
-	flagAny  = "any"
+	flagTags      = "tag"
+	flagAny       = "any"
+	flagPage      = "page"
+	flagLimit     = "limit"
+	flagOrder     = "order"
+	flagMinHeight = "min-height"
+	flagMaxHeight = "max-height"
 )
 
+// Orders of the search results, by height
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+// Tendermint returns at most MaxSearchLimit txs per page
+const (
+	DefaultSearchLimit = 30
+	MaxSearchLimit     = 100
+)
+
+// SearchTxsParams selects a page of the txs matching all tags, within the
+// optional height bounds.
+type SearchTxsParams struct {
+	Tags      []string
+	Page      int
+	Limit     int
+	Order     string
+	MinHeight int64
+	MaxHeight int64
+}
+
+// ValidateBasic checks the search parameters
+func (p SearchTxsParams) ValidateBasic() error {
+	if len(p.Tags) == 0 {
+		return errors.New("must declare at least one tag to search")
+	}
+	if p.Page < 1 {
+		return fmt.Errorf("page must be at least 1, got %d", p.Page)
+	}
+	if p.Limit < 1 || p.Limit > MaxSearchLimit {
+		return fmt.Errorf("limit must be between 1 and %d, got %d", MaxSearchLimit, p.Limit)
+	}
+	if p.Order != OrderAsc && p.Order != OrderDesc {
+		return fmt.Errorf("order must be %s or %s, got %q", OrderAsc, OrderDesc, p.Order)
+	}
+	if p.MinHeight < 0 || p.MaxHeight < 0 ||
+		(p.MaxHeight > 0 && p.MinHeight > p.MaxHeight) {
+		return fmt.Errorf("invalid height range [%d, %d]", p.MinHeight, p.MaxHeight)
+	}
+	return nil
+}
+
+// SearchTxsResult holds a page of the txs matching a search
+type SearchTxsResult struct {
+	TotalCount int    `json:"total_count"`
+	Count      int    `json:"count"`
+	PageNumber int    `json:"page_number"`
+	PageTotal  int    `json:"page_total"`
+	Limit      int    `json:"limit"`
+	Txs        []Info `json:"txs"`
+}
+
 // default client command to search through tagged transactions
 func SearchTxCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -35,19 +97,31 @@ passed to the --tags option. To match any transaction, use the --any option.
 
 For example:
 
-$ gaiacli tendermint txs --tag test1,test2
+$ gaiacli query txs --tag test1,test2
 
 will match any transaction tagged with both test1,test2. To match a transaction tagged with either
 test1 or test2, use:
 
-$ gaiacli tendermint txs --tag test1,test2 --any
+$ gaiacli query txs --tag test1,test2 --any
+
+Results are paginated and ordered by height, e.g. the 10 most recent transactions
+since height 1000:
+
+$ gaiacli query txs --tag test1 --min-height 1000 --order desc --limit 10
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			tags := viper.GetStringSlice(flagTags)
+			params := SearchTxsParams{
+				Tags:      viper.GetStringSlice(flagTags),
+				Page:      viper.GetInt(flagPage),
+				Limit:     viper.GetInt(flagLimit),
+				Order:     viper.GetString(flagOrder),
+				MinHeight: viper.GetInt64(flagMinHeight),
+				MaxHeight: viper.GetInt64(flagMaxHeight),
+			}
 
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 
-			txs, err := searchTxs(cliCtx, cdc, tags)
+			txs, err := SearchTxs(cliCtx, cdc, params)
 			if err != nil {
 				return err
 			}
@@ -76,16 +150,30 @@ $ gaiacli tendermint txs --tag test1,test2 --any
 	viper.BindPFlag(client.FlagTrustNode, cmd.Flags().Lookup(client.FlagTrustNode))
 	cmd.Flags().StringSlice(flagTags, nil, "Comma-separated list of tags that must match")
 	cmd.Flags().Bool(flagAny, false, "Return transactions that match ANY tag, rather than ALL")
+	cmd.Flags().Int(flagPage, 1, "Page of the results to return, starting at 1")
+	cmd.Flags().Int(flagLimit, DefaultSearchLimit, fmt.Sprintf("Number of results per page, at most %d", MaxSearchLimit))
+	cmd.Flags().String(flagOrder, OrderAsc, fmt.Sprintf("Order of the results by height, %s or %s", OrderAsc, OrderDesc))
+	cmd.Flags().Int64(flagMinHeight, 0, "Only return transactions at or above this height")
+	cmd.Flags().Int64(flagMaxHeight, 0, "Only return transactions at or below this height")
 	return cmd
 }
 
-func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string) ([]Info, error) {
-	if len(tags) == 0 {
-		return nil, errors.New("must declare at least one tag to search")
+// SearchTxs returns the requested page of the txs matching all the tags of
+// the search. Txs are verified unless the node is trusted.
+func SearchTxs(cliCtx context.CLIContext, cdc *codec.Codec, params SearchTxsParams) (*SearchTxsResult, error) {
+	if err := params.ValidateBasic(); err != nil {
+		return nil, err
 	}
 
 	// XXX: implement ANY
-	query := strings.Join(tags, " AND ")
+	conditions := append([]string{}, params.Tags...)
+	if params.MinHeight > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s>=%d", tmtypes.TxHeightKey, params.MinHeight))
+	}
+	if params.MaxHeight > 0 {
+		conditions = append(conditions, fmt.Sprintf("%s<=%d", tmtypes.TxHeightKey, params.MaxHeight))
+	}
+	query := strings.Join(conditions, " AND ")
 
 	// get the node
 	node, err := cliCtx.GetNode()
@@ -95,16 +183,57 @@ func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string) ([]In
 
 	prove := !cliCtx.TrustNode
 
-	// TODO: take these as args
-	page := 0
-	perPage := 100
-	res, err := node.TxSearch(query, prove, page, perPage)
+	// Tendermint sorts the matches by ascending height and pages them, so a
+	// page in descending order spans one or two of its pages. The first one
+	// also gives the total count.
+	first, err := node.TxSearch(query, prove, 1, params.Limit)
 	if err != nil {
 		return nil, err
 	}
+	total := first.TotalCount
+
+	// range of the requested page, in ascending order
+	start := min((params.Page-1)*params.Limit, total)
+	end := min(start+params.Limit, total)
+	if params.Order == OrderDesc {
+		start, end = total-end, total-start
+	}
+
+	var txs []*ctypes.ResultTx
+	if start < end {
+		firstPage, lastPage := start/params.Limit+1, (end-1)/params.Limit+1
+		for page := firstPage; page <= lastPage; page++ {
+			res := first
+			if page != 1 {
+				res, err = node.TxSearch(query, prove, page, params.Limit)
+				if err != nil {
+					return nil, err
+				}
+			}
+			txs = append(txs, res.Txs...)
+		}
+		// the matches may have changed between the pages
+		offset := start - (firstPage-1)*params.Limit
+		if offset+end-start > len(txs) {
+			return nil, errors.New("search results changed while paging, try again")
+		}
+		txs = txs[offset : offset+end-start]
+	}
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		if txs[i].Height != txs[j].Height {
+			return txs[i].Height < txs[j].Height
+		}
+		return txs[i].Index < txs[j].Index
+	})
+	if params.Order == OrderDesc {
+		for i, j := 0, len(txs)-1; i < j; i, j = i+1, j-1 {
+			txs[i], txs[j] = txs[j], txs[i]
+		}
+	}
 
 	if prove {
-		for _, tx := range res.Txs {
+		for _, tx := range txs {
 			err := ValidateTxResult(cliCtx, tx)
 			if err != nil {
 				return nil, err
@@ -112,12 +241,26 @@ func searchTxs(cliCtx context.CLIContext, cdc *codec.Codec, tags []string) ([]In
 		}
 	}
 
-	info, err := FormatTxResults(cdc, res.Txs)
+	info, err := FormatTxResults(cdc, txs)
 	if err != nil {
 		return nil, err
 	}
 
-	return info, nil
+	return &SearchTxsResult{
+		TotalCount: total,
+		Count:      len(info),
+		PageNumber: params.Page,
+		PageTotal:  (total + params.Limit - 1) / params.Limit,
+		Limit:      params.Limit,
+		Txs:        info,
+	}, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
 
 // parse the indexed txs into an array of Info
@@ -139,45 +282,102 @@ func FormatTxResults(cdc *codec.Codec, res []*ctypes.ResultTx) ([]Info, error) {
 // Search Tx REST Handler
 func SearchTxRequestHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		tag := r.FormValue("tag")
-		if tag == "" {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte("You need to provide at least a tag as a key=value pair to search for. Postfix the key with _bech32 to search bech32-encoded addresses or public keys"))
+		if err := r.ParseForm(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		keyValue := strings.Split(tag, "=")
-		key := keyValue[0]
-
-		value, err := url.QueryUnescape(keyValue[1])
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, sdk.AppendMsgToErr("could not decode address", err.Error()))
+		rawTags := r.Form["tag"]
+		if len(rawTags) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("You need to provide at least a tag as a key=value pair to search for. Postfix the key with _bech32 to search bech32-encoded addresses or public keys"))
 			return
 		}
 
-		if strings.HasSuffix(key, "_bech32") {
-			bech32address := strings.Trim(value, "'")
-			prefix := strings.Split(bech32address, "1")[0]
-			bz, err := sdk.GetFromBech32(bech32address, prefix)
+		params := SearchTxsParams{
+			Page:  1,
+			Limit: DefaultSearchLimit,
+			Order: OrderAsc,
+		}
+		for _, rawTag := range rawTags {
+			tag, err := parseTag(rawTag)
 			if err != nil {
 				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 				return
 			}
+			params.Tags = append(params.Tags, tag)
+		}
 
-			tag = strings.TrimRight(key, "_bech32") + "='" + sdk.AccAddress(bz).String() + "'"
+		var ok bool
+		if params.Page, ok = parseIntParam(w, r, "page", params.Page); !ok {
+			return
+		}
+		if params.Limit, ok = parseIntParam(w, r, "limit", params.Limit); !ok {
+			return
+		}
+		if order := r.FormValue("order"); order != "" {
+			params.Order = order
+		}
+		minHeight, ok := parseIntParam(w, r, "min_height", 0)
+		if !ok {
+			return
+		}
+		maxHeight, ok := parseIntParam(w, r, "max_height", 0)
+		if !ok {
+			return
+		}
+		params.MinHeight, params.MaxHeight = int64(minHeight), int64(maxHeight)
+		if err := params.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
-		txs, err := searchTxs(cliCtx, cdc, []string{tag})
+		txs, err := SearchTxs(cliCtx, cdc, params)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
-		if len(txs) == 0 {
-			w.Write([]byte("[]"))
-			return
+		utils.PostProcessResponse(w, cdc, txs, cliCtx.Indent)
+	}
+}
+
+// parseTag turns a key=value tag of the search route into a query condition,
+// converting bech32 values of keys ending with _bech32
+func parseTag(tag string) (string, error) {
+	keyValue := strings.SplitN(tag, "=", 2)
+	if len(keyValue) != 2 {
+		return "", fmt.Errorf("invalid tag %q, expected a key=value pair", tag)
+	}
+	key := keyValue[0]
+
+	value, err := url.QueryUnescape(keyValue[1])
+	if err != nil {
+		return "", errors.New(sdk.AppendMsgToErr("could not decode address", err.Error()))
+	}
+
+	if strings.HasSuffix(key, "_bech32") {
+		bech32address := strings.Trim(value, "'")
+		prefix := strings.Split(bech32address, "1")[0]
+		bz, err := sdk.GetFromBech32(bech32address, prefix)
+		if err != nil {
+			return "", err
 		}
 
-		utils.PostProcessResponse(w, cdc, txs, cliCtx.Indent)
+		return strings.TrimSuffix(key, "_bech32") + "='" + sdk.AccAddress(bz).String() + "'", nil
+	}
+	return tag, nil
+}
+
+func parseIntParam(w http.ResponseWriter, r *http.Request, name string, defaultIfEmpty int) (int, bool) {
+	s := r.FormValue(name)
+	if s == "" {
+		return defaultIfEmpty, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("'%s' is not a valid %s", s, name))
+		return 0, false
 	}
+	return n, true
 }
@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -10,11 +12,15 @@ import (
 
 	"github.com/yukimochizuki/cosmos-sdk/client"
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/keyerror"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	authtxb "github.com/yukimochizuki/cosmos-sdk/x/auth/client/txbuilder"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 const (
@@ -22,21 +28,63 @@ const (
 	queryArgGenerateOnly = "generate_only"
 )
 
+// Broadcast modes of the REST routes sending txs
+const (
+	// BroadcastBlock waits for the tx to be committed
+	BroadcastBlock = "block"
+	// BroadcastSync waits for the tx to pass CheckTx
+	BroadcastSync = "sync"
+	// BroadcastAsync returns as soon as the tx is sent to the node
+	BroadcastAsync = "async"
+)
+
+// TxQueryParams documents the query parameters of the routes completing txs
+// with CompleteAndBroadcastTxREST. They are superseded by the simulate and
+// generate_only fields of BaseReq.
+var TxQueryParams = []openapi.Param{
+	{Name: queryArgDryRun, Type: "boolean", Description: "Deprecated, use simulate in base_req"},
+	{Name: queryArgGenerateOnly, Type: "boolean", Description: "Deprecated, use generate_only in base_req"},
+}
+
+// ErrorResponse is the body of the error responses of the REST routes.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// SimulationResponse is the body of the responses of the REST routes
+// simulating txs.
+type SimulationResponse struct {
+	GasEstimate int64 `json:"gas_estimate"`
+}
+
 //----------------------------------------
 // Basic HTTP utilities
 
 // WriteErrorResponse prepares and writes a HTTP error
 // given a status code and an error message.
 func WriteErrorResponse(w http.ResponseWriter, status int, err string) {
+	output, _ := json.Marshal(ErrorResponse{Error: err})
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
-	w.Write([]byte(err))
+	w.Write(output)
 }
 
 // WriteSimulationResponse prepares and writes an HTTP
 // response for transactions simulations.
 func WriteSimulationResponse(w http.ResponseWriter, gas int64) {
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(fmt.Sprintf(`{"gas_estimate":%v}`, gas)))
+	writeJSONResponse(w, SimulationResponse{GasEstimate: gas})
+}
+
+// writeJSONResponse writes a response encoded with encoding/json rather than
+// amino, e.g. for integers to be encoded as numbers.
+func writeJSONResponse(w http.ResponseWriter, response interface{}) {
+	output, err := json.Marshal(response)
+	if err != nil {
+		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(output)
 }
 
 // HasDryRunArg returns true if the request's URL query contains the dry run
@@ -81,24 +129,6 @@ func ParseFloat64OrReturnBadRequest(w http.ResponseWriter, s string, defaultIfEm
 	return n, true
 }
 
-// WriteGenerateStdTxResponse writes response for the generate_only mode.
-func WriteGenerateStdTxResponse(w http.ResponseWriter, txBldr authtxb.TxBuilder, msgs []sdk.Msg) {
-	stdMsg, err := txBldr.Build(msgs)
-	if err != nil {
-		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-		return
-	}
-
-	output, err := txBldr.Codec.MarshalJSON(auth.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo))
-	if err != nil {
-		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-		return
-	}
-
-	w.Write(output)
-	return
-}
-
 func urlQueryHasArg(url *url.URL, arg string) bool { return url.Query().Get(arg) == "true" }
 
 //----------------------------------------
@@ -106,6 +136,10 @@ func urlQueryHasArg(url *url.URL, arg string) bool { return url.Query().Get(arg)
 
 // BaseReq defines a structure that can be embedded in other request structures
 // that all share common "base" fields.
+//
+// Txs are signed with the key of Name and broadcast with BroadcastMode, block
+// by default. If Simulate is set, the gas of the txs is estimated instead, and
+// if GenerateOnly is set, the unsigned txs are returned instead.
 type BaseReq struct {
 	Name          string `json:"name"`
 	Password      string `json:"password"`
@@ -114,6 +148,9 @@ type BaseReq struct {
 	Sequence      int64  `json:"sequence"`
 	Gas           string `json:"gas"`
 	GasAdjustment string `json:"gas_adjustment"`
+	GenerateOnly  bool   `json:"generate_only"`
+	Simulate      bool   `json:"simulate"`
+	BroadcastMode string `json:"broadcast_mode"`
 }
 
 // Sanitize performs basic sanitization on a BaseReq object.
@@ -126,6 +163,9 @@ func (br BaseReq) Sanitize() BaseReq {
 		GasAdjustment: strings.TrimSpace(br.GasAdjustment),
 		AccountNumber: br.AccountNumber,
 		Sequence:      br.Sequence,
+		GenerateOnly:  br.GenerateOnly,
+		Simulate:      br.Simulate,
+		BroadcastMode: strings.TrimSpace(br.BroadcastMode),
 	}
 }
 
@@ -167,18 +207,38 @@ func (br BaseReq) ValidateBasic(w http.ResponseWriter) bool {
 		WriteErrorResponse(w, http.StatusUnauthorized, "name required but not specified")
 		return false
 
-	case len(br.Password) == 0:
+	case len(br.Password) == 0 && !br.GenerateOnly && !br.Simulate:
 		WriteErrorResponse(w, http.StatusUnauthorized, "password required but not specified")
 		return false
 
 	case len(br.ChainID) == 0:
 		WriteErrorResponse(w, http.StatusUnauthorized, "chainID required but not specified")
 		return false
+
+	case br.GenerateOnly && br.Simulate:
+		WriteErrorResponse(w, http.StatusBadRequest, "generate_only and simulate are exclusive")
+		return false
+	}
+
+	if err := ValidateBroadcastMode(br.BroadcastMode); err != nil {
+		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
 	}
 
 	return true
 }
 
+// ValidateBroadcastMode returns an error if mode is not a broadcast mode. An
+// empty mode stands for BroadcastBlock.
+func ValidateBroadcastMode(mode string) error {
+	switch mode {
+	case "", BroadcastBlock, BroadcastSync, BroadcastAsync:
+		return nil
+	}
+	return fmt.Errorf("unsupported broadcast mode %q, supported modes: %s, %s, %s",
+		mode, BroadcastBlock, BroadcastSync, BroadcastAsync)
+}
+
 // CompleteAndBroadcastTxREST implements a utility function that facilitates
 // sending a series of messages in a signed transaction given a TxBuilder and a
 // QueryContext. It ensures that the account exists, has a proper number and
@@ -186,8 +246,20 @@ func (br BaseReq) ValidateBasic(w http.ResponseWriter) bool {
 // supplied messages. Finally, it broadcasts the signed transaction to a node.
 //
 // NOTE: Also see CompleteAndBroadcastTxCli.
-// NOTE: Also see x/stake/client/rest/tx.go delegationsRequestHandlerFn.
 func CompleteAndBroadcastTxREST(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, baseReq BaseReq, msgs []sdk.Msg, cdc *codec.Codec) {
+	completeAndBroadcastTxsREST(w, r, cliCtx, baseReq, [][]sdk.Msg{msgs}, cdc, true)
+}
+
+// CompleteAndBroadcastTxsREST sends each group of messages in its own tx, the
+// txs having consecutive sequences starting from the one of the request. The
+// responses for the txs are written as an array.
+//
+// NOTE: The txs are not atomic, the txs following a failed one are not sent.
+func CompleteAndBroadcastTxsREST(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, baseReq BaseReq, txsMsgs [][]sdk.Msg, cdc *codec.Codec) {
+	completeAndBroadcastTxsREST(w, r, cliCtx, baseReq, txsMsgs, cdc, false)
+}
+
+func completeAndBroadcastTxsREST(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, baseReq BaseReq, txsMsgs [][]sdk.Msg, cdc *codec.Codec, single bool) {
 	simulateGas, gas, err := client.ReadGasFlag(baseReq.Gas)
 	if err != nil {
 		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -199,55 +271,158 @@ func CompleteAndBroadcastTxREST(w http.ResponseWriter, r *http.Request, cliCtx c
 		return
 	}
 
-	txBldr := authtxb.TxBuilder{
-		Codec:         cdc,
-		Gas:           gas,
-		GasAdjustment: adjustment,
-		SimulateGas:   simulateGas,
-		ChainID:       baseReq.ChainID,
-		AccountNumber: baseReq.AccountNumber,
-		Sequence:      baseReq.Sequence,
-	}
+	// the query arguments are still supported
+	simulate := baseReq.Simulate || HasDryRunArg(r)
+	generateOnly := baseReq.GenerateOnly || HasGenerateOnlyArg(r)
+
+	var (
+		estimates []SimulationResponse
+		stdTxs    []auth.StdTx
+		signedTxs [][]byte
+	)
+	for i, msgs := range txsMsgs {
+		txBldr := authtxb.TxBuilder{
+			Codec:         cdc,
+			Gas:           gas,
+			GasAdjustment: adjustment,
+			SimulateGas:   simulateGas,
+			ChainID:       baseReq.ChainID,
+			AccountNumber: baseReq.AccountNumber,
+			Sequence:      baseReq.Sequence + int64(i),
+		}
 
-	if HasDryRunArg(r) || txBldr.SimulateGas {
-		newBldr, err := EnrichCtxWithGas(txBldr, cliCtx, baseReq.Name, msgs)
-		if err != nil {
-			WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-			return
+		if simulate || txBldr.SimulateGas {
+			txBldr, err = EnrichCtxWithGas(txBldr, cliCtx, baseReq.Name, msgs)
+			if err != nil {
+				WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+				return
+			}
+
+			if simulate {
+				estimates = append(estimates, SimulationResponse{GasEstimate: txBldr.Gas})
+				continue
+			}
 		}
 
-		if HasDryRunArg(r) {
-			WriteSimulationResponse(w, newBldr.Gas)
-			return
+		if generateOnly {
+			stdMsg, err := txBldr.Build(msgs)
+			if err != nil {
+				WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			stdTxs = append(stdTxs, auth.NewStdTx(stdMsg.Msgs, stdMsg.Fee, nil, stdMsg.Memo))
+			continue
 		}
 
-		txBldr = newBldr
+		txBytes, err := txBldr.BuildAndSign(baseReq.Name, baseReq.Password, msgs)
+		if keyerror.IsErrKeyNotFound(err) {
+			WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		} else if keyerror.IsErrWrongPassword(err) {
+			WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		} else if err != nil {
+			WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		signedTxs = append(signedTxs, txBytes)
 	}
 
-	if HasGenerateOnlyArg(r) {
-		WriteGenerateStdTxResponse(w, txBldr, msgs)
+	switch {
+	case simulate && single:
+		writeJSONResponse(w, estimates[0])
 		return
-	}
-
-	txBytes, err := txBldr.BuildAndSign(baseReq.Name, baseReq.Password, msgs)
-	if keyerror.IsErrKeyNotFound(err) {
-		WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+	case simulate:
+		writeJSONResponse(w, estimates)
 		return
-	} else if keyerror.IsErrWrongPassword(err) {
-		WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+	case generateOnly && single:
+		PostProcessResponse(w, cdc, stdTxs[0], cliCtx.Indent)
 		return
-	} else if err != nil {
-		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+	case generateOnly:
+		PostProcessResponse(w, cdc, stdTxs, cliCtx.Indent)
+		return
+	}
+
+	if single {
+		res, err := BroadcastTx(cliCtx, baseReq.BroadcastMode, signedTxs[0])
+		if err != nil {
+			WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		PostProcessResponse(w, cdc, res, cliCtx.Indent)
 		return
 	}
 
-	res, err := cliCtx.BroadcastTx(txBytes)
+	results, err := broadcastTxs(cliCtx, baseReq.BroadcastMode, signedTxs)
 	if err != nil {
 		WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 		return
 	}
+	PostProcessResponse(w, cdc, results, cliCtx.Indent)
+}
+
+// broadcastTxs broadcasts signed txs in order with a broadcast mode, and
+// returns their results in a slice of the result type of the mode: amino
+// can't marshal a slice of interfaces.
+func broadcastTxs(cliCtx context.CLIContext, mode string, signedTxs [][]byte) (interface{}, error) {
+	switch mode {
+	case BroadcastSync, BroadcastAsync:
+		results := make([]*ctypes.ResultBroadcastTx, len(signedTxs))
+		for i, txBytes := range signedTxs {
+			res, err := BroadcastTx(cliCtx, mode, txBytes)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = res.(*ctypes.ResultBroadcastTx)
+		}
+		return results, nil
+
+	case "", BroadcastBlock:
+		results := make([]*ctypes.ResultBroadcastTxCommit, len(signedTxs))
+		for i, txBytes := range signedTxs {
+			res, err := BroadcastTx(cliCtx, mode, txBytes)
+			if err != nil {
+				return nil, err
+			}
+			results[i] = res.(*ctypes.ResultBroadcastTxCommit)
+		}
+		return results, nil
+	}
+
+	return nil, ValidateBroadcastMode(mode)
+}
+
+// BroadcastTx broadcasts a signed tx with a broadcast mode, block by default.
+// The tx is rejected with an error if it fails CheckTx, or DeliverTx in block
+// mode.
+func BroadcastTx(cliCtx context.CLIContext, mode string, txBytes []byte) (interface{}, error) {
+	switch mode {
+	case BroadcastSync:
+		res, err := cliCtx.BroadcastTxSync(txBytes)
+		if err != nil {
+			return nil, err
+		}
+		if res.Code != abci.CodeTypeOK {
+			return nil, errors.New(res.Log)
+		}
+		return res, nil
+
+	case BroadcastAsync:
+		res, err := cliCtx.BroadcastTxAsync(txBytes)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+
+	case "", BroadcastBlock:
+		res, err := cliCtx.BroadcastTx(txBytes)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
 
-	PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	return nil, ValidateBroadcastMode(mode)
 }
 
 // PostProcessResponse performs post process for rest response
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	"github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+)
+
+// broadcastClient accepts the txs it is sent, except those equal to reject.
+type broadcastClient struct {
+	rpcclient.Client
+	reject string
+}
+
+func (c broadcastClient) BroadcastTxCommit(tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
+	if string(tx) == c.reject {
+		return &ctypes.ResultBroadcastTxCommit{CheckTx: abci.ResponseCheckTx{Code: 1, Log: "rejected"}}, nil
+	}
+	return &ctypes.ResultBroadcastTxCommit{Hash: tx.Hash(), Height: 1}, nil
+}
+
+func (c broadcastClient) BroadcastTxSync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	if string(tx) == c.reject {
+		return &ctypes.ResultBroadcastTx{Code: 1, Log: "rejected"}, nil
+	}
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
+
+func (c broadcastClient) BroadcastTxAsync(tx types.Tx) (*ctypes.ResultBroadcastTx, error) {
+	return c.BroadcastTxSync(tx)
+}
+
+func TestBroadcastTxs(t *testing.T) {
+	cdc := app.MakeCodec()
+	cliCtx := context.NewCLIContext().WithCodec(cdc).WithClient(broadcastClient{reject: "bad"})
+	signedTxs := [][]byte{[]byte("tx1"), []byte("tx2")}
+
+	for _, mode := range []string{"", BroadcastBlock, BroadcastSync, BroadcastAsync} {
+		results, err := broadcastTxs(cliCtx, mode, signedTxs)
+		require.NoError(t, err, mode)
+
+		// the results are written as a JSON array
+		w := httptest.NewRecorder()
+		PostProcessResponse(w, cdc, results, false)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var hashes []struct {
+			Hash string `json:"hash"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hashes), mode)
+		require.Len(t, hashes, 2, mode)
+		require.Equal(t, fmt.Sprintf("%X", types.Tx("tx2").Hash()), hashes[1].Hash, mode)
+	}
+
+	// the txs following a rejected one are not sent
+	_, err := broadcastTxs(cliCtx, BroadcastSync, [][]byte{[]byte("bad"), []byte("tx2")})
+	require.Equal(t, errors.New("rejected"), err)
+
+	_, err = broadcastTxs(cliCtx, "unknown", signedTxs)
+	require.Error(t, err)
+}
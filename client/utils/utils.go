@@ -2,8 +2,12 @@ package utils
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"time"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
 	"github.com/yukimochizuki/cosmos-sdk/client/keys"
@@ -11,7 +15,9 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	authtxb "github.com/yukimochizuki/cosmos-sdk/x/auth/client/txbuilder"
 	"github.com/tendermint/go-amino"
+	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/common"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // CompleteAndBroadcastTxCli implements a utility function that
@@ -109,27 +115,43 @@ func PrintUnsignedStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msg
 // is false, it replaces the signatures already attached with the new signature.
 // Don't perform online validation or lookups if offline is true.
 func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string, stdTx auth.StdTx, appendSig bool, offline bool) (auth.StdTx, error) {
-	var signedStdTx auth.StdTx
+	signedStdTxs, err := SignStdTxs(txBldr, cliCtx, name, []auth.StdTx{stdTx}, appendSig, offline)
+	if err != nil {
+		return auth.StdTx{}, err
+	}
+	return signedStdTxs[0], nil
+}
 
+// SignStdTxs signs a batch of StdTxs sent from the same account, as SignStdTx
+// does, prompting once for the passphrase. The account number and sequence of
+// the first tx are looked up unless they are set or offline is true, and the
+// sequence is incremented for each of the following txs.
+func SignStdTxs(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string, stdTxs []auth.StdTx, appendSig bool, offline bool) ([]auth.StdTx, error) {
 	keybase, err := keys.GetKeyBase()
 	if err != nil {
-		return signedStdTx, err
+		return nil, err
 	}
 	info, err := keybase.Get(name)
 	if err != nil {
-		return signedStdTx, err
+		return nil, err
 	}
 	addr := info.GetPubKey().Address()
 
 	// Check whether the address is a signer
-	if !isTxSigner(sdk.AccAddress(addr), stdTx.GetSigners()) {
-		fmt.Fprintf(os.Stderr, "WARNING: The generated transaction's intended signer does not match the given signer: '%v'\n", name)
+	for i, stdTx := range stdTxs {
+		if !isTxSigner(sdk.AccAddress(addr), stdTx.GetSigners()) {
+			if len(stdTxs) == 1 {
+				fmt.Fprintf(os.Stderr, "WARNING: The generated transaction's intended signer does not match the given signer: '%v'\n", name)
+			} else {
+				fmt.Fprintf(os.Stderr, "WARNING: The intended signer of transaction %d does not match the given signer: '%v'\n", i, name)
+			}
+		}
 	}
 
 	if !offline && txBldr.AccountNumber == 0 {
 		accNum, err := cliCtx.GetAccountNumber(addr)
 		if err != nil {
-			return signedStdTx, err
+			return nil, err
 		}
 		txBldr = txBldr.WithAccountNumber(accNum)
 	}
@@ -137,16 +159,64 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 	if !offline && txBldr.Sequence == 0 {
 		accSeq, err := cliCtx.GetAccountSequence(addr)
 		if err != nil {
-			return signedStdTx, err
+			return nil, err
 		}
 		txBldr = txBldr.WithSequence(accSeq)
 	}
 
 	passphrase, err := keys.GetPassphrase(name)
 	if err != nil {
-		return signedStdTx, err
+		return nil, err
+	}
+
+	signedStdTxs := make([]auth.StdTx, len(stdTxs))
+	for i, stdTx := range stdTxs {
+		signedStdTxs[i], err = txBldr.WithSequence(txBldr.Sequence+int64(i)).SignStdTx(name, passphrase, stdTx, appendSig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return signedStdTxs, nil
+}
+
+// ReadStdTxs reads the StdTxs of a file holding a single JSON encoded tx, or a
+// batch of txs with one tx per line. If filename is a dash (-), the txs are
+// read from standard input.
+func ReadStdTxs(cdc *amino.Codec, filename string) ([]auth.StdTx, error) {
+	var in io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	var stdTxs []auth.StdTx
+	// consecutive JSON values are decoded whatever their layout
+	decoder := json.NewDecoder(in)
+	for {
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var stdTx auth.StdTx
+		if err := cdc.UnmarshalJSON(raw, &stdTx); err != nil {
+			return nil, fmt.Errorf("transaction %d: %v", len(stdTxs), err)
+		}
+		stdTxs = append(stdTxs, stdTx)
+	}
+
+	if len(stdTxs) == 0 {
+		return nil, errors.New("no transaction found")
 	}
-	return txBldr.SignStdTx(name, passphrase, stdTx, appendSig)
+	return stdTxs, nil
 }
 
 // nolint
@@ -195,7 +265,12 @@ func prepareTxBuilder(txBldr authtxb.TxBuilder, cliCtx context.CLIContext) (auth
 	// TODO: (ref #1903) Allow for user supplied account sequence without
 	// automatically doing a manual lookup.
 	if txBldr.Sequence == 0 {
-		accSeq, err := cliCtx.GetAccountSequence(from)
+		var accSeq int64
+		if cliCtx.Sequences != nil {
+			accSeq, err = cliCtx.Sequences.Next(from)
+		} else {
+			accSeq, err = cliCtx.GetAccountSequence(from)
+		}
 		if err != nil {
 			return txBldr, err
 		}
@@ -204,6 +279,92 @@ func prepareTxBuilder(txBldr authtxb.TxBuilder, cliCtx context.CLIContext) (auth
 	return txBldr, nil
 }
 
+// BroadcastTxWithRetry signs a tx with the next sequence of the sequence
+// manager of the context and broadcasts it synchronously. If CheckTx rejects
+// its sequence, the sequence of the account is read again from the node, then
+// the tx is signed again and rebroadcast, at most maxRetries times. If the
+// mempool of the node is full, the tx is rebroadcast after the next block.
+func BroadcastTxWithRetry(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name, passphrase string,
+	msgs []sdk.Msg, maxRetries int) (*ctypes.ResultBroadcastTx, error) {
+
+	if cliCtx.Sequences == nil {
+		return nil, errors.New("the context has no sequence manager")
+	}
+	from, err := cliCtx.GetFromAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	invalidSequence := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInvalidSequence))
+	mempoolFull := uint32(sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMempoolFull))
+
+	for retries := 0; ; retries++ {
+		seq, err := cliCtx.Sequences.Next(from)
+		if err != nil {
+			return nil, err
+		}
+		txBytes, err := txBldr.WithSequence(seq).BuildAndSign(name, passphrase, msgs)
+		if err != nil {
+			cliCtx.Sequences.Release(from, seq)
+			return nil, err
+		}
+
+		res, err := cliCtx.BroadcastTxSync(txBytes)
+		if err != nil {
+			// the tx may have reached the mempool, the next one finds out
+			cliCtx.Sequences.Resync(from)
+			return nil, err
+		}
+
+		switch {
+		case res.Code == abci.CodeTypeOK:
+			return res, nil
+
+		case res.Code == invalidSequence && retries < maxRetries:
+			// txs pending in the mempool are only counted by the node once
+			// committed, wait for them
+			cliCtx.Sequences.Resync(from)
+			if err := waitForNextBlock(cliCtx); err != nil {
+				return res, err
+			}
+
+		case res.Code == mempoolFull && retries < maxRetries:
+			cliCtx.Sequences.Release(from, seq)
+			if err := waitForNextBlock(cliCtx); err != nil {
+				return res, err
+			}
+
+		default:
+			cliCtx.Sequences.Release(from, seq)
+			return res, errors.New(res.Log)
+		}
+	}
+}
+
+// waitForNextBlock polls the node until a new block is committed.
+func waitForNextBlock(cliCtx context.CLIContext) error {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return err
+	}
+	height := status.SyncInfo.LatestBlockHeight
+
+	for {
+		time.Sleep(500 * time.Millisecond)
+		status, err := node.Status()
+		if err != nil {
+			return err
+		}
+		if status.SyncInfo.LatestBlockHeight > height {
+			return nil
+		}
+	}
+}
+
 // buildUnsignedStdTx builds a StdTx as per the parameters passed in the
 // contexts. Gas is automatically estimated if gas wanted is set to 0.
 func buildUnsignedStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg) (stdTx auth.StdTx, err error) {
@@ -2,10 +2,13 @@ package utils
 
 import (
 	"errors"
+	"io/ioutil"
+	"os"
 	"testing"
 
 	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/tendermint/tendermint/libs/common"
 )
@@ -56,3 +59,43 @@ func TestCalculateGas(t *testing.T) {
 		})
 	}
 }
+
+func TestReadStdTxs(t *testing.T) {
+	cdc := app.MakeCodec()
+	writeFile := func(content string) string {
+		f, err := ioutil.TempFile("", "txs")
+		assert.Nil(t, err)
+		_, err = f.WriteString(content)
+		assert.Nil(t, err)
+		f.Close()
+		return f.Name()
+	}
+
+	stdTx := auth.NewStdTx(nil, auth.NewStdFee(200000), nil, "")
+	compactJSON, err := cdc.MarshalJSON(stdTx)
+	assert.Nil(t, err)
+	compact := string(compactJSON)
+	indented, err := cdc.MarshalJSONIndent(stdTx, "", "  ")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		name    string
+		content string
+		wantLen int
+		wantErr bool
+	}{
+		{"single indented tx", string(indented), 1, false},
+		{"batch", compact + "\n" + compact + "\n" + compact + "\n", 3, false},
+		{"empty", "\n", 0, true},
+		{"invalid tx", compact + "\n{\"fee\":\"none\"}\n", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := writeFile(tt.content)
+			defer os.Remove(filename)
+			stdTxs, err := ReadStdTxs(cdc, filename)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantLen, len(stdTxs))
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package app
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -22,7 +21,6 @@ import (
 	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
-	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 const (
@@ -155,12 +153,20 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
 		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
 
+	// register the gauges of the modules, exported if the app has metrics
+	stake.RegisterGauges(app, app.stakeKeeper)
+	mint.RegisterGauges(app, app.mintKeeper)
+	distr.RegisterGauges(app, app.distrKeeper, app.stakeKeeper)
+	gov.RegisterGauges(app, app.govKeeper)
+	slashing.RegisterGauges(app, app.slashingKeeper)
+
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyMint, app.keyDistr,
 		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper, app.feeCollectionKeeper))
+	app.SetTxPriorityFunc(auth.NewFeePriorityFunc(app.stakeKeeper.BondDenom))
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
 	app.SetEndBlocker(app.EndBlocker)
 
@@ -229,10 +235,32 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 		// return sdk.ErrGenesisParse("").TraceCause(err, "")
 	}
 
-	// load the accounts
+	// load the accounts, keeping the account numbers of an exported state.
+	// The accounts of a new chain all have the account number zero, so the
+	// accounts whose number is already taken get the numbers following the
+	// largest one.
+	var renumbered []auth.Account
+	taken := make(map[int64]bool)
+	nextAccountNumber := int64(0)
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountKeeper.GetNextAccountNumber(ctx)
+		accountNumber := acc.GetAccountNumber()
+		if taken[accountNumber] {
+			renumbered = append(renumbered, acc)
+			continue
+		}
+		taken[accountNumber] = true
+		if accountNumber >= nextAccountNumber {
+			nextAccountNumber = accountNumber + 1
+		}
+		app.accountKeeper.SetAccount(ctx, acc)
+	}
+	app.accountKeeper.SetNextAccountNumber(ctx, nextAccountNumber)
+	for _, acc := range renumbered {
+		err := acc.SetAccountNumber(app.accountKeeper.GetNextAccountNumber(ctx))
+		if err != nil {
+			panic(err)
+		}
 		app.accountKeeper.SetAccount(ctx, acc)
 	}
 
@@ -289,34 +317,6 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	}
 }
 
-// export the state of gaia for a genesis file
-func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
-	ctx := app.NewContext(true, abci.Header{})
-
-	// iterate to get the accounts
-	accounts := []GenesisAccount{}
-	appendAccount := func(acc auth.Account) (stop bool) {
-		account := NewGenesisAccountI(acc)
-		accounts = append(accounts, account)
-		return false
-	}
-	app.accountKeeper.IterateAccounts(ctx, appendAccount)
-	genState := NewGenesisState(
-		accounts,
-		stake.WriteGenesis(ctx, app.stakeKeeper),
-		mint.WriteGenesis(ctx, app.mintKeeper),
-		distr.WriteGenesis(ctx, app.distrKeeper),
-		gov.WriteGenesis(ctx, app.govKeeper),
-		slashing.GenesisState{}, // TODO create write methods
-	)
-	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
-	if err != nil {
-		return nil, nil, err
-	}
-	validators = stake.WriteValidators(ctx, app.stakeKeeper)
-	return appState, validators, nil
-}
-
 //______________________________________________________________________________________________
 
 // Combined Staking Hooks
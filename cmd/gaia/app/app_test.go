@@ -1,12 +1,16 @@
 package app
 
 import (
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
 	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
@@ -25,7 +29,9 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	genesisState := GenesisState{
 		Accounts:     genaccs,
 		StakeData:    stake.DefaultGenesisState(),
+		MintData:     mint.DefaultGenesisState(),
 		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
 		SlashingData: slashing.DefaultGenesisState(),
 	}
 
@@ -49,6 +55,37 @@ func TestGaiadExport(t *testing.T) {
 
 	// Making a new app object with the db, so that initchain hasn't been called
 	newGapp := NewGaiaApp(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), db, nil)
-	_, _, err := newGapp.ExportAppStateAndValidators()
+	_, _, err := newGapp.ExportAppStateAndValidators(false)
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
+
+	_, _, err = newGapp.ExportAppStateAndValidators(true)
+	require.NoError(t, err, "ExportAppStateAndValidators for zero height should not have an error")
+}
+
+func TestInitChainAccountNumbers(t *testing.T) {
+	gapp := NewGaiaApp(log.NewNopLogger(), db.NewMemDB(), nil)
+
+	addrs := make([]sdk.AccAddress, 4)
+	for i := range addrs {
+		addrs[i] = sdk.AccAddress([]byte(fmt.Sprintf("addr%d_______________", i)))
+	}
+	// two exported accounts with a gap between their numbers, and two new
+	// accounts without an account number
+	require.NoError(t, setGenesis(gapp,
+		&auth.BaseAccount{Address: addrs[0], AccountNumber: 7, Sequence: 3},
+		&auth.BaseAccount{Address: addrs[1]},
+		&auth.BaseAccount{Address: addrs[2], AccountNumber: 2},
+		&auth.BaseAccount{Address: addrs[3]},
+	))
+
+	ctx := gapp.NewContext(true, abci.Header{})
+	accountNumber := func(addr sdk.AccAddress) int64 {
+		return gapp.accountKeeper.GetAccount(ctx, addr).GetAccountNumber()
+	}
+	require.Equal(t, int64(7), accountNumber(addrs[0]))
+	require.Equal(t, int64(3), gapp.accountKeeper.GetAccount(ctx, addrs[0]).GetSequence())
+	require.Equal(t, int64(0), accountNumber(addrs[1]))
+	require.Equal(t, int64(2), accountNumber(addrs[2]))
+	require.Equal(t, int64(8), accountNumber(addrs[3]))
+	require.Equal(t, int64(9), gapp.accountKeeper.GetNextAccountNumber(ctx))
 }
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	"github.com/yukimochizuki/cosmos-sdk/x/bank"
+	"github.com/yukimochizuki/cosmos-sdk/x/mock"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func genEd25519PrivKey() crypto.PrivKey   { return ed25519.GenPrivKey() }
+func genSecp256k1PrivKey() crypto.PrivKey { return secp256k1.GenPrivKey() }
+
+// getSigVerifyBenchmarkApp returns a mock app with the bank module and the
+// given keys registered as genesis accounts, committed up to height 1 so
+// that account numbers are checked.
+func getSigVerifyBenchmarkApp(b *testing.B, privs []crypto.PrivKey) *mock.App {
+	mapp := mock.NewApp()
+	bank.RegisterCodec(mapp.Cdc)
+	mapp.Router().AddRoute("bank", bank.NewHandler(bank.NewBaseKeeper(mapp.AccountKeeper)))
+	if err := mapp.CompleteSetup(); err != nil {
+		b.Fatal(err)
+	}
+
+	accs := make([]auth.Account, len(privs))
+	for i, priv := range privs {
+		accs[i] = &auth.BaseAccount{
+			Address: sdk.AccAddress(priv.PubKey().Address()),
+			Coins:   sdk.Coins{sdk.NewInt64Coin("foocoin", 100000000000)},
+		}
+	}
+	mock.SetGenesis(mapp, accs)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+	return mapp
+}
+
+// genMultiSignerSendTxs returns n encoded txs, each sending one coin from
+// every signer to a new address. The signers' sequences start at 0.
+func genMultiSignerSendTxs(cdc *codec.Codec, privs []crypto.PrivKey, n int) [][]byte {
+	coins := sdk.Coins{sdk.NewInt64Coin("foocoin", 1)}
+	inputs := make([]bank.Input, len(privs))
+	for i, priv := range privs {
+		inputs[i] = bank.NewInput(sdk.AccAddress(priv.PubKey().Address()), coins)
+	}
+	total := sdk.Coins{sdk.NewInt64Coin("foocoin", int64(len(privs)))}
+	outputs := []bank.Output{bank.NewOutput(sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address()), total)}
+	msgs := []sdk.Msg{bank.NewMsgSend(inputs, outputs)}
+	fee := auth.NewStdFee(100000 * int64(len(privs)))
+
+	txs := make([][]byte, n)
+	for seq := 0; seq < n; seq++ {
+		sigs := make([]auth.StdSignature, len(privs))
+		for i, priv := range privs {
+			sig, err := priv.Sign(auth.StdSignBytes("", int64(i), int64(seq), fee, msgs, ""))
+			if err != nil {
+				panic(err)
+			}
+			sigs[i] = auth.StdSignature{
+				PubKey:        priv.PubKey(),
+				Signature:     sig,
+				AccountNumber: int64(i),
+				Sequence:      int64(seq),
+			}
+		}
+		txs[seq] = cdc.MustMarshalBinary(auth.NewStdTx(msgs, fee, sigs, ""))
+	}
+	return txs
+}
+
+// benchmarkCheckDeliverTx checks b.N txs with numSigners signers each and
+// then delivers them in a single block, as a node receiving them over the
+// mempool would.
+func benchmarkCheckDeliverTx(b *testing.B, numSigners int, genPrivKey func() crypto.PrivKey) {
+	privs := make([]crypto.PrivKey, numSigners)
+	for i := range privs {
+		privs[i] = genPrivKey()
+	}
+	mapp := getSigVerifyBenchmarkApp(b, privs)
+	txs := genMultiSignerSendTxs(mapp.Cdc, privs, b.N)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res := mapp.CheckTx(txs[i])
+		if !res.IsOK() {
+			b.Fatalf("check tx %d failed: %s", i, res.Log)
+		}
+	}
+	mapp.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 2}})
+	for i := 0; i < b.N; i++ {
+		res := mapp.DeliverTx(txs[i])
+		if !res.IsOK() {
+			b.Fatalf("deliver tx %d failed: %s", i, res.Log)
+		}
+	}
+	mapp.EndBlock(abci.RequestEndBlock{})
+	mapp.Commit()
+}
+
+func BenchmarkCheckDeliverTx1SignerEd25519(b *testing.B) {
+	benchmarkCheckDeliverTx(b, 1, genEd25519PrivKey)
+}
+
+func BenchmarkCheckDeliverTx1SignerSecp256k1(b *testing.B) {
+	benchmarkCheckDeliverTx(b, 1, genSecp256k1PrivKey)
+}
+
+func BenchmarkCheckDeliverTx5SignersEd25519(b *testing.B) {
+	benchmarkCheckDeliverTx(b, 5, genEd25519PrivKey)
+}
+
+func BenchmarkCheckDeliverTx5SignersSecp256k1(b *testing.B) {
+	benchmarkCheckDeliverTx(b, 5, genSecp256k1PrivKey)
+}
@@ -0,0 +1,136 @@
+package app
+
+import (
+	"encoding/json"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
+	stakeTypes "github.com/yukimochizuki/cosmos-sdk/x/stake/types"
+)
+
+// export the state of gaia for a genesis file
+func (app *GaiaApp) ExportAppStateAndValidators(forZeroHeight bool) (
+	appState json.RawMessage, validators []tmtypes.GenesisValidator, err error) {
+
+	// rewards are withdrawn at the height of the last committed block
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+
+	if forZeroHeight {
+		app.prepForZeroHeightGenesis(ctx)
+	}
+
+	// iterate to get the accounts
+	accounts := []GenesisAccount{}
+	appendAccount := func(acc auth.Account) (stop bool) {
+		account := NewGenesisAccountI(acc)
+		accounts = append(accounts, account)
+		return false
+	}
+	app.accountKeeper.IterateAccounts(ctx, appendAccount)
+	genState := NewGenesisState(
+		accounts,
+		stake.WriteGenesis(ctx, app.stakeKeeper),
+		mint.WriteGenesis(ctx, app.mintKeeper),
+		distr.WriteGenesis(ctx, app.distrKeeper),
+		gov.WriteGenesis(ctx, app.govKeeper),
+		slashing.WriteGenesis(ctx, app.slashingKeeper),
+	)
+
+	if forZeroHeight {
+		resetGenesisHeights(&genState)
+	}
+
+	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
+	if err != nil {
+		return nil, nil, err
+	}
+	validators = stake.WriteValidators(ctx, app.stakeKeeper)
+	return appState, validators, nil
+}
+
+// prepare the state for a fresh start at zero height: pay out everything
+// which is owed at the current height, as the accumulated rewards and the
+// proposals can't be carried over to the new chain
+func (app *GaiaApp) prepForZeroHeightGenesis(ctx sdk.Context) {
+
+	// withdraw all validator commissions and delegator rewards
+	vdis := app.distrKeeper.GetAllValidatorDistInfos(ctx)
+	ddis := app.distrKeeper.GetAllDelegationDistInfos(ctx)
+	for _, vdi := range vdis {
+		err := app.distrKeeper.WithdrawValidatorRewardsAll(ctx, vdi.OperatorAddr)
+		if err != nil {
+			panic(err)
+		}
+	}
+	for _, ddi := range ddis {
+		err := app.distrKeeper.WithdrawDelegationReward(ctx, ddi.DelegatorAddr, ddi.ValOperatorAddr)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	// the collected fees aren't exported and there is no proposer to reward,
+	// so they go to the community pool
+	feePool := app.distrKeeper.GetFeePool(ctx)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(distr.NewDecCoins(app.feeCollectionKeeper.GetCollectedFees(ctx)))
+	app.distrKeeper.SetFeePool(ctx, feePool)
+	app.feeCollectionKeeper.ClearCollectedFees(ctx)
+
+	// proposals aren't exported, so refund the deposits of pending proposals
+	for _, proposal := range app.govKeeper.GetProposalsFiltered(ctx, nil, nil, gov.StatusNil, 0) {
+		app.govKeeper.RefundDeposits(ctx, proposal.GetProposalID())
+	}
+}
+
+// rebase all the heights recorded in the exported state to zero, the height
+// at which the new chain starts
+func resetGenesisHeights(genState *GenesisState) {
+
+	// everything has been withdrawn at the current height,
+	// so all the accumulations start over at height zero
+	distrData := &genState.DistrData
+	distrData.FeePool.TotalValAccum.UpdateHeight = 0
+	for i := range distrData.ValidatorDistInfos {
+		distrData.ValidatorDistInfos[i].FeePoolWithdrawalHeight = 0
+		distrData.ValidatorDistInfos[i].DelAccum.UpdateHeight = 0
+	}
+	for i := range distrData.DelegationDistInfos {
+		distrData.DelegationDistInfos[i].DelPoolWithdrawalHeight = 0
+	}
+
+	// unbondings and redelegations were started before any infraction
+	// which can be committed on the new chain
+	stakeData := &genState.StakeData
+	for i := range stakeData.UnbondingDelegations {
+		stakeData.UnbondingDelegations[i].CreationHeight = 0
+	}
+	for i := range stakeData.Redelegations {
+		stakeData.Redelegations[i].CreationHeight = 0
+	}
+
+	// the liveness of validators is tracked from the start of the new chain,
+	// infractions can only be committed in the slashing periods in progress,
+	// which start before the genesis block like the ones of genesis validators
+	slashingData := &genState.SlashingData
+	for i := range slashingData.SigningInfos {
+		slashingData.SigningInfos[i].ValidatorSigningInfo.StartHeight = 0
+	}
+	slashingPeriods := []slashing.ValidatorSlashingPeriod{}
+	for _, slashingPeriod := range slashingData.SlashingPeriods {
+		if slashingPeriod.EndHeight != 0 {
+			continue
+		}
+		slashingPeriod.StartHeight = -stakeTypes.ValidatorUpdateDelay
+		slashingPeriods = append(slashingPeriods, slashingPeriod)
+	}
+	slashingData.SlashingPeriods = slashingPeriods
+}
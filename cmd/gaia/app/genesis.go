@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,32 +53,59 @@ func NewGenesisState(accounts []GenesisAccount, stakeData stake.GenesisState, mi
 	}
 }
 
-// GenesisAccount doesn't need pubkey or sequence
+// GenesisAccount doesn't need pubkey. The original vesting coins of a
+// vesting account vest continuously from its start time to its end time, or
+// all at its end time when it has no start time.
 type GenesisAccount struct {
-	Address sdk.AccAddress `json:"address"`
-	Coins   sdk.Coins      `json:"coins"`
+	Address       sdk.AccAddress `json:"address"`
+	Coins         sdk.Coins      `json:"coins"`
+	Sequence      int64          `json:"sequence_number"`
+	AccountNumber int64          `json:"account_number"`
+
+	OriginalVesting sdk.Coins `json:"original_vesting,omitempty"`
+	StartTime       int64     `json:"start_time,omitempty"`
+	EndTime         int64     `json:"end_time,omitempty"`
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 	return GenesisAccount{
-		Address: acc.Address,
-		Coins:   acc.Coins,
+		Address:       acc.Address,
+		Coins:         acc.Coins,
+		AccountNumber: acc.AccountNumber,
+		Sequence:      acc.Sequence,
 	}
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
-		Address: acc.GetAddress(),
-		Coins:   acc.GetCoins(),
+	gacc := GenesisAccount{
+		Address:       acc.GetAddress(),
+		Coins:         acc.GetCoins(),
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
 	}
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
-		Address: ga.Address,
-		Coins:   ga.Coins.Sort(),
+// convert GenesisAccount to auth.BaseAccount, or to a vesting account
+func (ga *GenesisAccount) ToAccount() auth.Account {
+	bacc := auth.BaseAccount{
+		Address:       ga.Address,
+		Coins:         ga.Coins.Sort(),
+		AccountNumber: ga.AccountNumber,
+		Sequence:      ga.Sequence,
+	}
+	if ga.OriginalVesting.IsZero() {
+		return &bacc
+	}
+	if ga.StartTime != 0 {
+		return auth.NewContinuousVestingAccount(bacc, ga.OriginalVesting.Sort(), ga.StartTime, ga.EndTime)
 	}
+	return auth.NewDelayedVestingAccount(bacc, ga.OriginalVesting.Sort(), ga.EndTime)
 }
 
 // get app init parameters for server init command
@@ -144,30 +172,71 @@ func genesisAccountFromMsgCreateValidator(msg stake.MsgCreateValidator, amount s
 	return NewGenesisAccount(&accAuth)
 }
 
-// GaiaValidateGenesisState ensures that the genesis state obeys the expected invariants
-// TODO: No validators are both bonded and jailed (#2088)
-// TODO: Error if there is a duplicate validator (#1708)
+// GaiaValidateGenesisState ensures that the genesis state obeys the expected
+// invariants, returning the first problem found by GaiaValidateGenesisStateAll
+func GaiaValidateGenesisState(genesisState GenesisState) error {
+	errs := GaiaValidateGenesisStateAll(genesisState)
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// GaiaValidateGenesisStateAll validates the genesis accounts and runs the
+// genesis validation of every module, returning every problem found
 // TODO: Ensure all state machine parameters are in genesis (#1704)
-func GaiaValidateGenesisState(genesisState GenesisState) (err error) {
-	err = validateGenesisStateAccounts(genesisState.Accounts)
-	if err != nil {
-		return
+func GaiaValidateGenesisStateAll(genesisState GenesisState) (errs []error) {
+	errs = validateGenesisStateAccounts(genesisState.Accounts)
+
+	modules := []struct {
+		name     string
+		validate func() error
+	}{
+		{"stake", func() error {
+			// skip stakeData validation as genesis is created from txs
+			if len(genesisState.GenTxs) > 0 {
+				return nil
+			}
+			return stake.ValidateGenesis(genesisState.StakeData)
+		}},
+		{"mint", func() error { return mint.ValidateGenesis(genesisState.MintData) }},
+		{"distr", func() error { return distr.ValidateGenesis(genesisState.DistrData) }},
+		{"gov", func() error { return gov.ValidateGenesis(genesisState.GovData) }},
+		{"slashing", func() error { return slashing.ValidateGenesis(genesisState.SlashingData) }},
 	}
-	// skip stakeData validation as genesis is created from txs
-	if len(genesisState.GenTxs) > 0 {
-		return nil
+	for _, module := range modules {
+		err := module.validate()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s genesis state: %v", module.name, err))
+		}
 	}
-	return stake.ValidateGenesis(genesisState.StakeData)
+	return errs
 }
 
-// Ensures that there are no duplicate accounts in the genesis state,
-func validateGenesisStateAccounts(accs []GenesisAccount) (err error) {
+// Ensures that there are no duplicate accounts in the genesis state, that
+// no account holds negative coins, and that vesting accounts hold their
+// vesting coins and vest them after their start time
+func validateGenesisStateAccounts(accs []GenesisAccount) (errs []error) {
 	addrMap := make(map[string]bool, len(accs))
 	for i := 0; i < len(accs); i++ {
 		acc := accs[i]
 		strAddr := string(acc.Address)
 		if _, ok := addrMap[strAddr]; ok {
-			return fmt.Errorf("Duplicate account in genesis state: Address %v", acc.Address)
+			errs = append(errs, fmt.Errorf("Duplicate account in genesis state: Address %v", acc.Address))
+		}
+		if !acc.Coins.IsNotNegative() {
+			errs = append(errs, fmt.Errorf("Negative coins in genesis state: Address %v, coins %v", acc.Address, acc.Coins))
+		}
+		if !acc.OriginalVesting.IsZero() {
+			if !acc.OriginalVesting.IsValid() || !acc.OriginalVesting.IsPositive() ||
+				!acc.Coins.IsGTE(acc.OriginalVesting) {
+				errs = append(errs, fmt.Errorf("Invalid vesting coins in genesis state: Address %v, coins %v, vesting %v",
+					acc.Address, acc.Coins, acc.OriginalVesting))
+			}
+			if acc.EndTime <= acc.StartTime {
+				errs = append(errs, fmt.Errorf("Vesting account in genesis state ends before it starts: Address %v, start %d, end %d",
+					acc.Address, acc.StartTime, acc.EndTime))
+			}
 		}
 		addrMap[strAddr] = true
 	}
@@ -248,6 +317,85 @@ func CollectStdTxs(moniker string, genTxsDir string, cdc *codec.Codec) (
 	return
 }
 
+// ValidateGenTxs verifies the signatures of the genesis transactions for the
+// given chain, and ensures that the genesis accounts of their signers hold
+// enough coins to pay their fees and self delegations. As the genesis
+// transactions are delivered at height zero, they must be signed with the
+// account number zero and the sequences of the genesis accounts, which are
+// incremented by each transaction in order.
+func ValidateGenTxs(chainID string, genesisState GenesisState, genTxs []auth.StdTx) error {
+	balances := make(map[string]sdk.Coins, len(genesisState.Accounts))
+	sequences := make(map[string]int64, len(genesisState.Accounts))
+	for _, acc := range genesisState.Accounts {
+		balances[string(acc.Address)] = acc.Coins.Sort()
+		sequences[string(acc.Address)] = acc.Sequence
+	}
+
+	for i, genTx := range genTxs {
+		msgs := genTx.GetMsgs()
+		if len(msgs) != 1 {
+			return fmt.Errorf("genesis transaction %d must provide a single genesis message", i)
+		}
+		msg, ok := msgs[0].(stake.MsgCreateValidator)
+		if !ok {
+			return fmt.Errorf("genesis transaction %d must provide a MsgCreateValidator", i)
+		}
+
+		signers := genTx.GetSigners()
+		sigs := genTx.GetSignatures()
+		if len(sigs) != len(signers) {
+			return fmt.Errorf("genesis transaction of %s must have %d signatures, has %d",
+				msg.Description.Moniker, len(signers), len(sigs))
+		}
+		for j, sig := range sigs {
+			if sig.PubKey == nil || !bytes.Equal(sig.PubKey.Address(), signers[j]) {
+				return fmt.Errorf("genesis transaction of %s must be signed by %s", msg.Description.Moniker, signers[j])
+			}
+			if sig.AccountNumber != 0 {
+				return fmt.Errorf("the genesis transaction of %s must be signed with the account number 0, got %d",
+					msg.Description.Moniker, sig.AccountNumber)
+			}
+			sequence, ok := sequences[string(signers[j])]
+			if !ok {
+				return fmt.Errorf("no genesis account for %s, which signed the genesis transaction of %s",
+					signers[j], msg.Description.Moniker)
+			}
+			if sig.Sequence != sequence {
+				return fmt.Errorf("the genesis transaction of %s must be signed by %s with the sequence %d, got %d",
+					msg.Description.Moniker, signers[j], sequence, sig.Sequence)
+			}
+			signBytes := auth.StdSignBytes(chainID, 0, sequence, genTx.Fee, msgs, genTx.GetMemo())
+			if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+				return fmt.Errorf("invalid signature of %s on the genesis transaction of %s for chain %s",
+					signers[j], msg.Description.Moniker, chainID)
+			}
+			sequences[string(signers[j])]++
+		}
+
+		// the first signer pays the fees
+		feePayer := string(signers[0])
+		if !balances[feePayer].IsGTE(genTx.Fee.Amount) {
+			return fmt.Errorf("genesis account %s has %v, can't pay the fees %v of the genesis transaction of %s",
+				signers[0], balances[feePayer], genTx.Fee.Amount, msg.Description.Moniker)
+		}
+		balances[feePayer] = balances[feePayer].Minus(genTx.Fee.Amount)
+
+		delegator := string(msg.DelegatorAddr)
+		balance, ok := balances[delegator]
+		if !ok {
+			return fmt.Errorf("no genesis account for %s, which delegates in the genesis transaction of %s",
+				msg.DelegatorAddr, msg.Description.Moniker)
+		}
+		delegation := sdk.Coins{msg.Delegation}
+		if !balance.IsGTE(delegation) {
+			return fmt.Errorf("genesis account %s has %v, can't delegate %v in the genesis transaction of %s",
+				msg.DelegatorAddr, balance, msg.Delegation, msg.Description.Moniker)
+		}
+		balances[delegator] = balance.Minus(delegation)
+	}
+	return nil
+}
+
 func NewDefaultGenesisAccount(addr sdk.AccAddress) GenesisAccount {
 	accAuth := auth.NewBaseAccountWithAddress(addr)
 	accAuth.Coins = []sdk.Coin{
@@ -5,12 +5,16 @@ import (
 
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
 	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	stakeTypes "github.com/yukimochizuki/cosmos-sdk/x/stake/types"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 var (
@@ -42,9 +46,12 @@ func makeGenesisState(t *testing.T, genTxs []auth.StdTx) GenesisState {
 
 	// create the final app state
 	return GenesisState{
-		Accounts:  genAccs,
-		StakeData: stakeData,
-		GovData:   gov.DefaultGenesisState(),
+		Accounts:     genAccs,
+		StakeData:    stakeData,
+		MintData:     mint.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		SlashingData: slashing.DefaultGenesisState(),
 	}
 }
 
@@ -53,7 +60,16 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.AccAddress(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	require.Equal(t, &authAcc, genAcc.ToAccount())
+
+	authAcc.Coins = sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	vestingAcc := auth.NewContinuousVestingAccount(authAcc, sdk.Coins{sdk.NewInt64Coin("steak", 50)}, 1000, 2000)
+	genAcc = NewGenesisAccountI(vestingAcc)
+	require.Equal(t, vestingAcc, genAcc.ToAccount())
+
+	delayedAcc := auth.NewDelayedVestingAccount(authAcc, sdk.Coins{sdk.NewInt64Coin("steak", 50)}, 2000)
+	genAcc = NewGenesisAccountI(delayedAcc)
+	require.Equal(t, delayedAcc, genAcc.ToAccount())
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
@@ -106,3 +122,102 @@ func TestGaiaGenesisValidation(t *testing.T) {
 	err = GaiaValidateGenesisState(genesisState)
 	require.NotNil(t, err)
 }
+
+func TestGaiaGenesisValidationVesting(t *testing.T) {
+	acc := GenesisAccount{
+		Address:         sdk.AccAddress(pk1.Address()),
+		Coins:           sdk.Coins{sdk.NewInt64Coin("steak", 100)},
+		OriginalVesting: sdk.Coins{sdk.NewInt64Coin("steak", 100)},
+		StartTime:       1000,
+		EndTime:         2000,
+	}
+	require.Empty(t, validateGenesisStateAccounts([]GenesisAccount{acc}))
+
+	// the vesting coins are held by the account
+	invalid := acc
+	invalid.OriginalVesting = sdk.Coins{sdk.NewInt64Coin("steak", 101)}
+	require.Len(t, validateGenesisStateAccounts([]GenesisAccount{invalid}), 1)
+	invalid.OriginalVesting = sdk.Coins{sdk.NewInt64Coin("photino", 1)}
+	require.Len(t, validateGenesisStateAccounts([]GenesisAccount{invalid}), 1)
+
+	// the vesting ends after it starts
+	invalid = acc
+	invalid.EndTime = 1000
+	require.Len(t, validateGenesisStateAccounts([]GenesisAccount{invalid}), 1)
+	invalid.StartTime = 0
+	invalid.EndTime = 0
+	require.Len(t, validateGenesisStateAccounts([]GenesisAccount{invalid}), 1)
+}
+
+func TestGaiaGenesisValidationAll(t *testing.T) {
+	genTxs := []auth.StdTx{makeMsg("test-0", pk1), makeMsg("test-1", pk1)}
+	genesisState := makeGenesisState(t, genTxs)
+	require.Empty(t, GaiaValidateGenesisStateAll(makeGenesisState(t, genTxs[:1])))
+
+	// every problem is reported
+	genesisState.GovData.TallyingProcedure.Threshold = sdk.ZeroDec()
+	genesisState.SlashingData.Params.SignedBlocksWindow = 0
+	genesisState.DistrData.CommunityTax = sdk.NewDec(2)
+	errs := GaiaValidateGenesisStateAll(genesisState)
+	require.Len(t, errs, 4)
+	require.Equal(t, GaiaValidateGenesisState(genesisState), errs[0])
+}
+
+func makeSignedGenTx(t *testing.T, chainID string, priv crypto.PrivKey, delegation int64, fee auth.StdFee) auth.StdTx {
+	return makeSignedGenTxWithSequence(t, chainID, priv, delegation, fee, 0, 0)
+}
+
+func makeSignedGenTxWithSequence(t *testing.T, chainID string, priv crypto.PrivKey, delegation int64, fee auth.StdFee,
+	accountNumber, sequence int64) auth.StdTx {
+
+	addr := sdk.ValAddress(priv.PubKey().Address())
+	msg := stake.NewMsgCreateValidator(addr, pk1, sdk.NewInt64Coin("steak", delegation),
+		stake.NewDescription("test", "", "", ""), stakeTypes.CommissionMsg{})
+	msgs := []sdk.Msg{msg}
+	sig, err := priv.Sign(auth.StdSignBytes(chainID, accountNumber, sequence, fee, msgs, ""))
+	require.NoError(t, err)
+	stdSig := auth.StdSignature{PubKey: priv.PubKey(), Signature: sig, AccountNumber: accountNumber, Sequence: sequence}
+	return auth.NewStdTx(msgs, fee, []auth.StdSignature{stdSig}, "")
+}
+
+func TestValidateGenTxs(t *testing.T) {
+	priv := secp256k1.GenPrivKey()
+	acc := auth.NewBaseAccountWithAddress(sdk.AccAddress(priv.PubKey().Address()))
+	acc.Coins = sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	genesisState := GenesisState{Accounts: []GenesisAccount{NewGenesisAccount(&acc)}}
+	fee := auth.NewStdFee(0, sdk.NewInt64Coin("steak", 10))
+
+	genTx := makeSignedGenTx(t, "test-chain", priv, 90, fee)
+	require.NoError(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+
+	// signed for another chain
+	require.Error(t, ValidateGenTxs("other-chain", genesisState, []auth.StdTx{genTx}))
+
+	// the fees and the delegations of all the gentxs of an account are paid
+	firstGenTx := makeSignedGenTx(t, "test-chain", priv, 80, fee)
+	nextGenTx := makeSignedGenTxWithSequence(t, "test-chain", priv, 10, auth.StdFee{}, 0, 1)
+	require.NoError(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{firstGenTx, nextGenTx}))
+	nextGenTx = makeSignedGenTxWithSequence(t, "test-chain", priv, 11, auth.StdFee{}, 0, 1)
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{firstGenTx, nextGenTx}))
+
+	// each gentx of an account is signed with the next sequence
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx, genTx}))
+
+	// the gentxs are signed with the account number zero and the sequence
+	// of the genesis account
+	genTx = makeSignedGenTxWithSequence(t, "test-chain", priv, 90, fee, 1, 0)
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+	genTx = makeSignedGenTxWithSequence(t, "test-chain", priv, 90, fee, 0, 1)
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+	genesisState.Accounts[0].Sequence = 1
+	require.NoError(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+	genesisState.Accounts[0].Sequence = 0
+
+	// the fees and the delegation can't exceed the coins of the account
+	genTx = makeSignedGenTx(t, "test-chain", priv, 91, fee)
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+
+	// the delegator must have a genesis account
+	genTx = makeSignedGenTx(t, "test-chain", secp256k1.GenPrivKey(), 1, auth.StdFee{})
+	require.Error(t, ValidateGenTxs("test-chain", genesisState, []auth.StdTx{genTx}))
+}
@@ -10,17 +10,23 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
+	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	authsim "github.com/yukimochizuki/cosmos-sdk/x/auth/simulation"
+	"github.com/yukimochizuki/cosmos-sdk/x/bank"
 	banksim "github.com/yukimochizuki/cosmos-sdk/x/bank/simulation"
 	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
 	distrsim "github.com/yukimochizuki/cosmos-sdk/x/distribution/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/gov"
 	govsim "github.com/yukimochizuki/cosmos-sdk/x/gov/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+	mintsim "github.com/yukimochizuki/cosmos-sdk/x/mint/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
 	slashingsim "github.com/yukimochizuki/cosmos-sdk/x/slashing/simulation"
@@ -29,15 +35,25 @@ import (
 )
 
 var (
-	seed      int64
-	numBlocks int
-	blockSize int
-	enabled   bool
-	verbose   bool
-	commit    bool
+	genesisFile      string
+	exportStatePath  string
+	operationLogPath string
+	replayPath       string
+	minimize         bool
+	seed             int64
+	numBlocks        int
+	blockSize        int
+	enabled          bool
+	verbose          bool
+	commit           bool
 )
 
 func init() {
+	flag.StringVar(&genesisFile, "SimulationGenesis", "", "Custom simulation genesis file, exported by a previous simulation run with the same seed")
+	flag.StringVar(&exportStatePath, "SimulationExportStatePath", "", "Custom file path to save the exported genesis at the end of the simulation")
+	flag.StringVar(&operationLogPath, "SimulationOperationLog", "", "Custom file path to record the operation log of the simulation")
+	flag.StringVar(&replayPath, "SimulationReplay", "", "Operation log to replay on a fresh app")
+	flag.BoolVar(&minimize, "SimulationMinimize", false, "Minimize the replayed operation log if the replay fails")
 	flag.Int64Var(&seed, "SimulationSeed", 42, "Simulation random seed")
 	flag.IntVar(&numBlocks, "SimulationNumBlocks", 500, "Number of blocks")
 	flag.IntVar(&blockSize, "SimulationBlockSize", 200, "Operations per block")
@@ -47,6 +63,24 @@ func init() {
 }
 
 func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+	if genesisFile != "" {
+		return appStateFromGenesisFileFn(genesisFile)
+	}
+	return appStateRandomizedFn(r, accs)
+}
+
+// continue the simulation from the state of a previous run, the accounts
+// being the same as long as the seed is the same
+func appStateFromGenesisFileFn(genesisFile string) json.RawMessage {
+	genDoc, err := tmtypes.GenesisDocFromFile(genesisFile)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("Imported the app state of %s\n", genesisFile)
+	return genDoc.AppState
+}
+
+func appStateRandomizedFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 	var genesisAccounts []GenesisAccount
 
 	amt := int64(10000)
@@ -60,10 +94,19 @@ func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 		})
 	}
 
-	// Default genesis state
-	govGenesis := gov.DefaultGenesisState()
+	// Random genesis params, reproduced by the seed
+	depositProcedure, votingProcedure, tallyingProcedure := govsim.RandomParams(r)
+	govGenesis := gov.NewGenesisState(1, depositProcedure, votingProcedure, tallyingProcedure)
+	fmt.Printf("Selected randomly generated governance parameters: %+v\n", govGenesis)
+
 	stakeGenesis := stake.DefaultGenesisState()
+	stakeGenesis.Params = stakesim.RandomParams(r)
+	fmt.Printf("Selected randomly generated stake parameters: %+v\n", stakeGenesis.Params)
+
 	slashingGenesis := slashing.DefaultGenesisState()
+	slashingGenesis.Params = slashingsim.RandomParams(r)
+	fmt.Printf("Selected randomly generated slashing parameters: %+v\n", slashingGenesis.Params)
+
 	var validators []stake.Validator
 	var delegations []stake.Delegation
 
@@ -84,13 +127,21 @@ func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 	stakeGenesis.Pool.LooseTokens = sdk.NewDec(amt*250 + (numInitiallyBonded * amt))
 	stakeGenesis.Validators = validators
 	stakeGenesis.Bonds = delegations
+
 	mintGenesis := mint.DefaultGenesisState()
+	mintGenesis.Params = mintsim.RandomParams(r)
+	fmt.Printf("Selected randomly generated minting parameters: %+v\n", mintGenesis.Params)
+
+	distrGenesis := distr.DefaultGenesisWithValidators(valAddrs)
+	distrGenesis.CommunityTax, distrGenesis.BaseProposerReward, distrGenesis.BonusProposerReward = distrsim.RandomParams(r)
+	fmt.Printf("Selected randomly generated distribution parameters: community tax %v, base proposer reward %v, bonus proposer reward %v\n",
+		distrGenesis.CommunityTax, distrGenesis.BaseProposerReward, distrGenesis.BonusProposerReward)
 
 	genesis := GenesisState{
 		Accounts:     genesisAccounts,
 		StakeData:    stakeGenesis,
 		MintData:     mintGenesis,
-		DistrData:    distr.DefaultGenesisWithValidators(valAddrs),
+		DistrData:    distrGenesis,
 		SlashingData: slashingGenesis,
 		GovData:      govGenesis,
 	}
@@ -114,23 +165,24 @@ func testAndRunTxs(app *GaiaApp) []simulation.WeightedOperation {
 		{50, distrsim.SimulateMsgWithdrawValidatorRewardsAll(app.accountKeeper, app.distrKeeper)},
 		{5, govsim.SimulateSubmittingVotingAndSlashingForProposal(app.govKeeper, app.stakeKeeper)},
 		{100, govsim.SimulateMsgDeposit(app.govKeeper, app.stakeKeeper)},
+		{100, govsim.SimulateMsgVote(app.govKeeper, app.stakeKeeper)},
 		{100, stakesim.SimulateMsgCreateValidator(app.accountKeeper, app.stakeKeeper)},
 		{5, stakesim.SimulateMsgEditValidator(app.stakeKeeper)},
 		{100, stakesim.SimulateMsgDelegate(app.accountKeeper, app.stakeKeeper)},
 		{100, stakesim.SimulateMsgBeginUnbonding(app.accountKeeper, app.stakeKeeper)},
 		{100, stakesim.SimulateMsgBeginRedelegate(app.accountKeeper, app.stakeKeeper)},
-		{100, slashingsim.SimulateMsgUnjail(app.slashingKeeper)},
+		{100, slashingsim.SimulateMsgUnjail(app.slashingKeeper, app.stakeKeeper)},
 	}
 }
 
 func invariants(app *GaiaApp) []simulation.Invariant {
 	return []simulation.Invariant{
 		banksim.NonnegativeBalanceInvariant(app.accountKeeper),
-		govsim.AllInvariants(),
+		govsim.AllInvariants(app.govKeeper),
 		distrsim.AllInvariants(app.distrKeeper, app.stakeKeeper),
 		stakesim.AllInvariants(app.bankKeeper, app.stakeKeeper,
-			app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper),
-		slashingsim.AllInvariants(),
+			app.feeCollectionKeeper, app.distrKeeper, app.accountKeeper, app.govKeeper),
+		slashingsim.AllInvariants(app.slashingKeeper, app.stakeKeeper),
 	}
 }
 
@@ -187,8 +239,16 @@ func TestFullGaiaSimulation(t *testing.T) {
 	app := NewGaiaApp(logger, db, nil)
 	require.Equal(t, "GaiaApp", app.Name())
 
+	var opLog *simulation.OperationLog
+	if operationLogPath != "" {
+		file, err := os.Create(operationLogPath)
+		require.Nil(t, err)
+		defer file.Close()
+		opLog = simulation.NewOperationLog(operationLogCodec(), file)
+	}
+
 	// Run randomized simulation
-	err := simulation.SimulateFromSeed(
+	err := simulation.SimulateFromSeedWithOperationLog(
 		t, app.BaseApp, appStateFn, seed,
 		testAndRunTxs(app),
 		[]simulation.RandSetup{},
@@ -196,11 +256,372 @@ func TestFullGaiaSimulation(t *testing.T) {
 		numBlocks,
 		blockSize,
 		commit,
+		opLog,
 	)
 	if commit {
 		fmt.Println("Database Size", db.Stats()["database.size"])
 	}
 	require.Nil(t, err)
+
+	if exportStatePath != "" {
+		fmt.Printf("Exporting the app state to %s\n", exportStatePath)
+		require.Nil(t, exportGenesisFile(app, exportStatePath))
+	}
+}
+
+// save the state of the app as a genesis file, from which a later run with the
+// same seed can continue the simulation. The new chain restarts at height one,
+// so the state is exported for a zero height genesis.
+func exportGenesisFile(app *GaiaApp, path string) error {
+	appState, validators, err := app.ExportAppStateAndValidators(true)
+	if err != nil {
+		return err
+	}
+	genDoc := tmtypes.GenesisDoc{
+		ChainID:    "simulation",
+		Validators: validators,
+		AppState:   appState,
+	}
+	return genDoc.SaveAs(path)
+}
+
+func TestGaiaReplay(t *testing.T) {
+	if replayPath == "" {
+		t.Skip("Skipping Gaia operation log replay")
+	}
+
+	file, err := os.Open(replayPath)
+	require.Nil(t, err)
+	entries, err := simulation.ReadOperationLog(file)
+	file.Close()
+	require.Nil(t, err)
+
+	replay := func(entries []simulation.OperationEntry) error {
+		app := NewGaiaApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+		return simulation.ReplayOperationLog(app.BaseApp, operationLogCodec(), entries, replayDeliverFn(app), invariants(app))
+	}
+	err = replay(entries)
+	if err == nil || !minimize {
+		require.Nil(t, err)
+		return
+	}
+
+	// Shrink the log to the operations needed to reproduce a failure
+	fmt.Printf("Replay failed: %v\nMinimizing the operation log...\n", err)
+	minimized := simulation.MinimizeOperationLog(entries, func(entries []simulation.OperationEntry) bool {
+		return replay(entries) != nil
+	})
+	minimizedPath := replayPath + ".min"
+	file, err = os.Create(minimizedPath)
+	require.Nil(t, err)
+	defer file.Close()
+	require.Nil(t, simulation.WriteOperationLog(file, minimized))
+	t.Fatalf("replay failed with %v, the minimized operation log was written to %s", replay(minimized), minimizedPath)
+}
+
+// the codec of the operation logs, with the msgs only delivered by the
+// simulation registered along the ones of the app
+func operationLogCodec() *codec.Codec {
+	cdc := MakeCodec()
+	authsim.RegisterCodec(cdc)
+	return cdc
+}
+
+// deliver the msgs of a replayed operation log with the handlers of the
+// simulation operations
+func replayDeliverFn(app *GaiaApp) simulation.MsgDeliverer {
+	handlers := map[string]sdk.Handler{
+		"auth":     authsim.NewHandler(app.accountKeeper, app.feeCollectionKeeper),
+		"bank":     bank.NewHandler(app.bankKeeper),
+		"stake":    stake.NewHandler(app.stakeKeeper),
+		"distr":    distr.NewHandler(app.distrKeeper),
+		"slashing": slashing.NewHandler(app.slashingKeeper),
+		"gov":      govsim.NewHandler(app.govKeeper, app.stakeKeeper),
+	}
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		handler, ok := handlers[msg.Route()]
+		if !ok {
+			return sdk.ErrUnknownRequest("unrecognized msg route: " + msg.Route()).Result()
+		}
+		return handler(ctx, msg)
+	}
+}
+
+func TestGaiaSimulationAfterImport(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping Gaia simulation after import")
+	}
+
+	var logger log.Logger
+	if verbose {
+		logger = log.TestingLogger()
+	} else {
+		logger = log.NewNopLogger()
+	}
+	app := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+
+	// Run randomized simulation
+	err := simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, seed,
+		testAndRunTxs(app),
+		[]simulation.RandSetup{},
+		invariants(app),
+		numBlocks,
+		blockSize,
+		true,
+	)
+	require.Nil(t, err)
+
+	// The gov simulation removed the deposits from the loose tokens. The
+	// proposals aren't exported and the export refunds the held deposits, so
+	// they are added back with the ones refunded by the passed proposals.
+	ctx := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	refunded := govsim.RefundedDeposits(app.govKeeper, ctx).Add(govsim.HeldDeposits(app.govKeeper, ctx))
+
+	appState, _, err := app.ExportAppStateAndValidators(true)
+	require.Nil(t, err)
+
+	var genesis GenesisState
+	require.Nil(t, app.cdc.UnmarshalJSON(appState, &genesis))
+	genesis.StakeData.Pool.LooseTokens = genesis.StakeData.Pool.LooseTokens.Add(sdk.NewDecFromInt(refunded))
+	appState, err = codec.MarshalJSONIndent(app.cdc, genesis)
+	require.Nil(t, err)
+
+	// Continue the simulation on a new app started from the exported state,
+	// the same seed generating the same accounts
+	newApp := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+	err = simulation.SimulateFromSeed(
+		t, newApp.BaseApp, func(_ *rand.Rand, _ []simulation.Account) json.RawMessage { return appState }, seed,
+		testAndRunTxs(newApp),
+		[]simulation.RandSetup{},
+		invariants(newApp),
+		numBlocks,
+		blockSize,
+		true,
+	)
+	require.Nil(t, err)
+}
+
+func TestGaiaZeroHeightExport(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping Gaia zero height export")
+	}
+
+	var logger log.Logger
+	if verbose {
+		logger = log.TestingLogger()
+	} else {
+		logger = log.NewNopLogger()
+	}
+	app := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+
+	// Run randomized simulation
+	err := simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, seed,
+		testAndRunTxs(app),
+		[]simulation.RandSetup{},
+		invariants(app),
+		numBlocks,
+		blockSize,
+		true,
+	)
+	require.Nil(t, err)
+
+	appState, _, err := app.ExportAppStateAndValidators(true)
+	require.Nil(t, err)
+
+	// Start a new chain from the exported state
+	newApp := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+	newApp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newApp.Commit()
+
+	newAppState, _, err := newApp.ExportAppStateAndValidators(false)
+	require.Nil(t, err)
+
+	var genesis, newGenesis GenesisState
+	require.Nil(t, app.cdc.UnmarshalJSON(appState, &genesis))
+	require.Nil(t, newApp.cdc.UnmarshalJSON(newAppState, &newGenesis))
+
+	// The new chain must hold the exact same balances and stake
+	require.Equal(t, len(genesis.Accounts), len(newGenesis.Accounts))
+	for i, acc := range genesis.Accounts {
+		newAcc := newGenesis.Accounts[i]
+		require.Equal(t, acc.Address, newAcc.Address)
+		require.True(t, acc.Coins.IsEqual(newAcc.Coins),
+			"account %s: %v != %v", acc.Address, acc.Coins, newAcc.Coins)
+	}
+	require.Equal(t, len(genesis.StakeData.Validators), len(newGenesis.StakeData.Validators))
+	for i, val := range genesis.StakeData.Validators {
+		newVal := newGenesis.StakeData.Validators[i]
+		require.Equal(t, val.OperatorAddr, newVal.OperatorAddr)
+		require.Equal(t, val.Status, newVal.Status)
+		require.True(t, val.Tokens.Equal(newVal.Tokens))
+		require.True(t, val.DelegatorShares.Equal(newVal.DelegatorShares))
+	}
+	mustEqualJSON := func(expected, actual interface{}) {
+		expectedJSON, err := app.cdc.MarshalJSON(expected)
+		require.Nil(t, err)
+		actualJSON, err := newApp.cdc.MarshalJSON(actual)
+		require.Nil(t, err)
+		require.Equal(t, string(expectedJSON), string(actualJSON))
+	}
+	mustEqualJSON(genesis.StakeData.Pool, newGenesis.StakeData.Pool)
+	mustEqualJSON(genesis.StakeData.Bonds, newGenesis.StakeData.Bonds)
+	mustEqualJSON(genesis.StakeData.UnbondingDelegations, newGenesis.StakeData.UnbondingDelegations)
+	mustEqualJSON(genesis.StakeData.Redelegations, newGenesis.StakeData.Redelegations)
+	mustEqualJSON(genesis.DistrData, newGenesis.DistrData)
+	mustEqualJSON(genesis.SlashingData, newGenesis.SlashingData)
+}
+
+// TestGaiaZeroHeightExportImport runs a short simulation regardless of
+// -SimulationEnabled, exports it at zero height and requires the imported
+// chain to hold the exported state.
+func TestGaiaZeroHeightExportImport(t *testing.T) {
+	logger := log.NewNopLogger()
+	app := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+
+	err := simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, 7,
+		testAndRunTxs(app),
+		[]simulation.RandSetup{},
+		invariants(app),
+		20,
+		20,
+		true,
+	)
+	require.Nil(t, err)
+
+	appState, _, err := app.ExportAppStateAndValidators(true)
+	require.Nil(t, err)
+
+	// Start a new chain from the exported state
+	newApp := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+	newApp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newApp.Commit()
+
+	// No block was produced on the new chain, so it must export the
+	// imported genesis as is
+	newAppState, _, err := newApp.ExportAppStateAndValidators(false)
+	require.Nil(t, err)
+	require.JSONEq(t, string(appState), string(newAppState))
+
+	// and starting another chain from it must give the same stores
+	reimportedApp := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+	reimportedApp.InitChain(abci.RequestInitChain{AppStateBytes: newAppState})
+	reimportedApp.Commit()
+
+	compareStores(t, newApp, reimportedApp)
+}
+
+type storeKeysPrefixes struct {
+	A        sdk.StoreKey
+	B        sdk.StoreKey
+	Prefixes [][]byte
+}
+
+func TestGaiaImportExport(t *testing.T) {
+	if !enabled {
+		t.Skip("Skipping Gaia import/export simulation")
+	}
+
+	var logger log.Logger
+	if verbose {
+		logger = log.TestingLogger()
+	} else {
+		logger = log.NewNopLogger()
+	}
+	app := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+
+	// Run randomized simulation
+	err := simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, seed,
+		testAndRunTxs(app),
+		[]simulation.RandSetup{},
+		invariants(app),
+		numBlocks,
+		blockSize,
+		true,
+	)
+	require.Nil(t, err)
+
+	appState, _, err := app.ExportAppStateAndValidators(false)
+	require.Nil(t, err)
+
+	// Import the exported state into a new app
+	newApp := NewGaiaApp(logger, dbm.NewMemDB(), nil)
+	newApp.InitChain(abci.RequestInitChain{AppStateBytes: appState})
+	newApp.Commit()
+
+	compareStores(t, app, newApp)
+}
+
+// compareStores requires the committed stores of two apps to hold the same
+// key/value pairs, except the ones which aren't carried over by an export
+func compareStores(t *testing.T, app, newApp *GaiaApp) {
+	ctxA := app.NewContext(true, abci.Header{Height: app.LastBlockHeight()})
+	ctxB := newApp.NewContext(true, abci.Header{Height: newApp.LastBlockHeight()})
+
+	// The fee collection store is not compared, as the collected fees
+	// aren't exported
+	storesToCompare := []storeKeysPrefixes{
+		{app.keyMain, newApp.keyMain, [][]byte{}},
+		{app.keyAccount, newApp.keyAccount, [][]byte{}},
+		// the order of the entries of a queue timeslice depends on the
+		// order of the transactions which created them
+		{app.keyStake, newApp.keyStake, [][]byte{
+			stake.UnbondingQueueKey, stake.RedelegationQueueKey, stake.ValidatorQueueKey,
+		}},
+		// the pubkeys of validators which were never exported are missing
+		{app.keySlashing, newApp.keySlashing, [][]byte{slashing.AddrPubkeyRelationKey}},
+		{app.keyMint, newApp.keyMint, [][]byte{}},
+		// the previous proposer is set again in the first block
+		{app.keyDistr, newApp.keyDistr, [][]byte{distr.ProposerKey}},
+		{app.keyParams, newApp.keyParams, [][]byte{}},
+		// proposals, deposits and votes aren't exported
+		{app.keyGov, newApp.keyGov, [][]byte{
+			gov.KeyActiveProposalQueue, gov.KeyInactiveProposalQueue,
+			gov.PrefixProposals, gov.PrefixDeposits, gov.PrefixVotes,
+		}},
+	}
+
+	for _, skp := range storesToCompare {
+		storeA := ctxA.KVStore(skp.A)
+		storeB := ctxB.KVStore(skp.B)
+		kvAs, kvBs, count := sdk.DiffKVStores(storeA, storeB, skp.Prefixes, 10)
+		fmt.Printf("Compared %d key/value pairs between %s and %s\n", count, skp.A, skp.B)
+		require.True(t, len(kvAs) == 0, getDiffLog(app.cdc, skp.A.Name(), kvAs, kvBs))
+	}
+}
+
+// decoders of the values of each store, keyed by store name
+var storeDecoders = map[string]func(cdc *codec.Codec, kv cmn.KVPair) string{
+	"acc":      authsim.DecodeStore,
+	"stake":    stakesim.DecodeStore,
+	"slashing": slashingsim.DecodeStore,
+	"mint":     mintsim.DecodeStore,
+	"distr":    distrsim.DecodeStore,
+	"gov":      govsim.DecodeStore,
+}
+
+// getDiffLog returns a human readable log of the differing key/value pairs
+// of a store
+func getDiffLog(cdc *codec.Codec, storeName string, kvAs, kvBs []cmn.KVPair) string {
+	decodeValue := func(kv cmn.KVPair) string {
+		if kv.Value == nil {
+			return "<missing>"
+		}
+		decoder, ok := storeDecoders[storeName]
+		if !ok {
+			return fmt.Sprintf("%X", kv.Value)
+		}
+		return decoder(cdc, kv)
+	}
+
+	diff := fmt.Sprintf("store %s differs:\n", storeName)
+	for i := range kvAs {
+		diff += fmt.Sprintf("key %X\n\tA: %s\n\tB: %s\n", kvAs[i].Key, decodeValue(kvAs[i]), decodeValue(kvBs[i]))
+	}
+	return diff
 }
 
 // TODO: Make another test for the fuzzer itself, which just has noOp txs
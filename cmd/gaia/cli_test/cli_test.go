@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -154,6 +155,51 @@ func TestGaiaCLISend(t *testing.T) {
 	require.Equal(t, int64(20), fooAcc.GetCoins().AmountOf("steak").Int64())
 }
 
+func TestGaiaCLISendBatch(t *testing.T) {
+	chainID, servAddr, port := initializeFixtures(t)
+	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
+
+	// start gaiad server
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v", gaiadHome, servAddr))
+
+	defer proc.Stop(false)
+	tests.WaitForTMStart(port)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	fooAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show foo --output=json --home=%s", gaiacliHome))
+	barAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show bar --output=json --home=%s", gaiacliHome))
+
+	batchFile := writeToNewTempFile(t, fmt.Sprintf("# recipient,amount\n%s,10steak\n%s,5steak\n", barAddr, barAddr))
+	defer os.Remove(batchFile.Name())
+
+	// a malformed file is rejected before anything is sent
+	malformedFile := writeToNewTempFile(t, fmt.Sprintf("%s,10steak\n%s\n", barAddr, barAddr))
+	defer os.Remove(malformedFile.Name())
+	success := executeWrite(t, fmt.Sprintf("gaiacli tx send-batch %v --from=foo %v", flags, malformedFile.Name()), app.DefaultKeyPass)
+	require.False(t, success)
+
+	// the unsigned txs are printed one per line
+	success, stdout, stderr := executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx send-batch %v --from=foo --generate-only %v", flags, batchFile.Name()))
+	require.True(t, success)
+	require.Empty(t, stderr)
+	require.Len(t, strings.Split(strings.TrimSpace(stdout), "\n"), 2)
+
+	// both transfers are sent with consecutive sequences
+	success, stdout, _ = executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx send-batch %v --from=foo %v", flags, batchFile.Name()), app.DefaultKeyPass)
+	require.True(t, success)
+	require.Contains(t, stdout, "row 2: sent 10steak")
+	require.Contains(t, stdout, "row 3: sent 5steak")
+	tests.WaitForNextNBlocksTM(2, port)
+
+	barAcc := executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", barAddr, flags))
+	require.Equal(t, int64(15), barAcc.GetCoins().AmountOf("steak").Int64())
+	fooAcc := executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", fooAddr, flags))
+	require.Equal(t, int64(35), fooAcc.GetCoins().AmountOf("steak").Int64())
+	require.Equal(t, int64(2), fooAcc.GetSequence())
+}
+
 func TestGaiaCLIGasAuto(t *testing.T) {
 	chainID, servAddr, port := initializeFixtures(t)
 	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
@@ -535,6 +581,72 @@ func TestGaiaCLISendGenerateSignAndBroadcast(t *testing.T) {
 	require.Equal(t, int64(40), fooAcc.GetCoins().AmountOf("steak").Int64())
 }
 
+func TestGaiaCLISignBatchAndBroadcast(t *testing.T) {
+	chainID, servAddr, port := initializeFixtures(t)
+	flags := fmt.Sprintf("--home=%s --node=%v --chain-id=%v", gaiacliHome, servAddr, chainID)
+
+	// start gaiad server
+	proc := tests.GoExecuteTWithStdout(t, fmt.Sprintf("gaiad start --home=%s --rpc.laddr=%v", gaiadHome, servAddr))
+
+	defer proc.Stop(false)
+	tests.WaitForTMStart(port)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	fooAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show foo --output=json --home=%s", gaiacliHome))
+	barAddr, _ := executeGetAddrPK(t, fmt.Sprintf("gaiacli keys show bar --output=json --home=%s", gaiacliHome))
+	fooAcc := executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", fooAddr, flags))
+
+	// Generate a batch of unsigned txs
+	unsignedTxs := ""
+	for i := 0; i < 3; i++ {
+		success, stdout, stderr := executeWriteRetStdStreams(t, fmt.Sprintf(
+			"gaiacli tx send %v --amount=10steak --to=%s --from=foo --generate-only",
+			flags, barAddr), []string{}...)
+		require.True(t, success)
+		require.Empty(t, stderr)
+		unsignedTxs += stdout + "\n"
+	}
+	unsignedTxFile := writeToNewTempFile(t, unsignedTxs)
+	defer os.Remove(unsignedTxFile.Name())
+
+	// Unsigned txs don't validate
+	validateFlags := fmt.Sprintf("--home=%s --chain-id=%v", gaiacliHome, chainID)
+	success, _, _ := executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx validate-signatures %v %v", validateFlags, unsignedTxFile.Name()))
+	require.False(t, success)
+
+	// Sign the batch offline
+	success, stdout, _ := executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx sign %v --name=foo --batch --offline --account-number=%d --sequence=%d %v",
+		flags, fooAcc.AccountNumber, fooAcc.Sequence, unsignedTxFile.Name()), app.DefaultKeyPass)
+	require.True(t, success)
+	signedTxs := strings.Split(strings.TrimSpace(stdout), "\n")
+	require.Len(t, signedTxs, 3)
+	for i, signedTx := range signedTxs {
+		msg := unmarshalStdTx(t, signedTx)
+		require.Equal(t, 1, len(msg.GetSignatures()))
+		require.Equal(t, fooAcc.Sequence+int64(i), msg.GetSignatures()[0].Sequence)
+	}
+	signedTxFile := writeToNewTempFile(t, stdout)
+	defer os.Remove(signedTxFile.Name())
+
+	// Validate the signatures
+	success, stdout, _ = executeWriteRetStdStreams(t, fmt.Sprintf(
+		"gaiacli tx validate-signatures %v %v", validateFlags, signedTxFile.Name()))
+	require.True(t, success)
+	require.Equal(t, 3, strings.Count(stdout, fmt.Sprintf("%v OK", fooAddr)))
+
+	// Broadcast the batch
+	success = executeWrite(t, fmt.Sprintf("gaiacli tx broadcast %v %v", flags, signedTxFile.Name()))
+	require.True(t, success)
+	tests.WaitForNextNBlocksTM(2, port)
+
+	barAcc := executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", barAddr, flags))
+	require.Equal(t, int64(30), barAcc.GetCoins().AmountOf("steak").Int64())
+	fooAcc = executeGetAccount(t, fmt.Sprintf("gaiacli query account %s %v", fooAddr, flags))
+	require.Equal(t, int64(20), fooAcc.GetCoins().AmountOf("steak").Int64())
+}
+
 func TestGaiaCLIConfig(t *testing.T) {
 	require.NoError(t, os.RemoveAll(gaiacliHome))
 	require.NoError(t, os.RemoveAll(gaiadHome))
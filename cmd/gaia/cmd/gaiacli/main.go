@@ -102,6 +102,7 @@ func main() {
 			bankcmd.GetBroadcastCommand(cdc),
 			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
+	txCmd.AddCommand(authcmd.GetValidateSignaturesCommand(cdc))
 	txCmd.AddCommand(client.LineBreak)
 
 	txCmd.AddCommand(
@@ -115,6 +116,7 @@ func main() {
 			distrcmd.GetCmdSetWithdrawAddr(cdc),
 			govcmd.GetCmdDeposit(cdc),
 			bankcmd.SendTxCmd(cdc),
+			bankcmd.SendBatchTxCmd(cdc),
 			govcmd.GetCmdSubmitProposal(cdc),
 			slashingcmd.GetCmdUnjail(cdc),
 			govcmd.GetCmdVote(cdc),
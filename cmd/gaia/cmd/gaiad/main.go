@@ -18,6 +18,7 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
 	gaiaInit "github.com/yukimochizuki/cosmos-sdk/cmd/gaia/init"
 	"github.com/yukimochizuki/cosmos-sdk/server"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 )
 
 func main() {
@@ -33,6 +34,10 @@ func main() {
 	rootCmd.AddCommand(gaiaInit.InitCmd(ctx, cdc, appInit))
 	rootCmd.AddCommand(gaiaInit.TestnetFilesCmd(ctx, cdc, appInit))
 	rootCmd.AddCommand(gaiaInit.GenTxCmd(ctx, cdc))
+	rootCmd.AddCommand(gaiaInit.CollectGenTxsCmd(ctx, cdc))
+	rootCmd.AddCommand(gaiaInit.AddGenesisAccountCmd(ctx, cdc))
+	rootCmd.AddCommand(gaiaInit.ValidateGenesisCmd(ctx, cdc))
+	rootCmd.AddCommand(gaiaInit.MigrateGenesisCmd(ctx, cdc))
 
 	server.AddCommands(ctx, cdc, rootCmd, appInit,
 		newApp, exportAppStateAndTMValidators)
@@ -50,12 +55,24 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 	return app.NewGaiaApp(logger, db, traceStore,
 		baseapp.SetPruning(viper.GetString("pruning")),
 		baseapp.SetMinimumFees(viper.GetString("minimum_fees")),
+		baseapp.SetMempoolPolicy(auth.NewMempoolPolicy(viper.GetInt("max_pending_txs_per_account"))),
+		baseapp.SetMetrics(appMetrics()),
+		baseapp.SetHaltHeight(uint64(viper.GetInt64("halt-height"))),
+		baseapp.SetHaltTime(uint64(viper.GetInt64("halt-time"))),
 	)
 }
 
+// the metrics of the app are only registered to Prometheus if served
+func appMetrics() *baseapp.Metrics {
+	if viper.GetBool("prometheus") {
+		return baseapp.PrometheusMetrics("gaiad")
+	}
+	return baseapp.NopMetrics()
+}
+
 func exportAppStateAndTMValidators(
-	logger log.Logger, db dbm.DB, traceStore io.Writer,
+	logger log.Logger, db dbm.DB, traceStore io.Writer, forZeroHeight bool,
 ) (json.RawMessage, []tmtypes.GenesisValidator, error) {
 	gApp := app.NewGaiaApp(logger, db, traceStore)
-	return gApp.ExportAppStateAndValidators()
+	return gApp.ExportAppStateAndValidators(forZeroHeight)
 }
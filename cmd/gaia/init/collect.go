@@ -0,0 +1,91 @@
+package init
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	cfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/server"
+)
+
+const flagGenTxDir = "gentx-dir"
+
+// CollectGenTxsCmd builds the gaiad collect-gentxs command.
+func CollectGenTxsCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "collect-gentxs",
+		Short: "Collect genesis txs and add them to genesis.json",
+		Long: `Collect the genesis transactions of the gentx directory, and add them to the
+genesis file of the node. Unlike init --with-txs, no account is created: the
+signatures of every genesis transaction are verified for the chain of the
+genesis file, and its signers must already have genesis accounts (see
+add-genesis-account) holding enough coins for its fees and self delegation.
+
+The other validators are added to the persistent peers of the node.
+`,
+		Args: cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+			nodeID, _, err := InitializeNodeValidatorFiles(config)
+			if err != nil {
+				return err
+			}
+
+			genFile := config.GenesisFile()
+			genDoc, genesisState, err := readGenesisState(cdc, genFile)
+			if err != nil {
+				return err
+			}
+
+			genTxsDir := viper.GetString(flagGenTxDir)
+			if genTxsDir == "" {
+				genTxsDir = filepath.Join(config.RootDir, "config", "gentx")
+			}
+			_, appGenTxs, persistentPeers, err := app.CollectStdTxs(config.Moniker, genTxsDir, cdc)
+			if err != nil {
+				return err
+			}
+			err = app.ValidateGenTxs(genDoc.ChainID, genesisState, appGenTxs)
+			if err != nil {
+				return err
+			}
+
+			genesisState.GenTxs = make([]json.RawMessage, len(appGenTxs))
+			for i, stdTx := range appGenTxs {
+				genesisState.GenTxs[i], err = cdc.MarshalJSON(stdTx)
+				if err != nil {
+					return err
+				}
+			}
+			err = app.GaiaValidateGenesisState(genesisState)
+			if err != nil {
+				return err
+			}
+			err = writeGenesisState(cdc, genFile, genDoc, genesisState)
+			if err != nil {
+				return err
+			}
+
+			config.P2P.PersistentPeers = persistentPeers
+			cfg.WriteConfigFile(filepath.Join(config.RootDir, "config", "config.toml"), config)
+
+			return displayInfo(cdc, printInfo{
+				Moniker: config.Moniker,
+				ChainID: genDoc.ChainID,
+				NodeID:  nodeID,
+			})
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, app.DefaultNodeHome, "node's home directory")
+	cmd.Flags().String(flagGenTxDir, "",
+		"override default \"gentx\" directory from which collect and execute genesis transactions; default [--home]/config/gentx/")
+	return cmd
+}
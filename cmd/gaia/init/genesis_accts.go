@@ -0,0 +1,137 @@
+package init
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/keys"
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/server"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+)
+
+const (
+	flagVestingAmt   = "vesting-amount"
+	flagVestingStart = "vesting-start-time"
+	flagVestingEnd   = "vesting-end-time"
+)
+
+// AddGenesisAccountCmd builds the gaiad add-genesis-account command.
+func AddGenesisAccountCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-genesis-account [address_or_key_name] [coin][,[coin]]",
+		Short: "Add a genesis account to genesis.json",
+		Long: `Add a genesis account holding the given coins to the genesis file of the
+node. The account is given either by its address, or by the name of a key of
+the client's keybase (--home-client).
+
+The bonded denomination held by the account is added to the loose tokens of
+the stake pool. An account can be added only once.
+
+With --vesting-amount, the given part of the coins of the account vests until
+--vesting-end-time, and can't be sent, delegated or paid as fees before. The
+coins vest linearly from --vesting-start-time if it is given, or all at the end
+time otherwise. Times are unix timestamps in seconds.
+`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			addr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				kb, err := keys.GetKeyBaseFromDir(viper.GetString(flagClientHome))
+				if err != nil {
+					return err
+				}
+				info, err := kb.Get(args[0])
+				if err != nil {
+					return fmt.Errorf("%s is neither an address nor a key name: %v", args[0], err)
+				}
+				addr = info.GetAddress()
+			}
+
+			coins, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+			vestingAmt, err := sdk.ParseCoins(viper.GetString(flagVestingAmt))
+			if err != nil {
+				return err
+			}
+			vestingStart := viper.GetInt64(flagVestingStart)
+			vestingEnd := viper.GetInt64(flagVestingEnd)
+
+			genFile := config.GenesisFile()
+			genDoc, genesisState, err := readGenesisState(cdc, genFile)
+			if err != nil {
+				return err
+			}
+			genesisState, err = addGenesisAccount(genesisState, addr, coins, vestingAmt, vestingStart, vestingEnd)
+			if err != nil {
+				return err
+			}
+			return writeGenesisState(cdc, genFile, genDoc, genesisState)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, app.DefaultNodeHome, "node's home directory")
+	cmd.Flags().String(flagClientHome, app.DefaultCLIHome, "client's home directory")
+	cmd.Flags().String(flagVestingAmt, "", "amount of the coins which vest")
+	cmd.Flags().Int64(flagVestingStart, 0, "unix time at which the coins start vesting linearly")
+	cmd.Flags().Int64(flagVestingEnd, 0, "unix time at which all the coins have vested")
+	return cmd
+}
+
+// addGenesisAccount appends an account holding the coins to the genesis
+// state, numbered after the existing accounts, and adds its bonded tokens to
+// the loose tokens of the pool. The account is a vesting account if vesting
+// coins are given.
+func addGenesisAccount(genesisState app.GenesisState, addr sdk.AccAddress, coins sdk.Coins,
+	vestingAmt sdk.Coins, vestingStart, vestingEnd int64) (app.GenesisState, error) {
+
+	accountNumber := int64(0)
+	for _, acc := range genesisState.Accounts {
+		if acc.Address.Equals(addr) {
+			return genesisState, fmt.Errorf("the genesis file already has an account for %s", addr)
+		}
+		if acc.AccountNumber >= accountNumber {
+			accountNumber = acc.AccountNumber + 1
+		}
+	}
+	if !coins.IsValid() || !coins.IsPositive() {
+		return genesisState, fmt.Errorf("invalid coins %v for the genesis account %s", coins, addr)
+	}
+
+	acc := auth.NewBaseAccountWithAddress(addr)
+	acc.Coins = coins
+	acc.AccountNumber = accountNumber
+	genesisAccount := app.NewGenesisAccount(&acc)
+
+	switch {
+	case !vestingAmt.IsZero():
+		if !vestingAmt.IsPositive() || !coins.IsGTE(vestingAmt) {
+			return genesisState, fmt.Errorf("the vesting coins %v of the genesis account %s must be part of its coins %v",
+				vestingAmt, addr, coins)
+		}
+		if vestingEnd <= vestingStart {
+			return genesisState, fmt.Errorf("the vesting of the genesis account %s must end after it starts", addr)
+		}
+		genesisAccount.OriginalVesting = vestingAmt
+		genesisAccount.StartTime = vestingStart
+		genesisAccount.EndTime = vestingEnd
+	case vestingStart != 0 || vestingEnd != 0:
+		return genesisState, fmt.Errorf("the vesting times of the genesis account %s are given without vesting coins", addr)
+	}
+	genesisState.Accounts = append(genesisState.Accounts, genesisAccount)
+
+	bonded := coins.AmountOf(genesisState.StakeData.Params.BondDenom)
+	pool := &genesisState.StakeData.Pool
+	pool.LooseTokens = pool.LooseTokens.Add(sdk.NewDecFromInt(bonded))
+	return genesisState, nil
+}
@@ -0,0 +1,77 @@
+package init
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
+)
+
+func TestAddGenesisAccount(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	genesisState := app.GenesisState{StakeData: stake.DefaultGenesisState()}
+
+	coins, err := sdk.ParseCoins("10fooToken,100steak")
+	require.NoError(t, err)
+	genesisState, err = addGenesisAccount(genesisState, addr1, coins, nil, 0, 0)
+	require.NoError(t, err)
+	genesisState, err = addGenesisAccount(genesisState, addr2, sdk.Coins{sdk.NewInt64Coin("steak", 50)}, nil, 0, 0)
+	require.NoError(t, err)
+
+	require.Len(t, genesisState.Accounts, 2)
+	require.Equal(t, int64(0), genesisState.Accounts[0].AccountNumber)
+	require.Equal(t, int64(1), genesisState.Accounts[1].AccountNumber)
+	require.Equal(t, coins, genesisState.Accounts[0].Coins)
+	require.True(t, sdk.NewDec(150).Equal(genesisState.StakeData.Pool.LooseTokens))
+
+	// an account is added only once
+	_, err = addGenesisAccount(genesisState, addr1, coins, nil, 0, 0)
+	require.Error(t, err)
+
+	// accounts must hold positive coins
+	_, err = addGenesisAccount(genesisState, sdk.AccAddress([]byte("addr3")), sdk.Coins{}, nil, 0, 0)
+	require.Error(t, err)
+}
+
+func TestAddGenesisVestingAccount(t *testing.T) {
+	addr1 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	addr2 := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	genesisState := app.GenesisState{StakeData: stake.DefaultGenesisState()}
+	coins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	vesting := sdk.Coins{sdk.NewInt64Coin("steak", 60)}
+
+	genesisState, err := addGenesisAccount(genesisState, addr1, coins, vesting, 1000, 2000)
+	require.NoError(t, err)
+	genesisState, err = addGenesisAccount(genesisState, addr2, coins, vesting, 0, 2000)
+	require.NoError(t, err)
+	require.True(t, sdk.NewDec(200).Equal(genesisState.StakeData.Pool.LooseTokens))
+
+	acc := genesisState.Accounts[0].ToAccount()
+	require.IsType(t, &auth.ContinuousVestingAccount{}, acc)
+	require.Equal(t, coins, acc.GetCoins())
+	require.Equal(t, vesting, acc.(auth.VestingAccount).GetOriginalVesting())
+	require.Equal(t, int64(1000), acc.(auth.VestingAccount).GetStartTime())
+	require.Equal(t, int64(2000), acc.(auth.VestingAccount).GetEndTime())
+	require.IsType(t, &auth.DelayedVestingAccount{}, genesisState.Accounts[1].ToAccount())
+
+	addr3 := sdk.AccAddress([]byte("addr3"))
+	// the vesting coins are part of the coins of the account
+	_, err = addGenesisAccount(genesisState, addr3, coins, sdk.Coins{sdk.NewInt64Coin("steak", 101)}, 0, 2000)
+	require.Error(t, err)
+	_, err = addGenesisAccount(genesisState, addr3, coins, sdk.Coins{sdk.NewInt64Coin("photino", 1)}, 0, 2000)
+	require.Error(t, err)
+	// the vesting ends after it starts
+	_, err = addGenesisAccount(genesisState, addr3, coins, vesting, 2000, 2000)
+	require.Error(t, err)
+	_, err = addGenesisAccount(genesisState, addr3, coins, vesting, 0, 0)
+	require.Error(t, err)
+	// vesting times are only given with vesting coins
+	_, err = addGenesisAccount(genesisState, addr3, coins, nil, 0, 2000)
+	require.Error(t, err)
+}
@@ -279,3 +279,28 @@ func ReadOrCreatePrivValidator(privValFile string) crypto.PubKey {
 	}
 	return privValidator.GetPubKey()
 }
+
+// readGenesisState reads a genesis file and decodes its app state.
+func readGenesisState(cdc *codec.Codec, genesisFile string) (
+	genDoc *types.GenesisDoc, genesisState app.GenesisState, err error) {
+	genDoc, err = types.GenesisDocFromFile(genesisFile)
+	if err != nil {
+		return
+	}
+	err = cdc.UnmarshalJSON(genDoc.AppState, &genesisState)
+	return
+}
+
+// writeGenesisState encodes the app state into the genesis doc, and saves it
+// to the genesis file.
+func writeGenesisState(cdc *codec.Codec, genesisFile string, genDoc *types.GenesisDoc, genesisState app.GenesisState) error {
+	appState, err := codec.MarshalJSONIndent(cdc, genesisState)
+	if err != nil {
+		return err
+	}
+	genDoc.AppState = appState
+	if err = genDoc.ValidateAndComplete(); err != nil {
+		return err
+	}
+	return genDoc.SaveAs(genesisFile)
+}
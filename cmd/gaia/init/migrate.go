@@ -0,0 +1,51 @@
+package init
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/migrate"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/server"
+)
+
+// MigrateGenesisCmd builds the gaiad migrate command.
+func MigrateGenesisCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate [target-version] [genesis-file]",
+		Short: "Migrate a genesis file to a target version",
+		Long: fmt.Sprintf(`Migrate the app state of a genesis file exported by the version preceding
+the target version, and print the migrated genesis file to stdout.
+
+The migrated app state is validated as a genesis state of the target version.
+The chain ID, the genesis time and the validators of the genesis file are kept,
+and should be updated to restart the chain.
+
+Target versions: %s
+`, strings.Join(migrate.Versions(), ", ")),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version, genesisFile := args[0], args[1]
+
+			doc, err := tmtypes.GenesisDocFromFile(genesisFile)
+			if err != nil {
+				return err
+			}
+			doc.AppState, err = migrate.Migrate(cdc, doc.AppState, version)
+			if err != nil {
+				return err
+			}
+
+			encoded, err := codec.MarshalJSONIndent(cdc, doc)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(encoded))
+			return nil
+		},
+	}
+	return cmd
+}
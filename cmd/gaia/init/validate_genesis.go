@@ -0,0 +1,54 @@
+package init
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/server"
+)
+
+// ValidateGenesisCmd builds the gaiad validate-genesis command.
+func ValidateGenesisCmd(ctx *server.Context, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-genesis [file]",
+		Short: "Validate a genesis file",
+		Long: `Validate the genesis accounts and the genesis state of every module of a
+genesis file, by default the genesis file of the node, and report every
+problem found.
+`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			genFile := config.GenesisFile()
+			if len(args) == 1 {
+				genFile = args[0]
+			}
+			_, genesisState, err := readGenesisState(cdc, genFile)
+			if err != nil {
+				return fmt.Errorf("error reading the genesis file %s: %v", genFile, err)
+			}
+
+			errs := app.GaiaValidateGenesisStateAll(genesisState)
+			if len(errs) > 0 {
+				for _, err := range errs {
+					fmt.Fprintln(os.Stderr, err)
+				}
+				return fmt.Errorf("the genesis file %s is invalid, %d problems found", genFile, len(errs))
+			}
+
+			fmt.Printf("File at %s is a valid genesis file\n", genFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, app.DefaultNodeHome, "node's home directory")
+	return cmd
+}
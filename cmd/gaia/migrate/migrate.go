@@ -0,0 +1,94 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+)
+
+// MigrationFunc migrates the amino-JSON genesis state of a module, as
+// exported by the version preceding the version of the migration
+type MigrationFunc func(data json.RawMessage) (json.RawMessage, error)
+
+// ModuleMigrations are the migrations of a version, by the key of the genesis
+// state of their module in the app state
+type ModuleMigrations map[string]MigrationFunc
+
+// Migrations are the migrations to every version from the preceding one
+var Migrations = map[string]ModuleMigrations{
+	"v0.26": {
+		"accounts": MigrateAccountsV026,
+		"stake":    MigrateStakeV026,
+		"slashing": MigrateSlashingV026,
+	},
+}
+
+// Versions returns the sorted versions which can be migrated to
+func Versions() []string {
+	var versions []string
+	for version := range Migrations {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// Migrate migrates the app state of a genesis file exported by the version
+// preceding the target version, applying the migrations of all the modules
+// of the target version in the order of their keys. The migrated state is
+// validated with GaiaValidateGenesisState, and returned in the amino JSON of
+// the current app.
+func Migrate(cdc *codec.Codec, appState json.RawMessage, version string) (json.RawMessage, error) {
+	migrations, ok := Migrations[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown version %s, expected one of %v", version, Versions())
+	}
+
+	var state map[string]json.RawMessage
+	err := json.Unmarshal(appState, &state)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app state: %v", err)
+	}
+
+	var modules []string
+	for module := range migrations {
+		modules = append(modules, module)
+	}
+	sort.Strings(modules)
+	for _, module := range modules {
+		data, ok := state[module]
+		if !ok {
+			return nil, fmt.Errorf("missing genesis state of module %s", module)
+		}
+		migrated, err := migrations[module](data)
+		if err != nil {
+			return nil, fmt.Errorf("error migrating the genesis state of module %s: %v", module, err)
+		}
+		state[module] = migrated
+	}
+
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	var genesisState app.GenesisState
+	err = cdc.UnmarshalJSON(bz, &genesisState)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrated app state: %v", err)
+	}
+	err = app.GaiaValidateGenesisState(genesisState)
+	if err != nil {
+		return nil, fmt.Errorf("invalid migrated app state: %v", err)
+	}
+	return codec.MarshalJSONIndent(cdc, genesisState)
+}
+
+// setDefault sets the field of a JSON object if it is missing
+func setDefault(object map[string]json.RawMessage, field string, value string) {
+	if _, ok := object[field]; !ok {
+		object[field] = json.RawMessage(value)
+	}
+}
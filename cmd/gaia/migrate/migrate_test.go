@@ -0,0 +1,79 @@
+package migrate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+)
+
+func readTestData(t *testing.T, version, name string) json.RawMessage {
+	bz, err := ioutil.ReadFile(filepath.Join("testdata", version, name))
+	require.NoError(t, err)
+	return bz
+}
+
+// every migration turns testdata/<version>/<module>_input.json into
+// testdata/<version>/<module>_golden.json
+func TestMigrationsGolden(t *testing.T) {
+	for version, migrations := range Migrations {
+		for module, migration := range migrations {
+			input := readTestData(t, version, module+"_input.json")
+			golden := readTestData(t, version, module+"_golden.json")
+
+			migrated, err := migration(input)
+			require.NoError(t, err, "%s %s", version, module)
+			require.JSONEq(t, string(golden), string(migrated), "%s %s", version, module)
+
+			// migrating a migrated state doesn't change it
+			again, err := migration(migrated)
+			require.NoError(t, err, "%s %s", version, module)
+			require.JSONEq(t, string(migrated), string(again), "%s %s", version, module)
+		}
+	}
+}
+
+func TestMigrateV026(t *testing.T) {
+	cdc := app.MakeCodec()
+	marshalJSON := func(o interface{}) json.RawMessage {
+		bz, err := cdc.MarshalJSON(o)
+		require.NoError(t, err)
+		return bz
+	}
+	appState, err := json.Marshal(map[string]json.RawMessage{
+		"accounts": readTestData(t, "v0.26", "accounts_input.json"),
+		"stake":    readTestData(t, "v0.26", "stake_input.json"),
+		"slashing": readTestData(t, "v0.26", "slashing_input.json"),
+		"mint":     marshalJSON(mint.DefaultGenesisState()),
+		"distr":    marshalJSON(distr.DefaultGenesisState()),
+		"gov":      marshalJSON(gov.DefaultGenesisState()),
+	})
+	require.NoError(t, err)
+
+	migrated, err := Migrate(cdc, appState, "v0.26")
+	require.NoError(t, err)
+
+	var genesisState app.GenesisState
+	require.NoError(t, cdc.UnmarshalJSON(migrated, &genesisState))
+	require.Len(t, genesisState.Accounts, 2)
+	for i, acc := range genesisState.Accounts {
+		require.Equal(t, int64(i), acc.AccountNumber)
+		require.Equal(t, int64(0), acc.Sequence)
+	}
+	require.Equal(t, sdk.NewInt(150), genesisState.Accounts[0].Coins.AmountOf("steak"))
+	require.True(t, genesisState.StakeData.LastTotalPower.IsZero())
+	require.False(t, genesisState.StakeData.Exported)
+	require.Equal(t, slashing.DefaultParams(), genesisState.SlashingData.Params)
+
+	_, err = Migrate(cdc, appState, "v0.1")
+	require.Error(t, err)
+}
@@ -0,0 +1,59 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MigrateAccountsV026 numbers the genesis accounts in the order of the
+// genesis file, in which their account numbers were assigned at genesis by
+// v0.25, and starts their sequences at zero.
+func MigrateAccountsV026(data json.RawMessage) (json.RawMessage, error) {
+	var accounts []map[string]json.RawMessage
+	err := json.Unmarshal(data, &accounts)
+	if err != nil {
+		return nil, err
+	}
+	for i, acc := range accounts {
+		setDefault(acc, "account_number", fmt.Sprintf(`"%d"`, i))
+		setDefault(acc, "sequence_number", `"0"`)
+	}
+	return json.Marshal(accounts)
+}
+
+// MigrateStakeV026 adds the fields of the exported stake state, which v0.25
+// didn't export. The state is not marked as exported, so that the validator
+// set is computed from the validators at genesis, as v0.25 did.
+func MigrateStakeV026(data json.RawMessage) (json.RawMessage, error) {
+	var state map[string]json.RawMessage
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	setDefault(state, "intra_tx_counter", `0`)
+	setDefault(state, "last_total_power", `"0"`)
+	setDefault(state, "last_validator_powers", `[]`)
+	setDefault(state, "unbonding_delegations", `[]`)
+	setDefault(state, "redelegations", `[]`)
+	setDefault(state, "exported", `false`)
+	return json.Marshal(state)
+}
+
+// MigrateSlashingV026 renames the untagged params field of the slashing state
+// of v0.25, and adds the signing infos, missed blocks and slashing periods,
+// which v0.25 didn't export.
+func MigrateSlashingV026(data json.RawMessage) (json.RawMessage, error) {
+	var state map[string]json.RawMessage
+	err := json.Unmarshal(data, &state)
+	if err != nil {
+		return nil, err
+	}
+	if params, ok := state["Params"]; ok {
+		state["params"] = params
+		delete(state, "Params")
+	}
+	setDefault(state, "signing_infos", `[]`)
+	setDefault(state, "missed_blocks", `[]`)
+	setDefault(state, "slashing_periods", `[]`)
+	return json.Marshal(state)
+}
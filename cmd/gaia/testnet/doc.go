@@ -0,0 +1,27 @@
+/*
+Package testnet starts a Gaia testnet of several validators in a single
+process, for end-to-end tests which don't need the gaiad and gaiacli binaries.
+
+Every validator runs its own GaiaApp and Tendermint node on in-memory
+databases, and the nodes are connected to each other through their P2P ports
+on localhost. Each validator exposes a CLIContext querying and broadcasting
+to its node, and optionally an LCD:
+
+	net := testnet.New(t, testnet.DefaultConfig())
+	defer net.Cleanup()
+
+	net.WaitForHeight(t, 3)
+
+	// the chain keeps going without one of its four validators
+	val := net.Validators[0]
+	val.Stop(t)
+	net.WaitForHeight(t, 5)
+
+	// the validator catches up once restarted
+	val.Start(t)
+	net.WaitForHeight(t, 7)
+
+The operator keys of the validators are stored in the keybase of the
+network, with gaia's DefaultKeyPass, so that tests can sign txs from them.
+*/
+package testnet
@@ -0,0 +1,191 @@
+package testnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/libs/cli"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/client"
+	"github.com/yukimochizuki/cosmos-sdk/client/keys"
+	gapp "github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	crkeys "github.com/yukimochizuki/cosmos-sdk/crypto/keys"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+)
+
+// Config configures a testnet
+type Config struct {
+	NumValidators int              // number of validators, all bonded with the same power
+	ChainID       string           // chain ID, random if empty
+	Accounts      []sdk.AccAddress // additional accounts created at genesis
+	AccountCoins  sdk.Coins        // coins of each additional account
+	StartLCD      bool             // whether every validator serves an LCD
+	Logger        log.Logger       // logger of the apps and nodes, errors to stdout if nil
+}
+
+// DefaultConfig returns the config of a testnet of four validators serving
+// an LCD each
+func DefaultConfig() Config {
+	return Config{
+		NumValidators: 4,
+		AccountCoins:  sdk.Coins{sdk.NewInt64Coin("steak", 100)},
+		StartLCD:      true,
+	}
+}
+
+// Network is a testnet running in process
+type Network struct {
+	Validators []*Validator
+	GenesisDoc *tmtypes.GenesisDoc
+	Keybase    crkeys.Keybase
+	Codec      *codec.Codec
+
+	config Config
+	logger log.Logger
+	dir    string
+}
+
+// New creates a testnet, starts all its validators and waits for its first
+// block. The LCDs and the CLI contexts read their keys from the home
+// directory of the network, which is set in viper.
+func New(t *testing.T, config Config) *Network {
+	require.True(t, config.NumValidators > 0, "a testnet needs at least one validator")
+	if config.ChainID == "" {
+		config.ChainID = "testnet-" + cmn.RandStr(6)
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = log.NewFilter(log.NewTMLogger(log.NewSyncWriter(os.Stdout)), log.AllowError())
+	}
+
+	dir, err := ioutil.TempDir("", "testnet")
+	require.NoError(t, err)
+
+	viper.Set(cli.HomeFlag, filepath.Join(dir, "cli"))
+	viper.Set(client.FlagChainID, config.ChainID)
+	// TODO Set to false once the upstream Tendermint proof verification issue is fixed.
+	viper.Set(client.FlagTrustNode, true)
+	kb, err := keys.GetKeyBaseWithWritePerm()
+	require.NoError(t, err)
+
+	net := &Network{
+		Keybase: kb,
+		Codec:   gapp.MakeCodec(),
+		config:  config,
+		logger:  logger,
+		dir:     dir,
+	}
+
+	genTxs := make([]json.RawMessage, config.NumValidators)
+	for i := 0; i < config.NumValidators; i++ {
+		val := newValidator(t, net, i)
+		genTxs[i] = val.genTx(t)
+		net.Validators = append(net.Validators, val)
+	}
+
+	// every node is a persistent peer of all the others
+	for i, val := range net.Validators {
+		var peers []string
+		for j, peer := range net.Validators {
+			if i != j {
+				peers = append(peers, peer.peerAddress())
+			}
+		}
+		val.Config.P2P.PersistentPeers = strings.Join(peers, ",")
+	}
+
+	genesisState, err := gapp.GaiaAppGenState(net.Codec, genTxs)
+	require.NoError(t, err)
+	for _, addr := range config.Accounts {
+		acc := auth.NewBaseAccountWithAddress(addr)
+		acc.Coins = config.AccountCoins
+		genesisState.Accounts = append(genesisState.Accounts, gapp.NewGenesisAccount(&acc))
+		genesisState.StakeData.Pool.LooseTokens = genesisState.StakeData.Pool.LooseTokens.Add(
+			sdk.NewDecFromInt(config.AccountCoins.AmountOf("steak")))
+	}
+	appState, err := codec.MarshalJSONIndent(net.Codec, genesisState)
+	require.NoError(t, err)
+
+	// the validator set is initialized by the gentxs
+	net.GenesisDoc = &tmtypes.GenesisDoc{
+		ChainID:  config.ChainID,
+		AppState: appState,
+	}
+
+	for _, val := range net.Validators {
+		val.Start(t)
+	}
+	net.WaitForHeight(t, 1)
+	return net
+}
+
+// ChainID returns the chain ID of the testnet
+func (net *Network) ChainID() string {
+	return net.config.ChainID
+}
+
+// WaitForHeight waits until all the running validators have committed the
+// block of the given height, failing the test after a minute
+func (net *Network) WaitForHeight(t *testing.T, height int64) {
+	for _, val := range net.Validators {
+		if val.IsRunning() {
+			val.WaitForHeight(t, height)
+		}
+	}
+}
+
+// WaitForNextBlocks waits until all the running validators have committed n
+// more blocks than the highest of them
+func (net *Network) WaitForNextBlocks(t *testing.T, n int64) {
+	net.WaitForHeight(t, net.LatestHeight()+n)
+}
+
+// LatestHeight returns the highest height committed by a running validator
+func (net *Network) LatestHeight() (height int64) {
+	for _, val := range net.Validators {
+		if val.IsRunning() && val.LatestHeight() > height {
+			height = val.LatestHeight()
+		}
+	}
+	return height
+}
+
+// Cleanup stops all the validators and removes the directories of the
+// testnet
+func (net *Network) Cleanup() {
+	for _, val := range net.Validators {
+		if val.IsRunning() {
+			err := val.stop()
+			if err != nil {
+				net.logger.Error(fmt.Sprintf("error stopping %s", val.Moniker), "err", err)
+			}
+		}
+	}
+	os.RemoveAll(net.dir)
+}
+
+// waitFor polls the condition until it holds, failing the test after the
+// timeout
+func waitFor(t *testing.T, timeout time.Duration, what string, condition func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -0,0 +1,50 @@
+package testnet
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+)
+
+func TestNetworkRestartValidator(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the in-process testnet in short mode")
+	}
+
+	net := New(t, DefaultConfig())
+	defer net.Cleanup()
+	net.WaitForHeight(t, 2)
+
+	// every node serves the genesis accounts
+	for _, val := range net.Validators {
+		res, err := val.CLIContext().QueryStore(auth.AddressStoreKey(val.Address), "acc")
+		require.NoError(t, err)
+		require.NotEmpty(t, res)
+
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%s/node_version", val.LCDPort))
+		require.NoError(t, err)
+		resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// three of the four validators have more than 2/3 of the power
+	val := net.Validators[0]
+	val.Stop(t)
+	require.False(t, val.IsRunning())
+	net.WaitForNextBlocks(t, 2)
+
+	// the restarted validator catches up
+	val.Start(t)
+	height := net.LatestHeight()
+	val.WaitForHeight(t, height)
+	net.WaitForHeight(t, height+2)
+
+	acc, err := val.CLIContext().GetAccount(val.Address)
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt(50), acc.GetCoins().AmountOf("steak"))
+}
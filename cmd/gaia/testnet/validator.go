@@ -0,0 +1,239 @@
+package testnet
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	tmcfg "github.com/tendermint/tendermint/config"
+	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	nm "github.com/tendermint/tendermint/node"
+	"github.com/tendermint/tendermint/p2p"
+	pvm "github.com/tendermint/tendermint/privval"
+	"github.com/tendermint/tendermint/proxy"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmrpc "github.com/tendermint/tendermint/rpc/lib/server"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/lcd"
+	gapp "github.com/yukimochizuki/cosmos-sdk/cmd/gaia/app"
+	crkeys "github.com/yukimochizuki/cosmos-sdk/crypto/keys"
+	"github.com/yukimochizuki/cosmos-sdk/server"
+	"github.com/yukimochizuki/cosmos-sdk/tests"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	authcmd "github.com/yukimochizuki/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/yukimochizuki/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
+)
+
+// Validator is a validator of a testnet, running a GaiaApp and a Tendermint
+// node, and an LCD if the testnet serves them. Its databases are kept in
+// memory across restarts.
+type Validator struct {
+	Moniker    string
+	KeyName    string         // name of the operator key in the keybase of the testnet
+	Address    sdk.AccAddress // address of the operator account
+	ValAddress sdk.ValAddress
+	ConsPubKey crypto.PubKey
+	Config     *tmcfg.Config
+	RPCAddress string // address of the Tendermint RPC of the node
+	LCDPort    string // port of the LCD, empty if the testnet serves no LCD
+
+	// set while the validator is running
+	App  *gapp.GaiaApp
+	Node *nm.Node
+
+	network *Network
+	nodeKey *p2p.NodeKey
+	p2pPort string
+	lcdAddr string
+	lcd     net.Listener
+	appDB   dbm.DB
+	tmDBs   map[string]dbm.DB
+}
+
+func newValidator(t *testing.T, network *Network, i int) *Validator {
+	moniker := fmt.Sprintf("validator-%d", i)
+	dir := filepath.Join(network.dir, moniker)
+	require.NoError(t, cmn.EnsureDir(filepath.Join(dir, "config"), 0755))
+	require.NoError(t, cmn.EnsureDir(filepath.Join(dir, "data"), 0755))
+
+	config := tmcfg.TestConfig()
+	config.SetRoot(dir)
+	config.Moniker = moniker
+	config.TxIndex.IndexAllTags = true
+	// all the nodes listen on localhost
+	config.P2P.AddrBookStrict = false
+	config.P2P.AllowDuplicateIP = true
+	config.RPC.GRPCListenAddress = ""
+
+	p2pAddr, p2pPort, err := server.FreeTCPAddr()
+	require.NoError(t, err)
+	rpcAddr, rpcPort, err := server.FreeTCPAddr()
+	require.NoError(t, err)
+	config.P2P.ListenAddress = p2pAddr
+	config.RPC.ListenAddress = rpcAddr
+
+	nodeKey, err := p2p.LoadOrGenNodeKey(config.NodeKeyFile())
+	require.NoError(t, err)
+	privVal := pvm.LoadOrGenFilePV(config.PrivValidatorFile())
+
+	keyName := fmt.Sprintf("%s-%s", network.config.ChainID, moniker)
+	info, _, err := network.Keybase.CreateMnemonic(keyName, crkeys.English, gapp.DefaultKeyPass, crkeys.Secp256k1)
+	require.NoError(t, err)
+	addr := sdk.AccAddress(info.GetPubKey().Address())
+
+	val := &Validator{
+		Moniker:    moniker,
+		KeyName:    keyName,
+		Address:    addr,
+		ValAddress: sdk.ValAddress(addr),
+		ConsPubKey: privVal.GetPubKey(),
+		Config:     config,
+		RPCAddress: fmt.Sprintf("tcp://127.0.0.1:%s", rpcPort),
+		network:    network,
+		nodeKey:    nodeKey,
+		p2pPort:    p2pPort,
+		appDB:      dbm.NewMemDB(),
+		tmDBs:      make(map[string]dbm.DB),
+	}
+
+	if network.config.StartLCD {
+		val.lcdAddr, val.LCDPort, err = server.FreeTCPAddr()
+		require.NoError(t, err)
+	}
+	return val
+}
+
+// genTx returns the signed gentx creating the validator
+func (v *Validator) genTx(t *testing.T) []byte {
+	msg := stake.NewMsgCreateValidator(
+		v.ValAddress,
+		v.ConsPubKey,
+		sdk.NewInt64Coin("steak", 100),
+		stake.NewDescription(v.Moniker, "", "", ""),
+		stake.NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+	)
+	tx := auth.NewStdTx([]sdk.Msg{msg}, auth.StdFee{}, []auth.StdSignature{}, "")
+	signedTx, err := v.TxBuilder().SignStdTx(v.KeyName, gapp.DefaultKeyPass, tx, false)
+	require.NoError(t, err)
+	txBytes, err := v.network.Codec.MarshalJSON(signedTx)
+	require.NoError(t, err)
+	return txBytes
+}
+
+// peer address of the node, used in the persistent peers of the others
+func (v *Validator) peerAddress() string {
+	return fmt.Sprintf("%s@127.0.0.1:%s", v.nodeKey.ID(), v.p2pPort)
+}
+
+// Start starts the app and the node of the validator, and its LCD. A stopped
+// validator restarts from the state it had committed, and catches up with the
+// testnet.
+func (v *Validator) Start(t *testing.T) {
+	require.False(t, v.IsRunning(), "%s is already running", v.Moniker)
+	logger := v.network.logger.With("validator", v.Moniker)
+
+	v.App = gapp.NewGaiaApp(logger, v.appDB, nil)
+	privVal := pvm.LoadOrGenFilePV(v.Config.PrivValidatorFile())
+	genDocProvider := func() (*tmtypes.GenesisDoc, error) { return v.network.GenesisDoc, nil }
+	dbProvider := func(ctx *nm.DBContext) (dbm.DB, error) {
+		db, ok := v.tmDBs[ctx.ID]
+		if !ok {
+			db = dbm.NewMemDB()
+			v.tmDBs[ctx.ID] = db
+		}
+		return db, nil
+	}
+	node, err := nm.NewNode(
+		v.Config,
+		privVal,
+		v.nodeKey,
+		proxy.NewLocalClientCreator(v.App),
+		genDocProvider,
+		dbProvider,
+		nm.DefaultMetricsProvider(v.Config.Instrumentation),
+		logger.With("module", "node"),
+	)
+	require.NoError(t, err)
+	require.NoError(t, node.Start())
+	v.Node = node
+	tests.WaitForRPC(v.Config.RPC.ListenAddress)
+
+	if v.network.config.StartLCD {
+		v.lcd, err = tmrpc.StartHTTPServer(v.lcdAddr, lcd.NewHandler(v.CLIContext()),
+			logger.With("module", "lcd"), tmrpc.Config{})
+		require.NoError(t, err)
+		// no block is committed before enough validators are running
+		tests.WaitForStart(fmt.Sprintf("http://localhost:%s/version", v.LCDPort))
+	}
+}
+
+// Stop kills the node and the LCD of the validator. Its committed state is
+// kept, to restart it with Start.
+func (v *Validator) Stop(t *testing.T) {
+	require.True(t, v.IsRunning(), "%s is not running", v.Moniker)
+	require.NoError(t, v.stop())
+}
+
+func (v *Validator) stop() error {
+	if v.lcd != nil {
+		v.lcd.Close()
+		v.lcd = nil
+	}
+	err := v.Node.Stop()
+	v.Node.Wait()
+	v.Node = nil
+	v.App = nil
+	return err
+}
+
+// IsRunning returns whether the validator has been started and not stopped
+func (v *Validator) IsRunning() bool {
+	return v.Node != nil
+}
+
+// CLIContext returns a context querying and broadcasting to the node of the
+// validator, from its operator key
+func (v *Validator) CLIContext() context.CLIContext {
+	cdc := v.network.Codec
+	return context.NewCLIContext().
+		WithCodec(cdc).
+		WithAccountDecoder(authcmd.GetAccountDecoder(cdc)).
+		WithNodeURI(v.RPCAddress).
+		WithTrustNode(true).
+		WithFrom(v.KeyName)
+}
+
+// TxBuilder returns a tx builder for the chain of the testnet
+func (v *Validator) TxBuilder() authtxb.TxBuilder {
+	return authtxb.NewTxBuilderFromCLI().
+		WithCodec(v.network.Codec).
+		WithChainID(v.network.ChainID())
+}
+
+// LatestHeight returns the height of the last block committed by the node,
+// or zero if the node doesn't answer
+func (v *Validator) LatestHeight() int64 {
+	status, err := rpcclient.NewHTTP(v.RPCAddress, "/websocket").Status()
+	if err != nil {
+		return 0
+	}
+	return status.SyncInfo.LatestBlockHeight
+}
+
+// WaitForHeight waits until the node has committed the block of the given
+// height, failing the test after a minute
+func (v *Validator) WaitForHeight(t *testing.T, height int64) {
+	waitFor(t, time.Minute, fmt.Sprintf("%s to reach height %d", v.Moniker, height), func() bool {
+		return v.LatestHeight() >= height
+	})
+}
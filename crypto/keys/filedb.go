@@ -0,0 +1,125 @@
+package keys
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/keyerror"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/mintkey"
+)
+
+const fileDBSaltLen = 16
+
+// kvPair is an entry of a keyring file
+type kvPair struct {
+	Key   []byte
+	Value []byte
+}
+
+// NewFileDB opens the keyring stored in a single file at path, encrypted with
+// the master password. The file holds the bcrypt salt followed by the entries
+// encrypted with xsalsa20, as keys are encrypted by mintkey. It is created on
+// the first write if it doesn't exist. A keyerror.ErrWrongPassword is returned
+// if the password doesn't decrypt the file.
+func NewFileDB(path string, password string) (dbm.DB, error) {
+	mem := dbm.NewMemDB()
+
+	var salt []byte
+	bz, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		salt = crypto.CRandBytes(fileDBSaltLen)
+	case err != nil:
+		return nil, err
+	case len(bz) < fileDBSaltLen:
+		return nil, errors.Errorf("keyring file %s is corrupted", path)
+	default:
+		salt = bz[:fileDBSaltLen]
+	}
+
+	secret, err := fileDBSecret(salt, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if bz != nil {
+		plain, err := xsalsa20symmetric.DecryptSymmetric(bz[fileDBSaltLen:], secret)
+		if err != nil {
+			return nil, keyerror.NewErrWrongPassword()
+		}
+		var pairs []kvPair
+		if err := cdc.UnmarshalBinaryBare(plain, &pairs); err != nil {
+			return nil, errors.Wrapf(err, "keyring file %s is corrupted", path)
+		}
+		for _, pair := range pairs {
+			mem.Set(pair.Key, pair.Value)
+		}
+	}
+
+	// the whole file is rewritten on every write
+	persist := func([]batchOp) error {
+		var pairs []kvPair
+		iter := mem.Iterator(nil, nil)
+		for ; iter.Valid(); iter.Next() {
+			pairs = append(pairs, kvPair{iter.Key(), iter.Value()})
+		}
+		iter.Close()
+
+		enc := xsalsa20symmetric.EncryptSymmetric(cdc.MustMarshalBinaryBare(pairs), secret)
+		file := make([]byte, 0, len(salt)+len(enc))
+		file = append(append(file, salt...), enc...)
+		return writeFileAtomic(path, file)
+	}
+	return persistentDB{mem, persist}, nil
+}
+
+// fileDBSecret derives the 32 bytes secret of a keyring file from the master
+// password, the same way mintkey derives it from a key passphrase.
+func fileDBSecret(salt []byte, password string) ([]byte, error) {
+	if password == "" {
+		return nil, errors.New("keyring password must not be empty")
+	}
+	key, err := bcrypt.GenerateFromPassword(salt, []byte(password), mintkey.BcryptSecurityParameter)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not derive the keyring secret")
+	}
+	return crypto.Sha256(key), nil
+}
+
+// writeFileAtomic replaces the file at path, readable by the user only, so
+// that a crash leaves either the old or the new content: the content is
+// synced to a temporary file of the same directory, which is then renamed.
+func writeFileAtomic(path string, bz []byte) (err error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(bz); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -9,6 +9,9 @@
 //
 // In particular, this package (together with bip39) provides all necessary functionality to derive keys from
 // mnemonics generated during the cosmos fundraiser.
+//
+// ed25519 keys are derived as specified by SLIP-0010, which only supports hardened derivation:
+//  https://github.com/satoshilabs/slips/blob/master/slip-0010.md
 package hd
 
 import (
@@ -28,6 +31,8 @@ import (
 const (
 	BIP44Prefix        = "44'/118'/"
 	FullFundraiserPath = BIP44Prefix + "0'/0/0"
+	// FullEd25519Path is the fundraiser path with all levels hardened, for SLIP-0010 ed25519 derivation.
+	FullEd25519Path = BIP44Prefix + "0'/0'/0'"
 )
 
 // BIP44Params wraps BIP 44 params (5 level BIP 32 path).
@@ -163,6 +168,13 @@ func (p BIP44Params) String() string {
 		p.addressIdx)
 }
 
+// HardenedString returns the BIP44 path with all levels hardened, as required to derive ed25519 keys:
+// m / purpose' / coin_type' / account' / change' / address_index'
+func (p BIP44Params) HardenedString() string {
+	path := p.DerivationPath()
+	return fmt.Sprintf("%d'/%d'/%d'/%d'/%d'", path[0], path[1], path[2], path[3], path[4])
+}
+
 // ComputeMastersFromSeed returns the master public key, master secret, and chain code in hex.
 func ComputeMastersFromSeed(seed []byte) (secret [32]byte, chainCode [32]byte) {
 	masterSecret := []byte("Bitcoin seed")
@@ -201,6 +213,40 @@ func DerivePrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path str
 	return derivedKey, nil
 }
 
+// ComputeEd25519MastersFromSeed returns the SLIP-0010 ed25519 master secret and chain code.
+func ComputeEd25519MastersFromSeed(seed []byte) (secret [32]byte, chainCode [32]byte) {
+	masterSecret := []byte("ed25519 seed")
+	secret, chainCode = i64(masterSecret, seed)
+
+	return
+}
+
+// DeriveEd25519PrivateKeyForPath derives the ed25519 private key seed by following the SLIP-0010 path from
+// privKeyBytes, using the given chainCode. All levels of the path must be hardened.
+func DeriveEd25519PrivateKeyForPath(privKeyBytes [32]byte, chainCode [32]byte, path string) ([32]byte, error) {
+	data := privKeyBytes
+	parts := strings.Split(path, "/")
+	for _, part := range parts {
+		if !isHardened(part) {
+			return [32]byte{}, fmt.Errorf("invalid SLIP-0010 ed25519 path: %s is not hardened", part)
+		}
+		idx, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return [32]byte{}, fmt.Errorf("invalid SLIP-0010 ed25519 path: %s", err)
+		}
+		data, chainCode = deriveEd25519PrivateKey(data, chainCode, uint32(idx))
+	}
+	return data, nil
+}
+
+// deriveEd25519PrivateKey derives the hardened ed25519 private key with index and chainCode.
+// Unlike for secp256k1, the child key is the left half of the HMAC itself.
+func deriveEd25519PrivateKey(privKeyBytes [32]byte, chainCode [32]byte, index uint32) ([32]byte, [32]byte) {
+	data := append([]byte{byte(0)}, privKeyBytes[:]...)
+	data = append(data, uint32ToBytes(index|0x80000000)...)
+	return i64(chainCode[:], data)
+}
+
 // derivePrivateKey derives the private key with index and chainCode.
 // If harden is true, the derivation is 'hardened'.
 // It returns the new private key and new chain code.
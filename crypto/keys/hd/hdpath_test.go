@@ -131,3 +131,31 @@ func ExampleSomeBIP32TestVecs() {
 	//
 	// c4c11d8c03625515905d7e89d25dfc66126fbc629ecca6db489a1a72fc4bda78
 }
+
+func TestDeriveEd25519PrivateKeyForPath(t *testing.T) {
+	// test vector 1 of SLIP-0010
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, ch := ComputeEd25519MastersFromSeed(seed)
+	assert.Equal(t, "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7", hex.EncodeToString(master[:]))
+
+	cases := []struct {
+		path string
+		priv string
+	}{
+		{"0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"0'/1'", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, tc := range cases {
+		priv, err := DeriveEd25519PrivateKeyForPath(master, ch, tc.path)
+		assert.NoError(t, err, tc.path)
+		assert.Equal(t, tc.priv, hex.EncodeToString(priv[:]), tc.path)
+	}
+
+	// ed25519 keys can only be derived with hardened paths
+	_, err := DeriveEd25519PrivateKeyForPath(master, ch, FullFundraiserPath)
+	assert.Error(t, err)
+	assert.Equal(t, FullEd25519Path, NewFundraiserParams(0, 0).HardenedString())
+	_, err = DeriveEd25519PrivateKeyForPath(master, ch, FullEd25519Path)
+	assert.NoError(t, err)
+}
@@ -9,14 +9,17 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/cosmos/go-bip39"
+	ed25519lib "github.com/tendermint/ed25519"
 
 	"github.com/yukimochizuki/cosmos-sdk/crypto"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/hd"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/mintkey"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/remotesigner"
 	"github.com/yukimochizuki/cosmos-sdk/types"
 
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/keyerror"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -61,8 +64,8 @@ const (
 
 var (
 	// ErrUnsupportedSigningAlgo is raised when the caller tries to use a
-	// different signing scheme than secp256k1.
-	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo: only secp256k1 is supported")
+	// signing scheme that isn't supported for the kind of key.
+	ErrUnsupportedSigningAlgo = errors.New("unsupported signing algo")
 
 	// ErrUnsupportedLanguage is raised when the caller tries to use a
 	// different language than english for creating a mnemonic sentence.
@@ -92,7 +95,7 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 	if language != English {
 		return nil, "", ErrUnsupportedLanguage
 	}
-	if algo != Secp256k1 {
+	if algo != Secp256k1 && algo != Ed25519 {
 		err = ErrUnsupportedSigningAlgo
 		return
 	}
@@ -109,12 +112,12 @@ func (kb dbKeybase) CreateMnemonic(name string, language Language, passwd string
 	}
 
 	seed := bip39.NewSeed(mnemonic, defaultBIP39Passphrase)
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath, algo)
 	return
 }
 
 // TEMPORARY METHOD UNTIL WE FIGURE OUT USER FACING HD DERIVATION API
-func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err error) {
+func (kb dbKeybase) CreateKey(name, mnemonic, passwd string, algo SigningAlgo) (info Info, err error) {
 	words := strings.Split(mnemonic, " ")
 	if len(words) != 12 && len(words) != 24 {
 		err = fmt.Errorf("recovering only works with 12 word (fundraiser) or 24 word mnemonics, got: %v words", len(words))
@@ -124,7 +127,7 @@ func (kb dbKeybase) CreateKey(name, mnemonic, passwd string) (info Info, err err
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath, algo)
 	return
 }
 
@@ -141,16 +144,16 @@ func (kb dbKeybase) CreateFundraiserKey(name, mnemonic, passwd string) (info Inf
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath)
+	info, err = kb.persistDerivedKey(seed, passwd, name, hd.FullFundraiserPath, Secp256k1)
 	return
 }
 
-func (kb dbKeybase) Derive(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params) (info Info, err error) {
+func (kb dbKeybase) Derive(name, mnemonic, bip39Passphrase, encryptPasswd string, params hd.BIP44Params, algo SigningAlgo) (info Info, err error) {
 	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, bip39Passphrase)
 	if err != nil {
 		return
 	}
-	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String())
+	info, err = kb.persistDerivedKey(seed, encryptPasswd, name, params.String(), algo)
 
 	return
 }
@@ -175,10 +178,18 @@ func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error
 	return kb.writeOfflineKey(pub, name), nil
 }
 
-func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
-	// create master key and derive first key:
-	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
-	derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
+// CreateRemote creates a new reference to the key named remoteName of the
+// signer listening on signerAddr. The signer is queried for the public key.
+func (kb dbKeybase) CreateRemote(name, signerAddr, remoteName string) (Info, error) {
+	pub, err := remotesigner.GetPubKey(signerAddr, remoteName)
+	if err != nil {
+		return nil, err
+	}
+	return kb.writeRemoteKey(pub, signerAddr, remoteName, name), nil
+}
+
+func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string, algo SigningAlgo) (info Info, err error) {
+	priv, err := derivePrivKey(seed, fullHdPath, algo)
 	if err != nil {
 		return
 	}
@@ -186,14 +197,46 @@ func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath str
 	// if we have a password, use it to encrypt the private key and store it
 	// else store the public key only
 	if passwd != "" {
-		info = kb.writeLocalKey(secp256k1.PrivKeySecp256k1(derivedPriv), name, passwd)
+		info = kb.writeLocalKey(priv, name, passwd, algo)
 	} else {
-		pubk := secp256k1.PrivKeySecp256k1(derivedPriv).PubKey()
-		info = kb.writeOfflineKey(pubk, name)
+		info = kb.writeOfflineKey(priv.PubKey(), name)
 	}
 	return
 }
 
+// derivePrivKey derives the private key of the given algorithm from the seed.
+// ed25519 keys are derived with SLIP-0010, which hardens all levels of the
+// path.
+func derivePrivKey(seed []byte, fullHdPath string, algo SigningAlgo) (tmcrypto.PrivKey, error) {
+	switch algo {
+	case Secp256k1:
+		// create master key and derive first key:
+		masterPriv, ch := hd.ComputeMastersFromSeed(seed)
+		derivedPriv, err := hd.DerivePrivateKeyForPath(masterPriv, ch, fullHdPath)
+		if err != nil {
+			return nil, err
+		}
+		return secp256k1.PrivKeySecp256k1(derivedPriv), nil
+	case Ed25519:
+		params, err := hd.NewParamsFromPath(fullHdPath)
+		if err != nil {
+			return nil, err
+		}
+		masterPriv, ch := hd.ComputeEd25519MastersFromSeed(seed)
+		derivedPriv, err := hd.DeriveEd25519PrivateKeyForPath(masterPriv, ch, params.HardenedString())
+		if err != nil {
+			return nil, err
+		}
+		// the private key holds the seed followed by the public key
+		var priv [64]byte
+		copy(priv[:32], derivedPriv[:])
+		ed25519lib.MakePublicKey(&priv)
+		return ed25519.PrivKeyEd25519(priv), nil
+	default:
+		return nil, ErrUnsupportedSigningAlgo
+	}
+}
+
 // List returns the keys from storage in alphabetical order.
 func (kb dbKeybase) List() ([]Info, error) {
 	var res []Info
@@ -275,6 +318,16 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 		}
 		cdc.MustUnmarshalBinary([]byte(signed), sig)
 		return sig, linfo.GetPubKey(), nil
+	case remoteInfo:
+		rinfo := info.(remoteInfo)
+		sig, pub, err = remotesigner.Sign(rinfo.SignerAddr, rinfo.RemoteName, msg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !pub.Equals(rinfo.PubKey) {
+			return nil, nil, fmt.Errorf("remote signer signed with another key than %s", name)
+		}
+		return sig, pub, nil
 	}
 	sig, err = priv.Sign(msg)
 	if err != nil {
@@ -303,7 +356,7 @@ func (kb dbKeybase) ExportPrivateKeyObject(name string, passphrase string) (tmcr
 		}
 	case ledgerInfo:
 		return nil, errors.New("Only works on local private keys")
-	case offlineInfo:
+	case offlineInfo, remoteInfo:
 		return nil, errors.New("Only works on local private keys")
 	}
 	return priv, nil
@@ -368,7 +421,7 @@ func (kb dbKeybase) ImportPubKey(name string, armor string) (err error) {
 // Delete removes key forever, but we must present the
 // proper passphrase before deleting it (for security).
 // A passphrase of 'yes' is used to delete stored
-// references to offline and Ledger / HW wallet keys
+// references to offline, remote and Ledger / HW wallet keys
 func (kb dbKeybase) Delete(name, passphrase string) error {
 	// verify we have the proper password before deleting
 	info, err := kb.Get(name)
@@ -386,7 +439,7 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 		kb.db.DeleteSync(infoKey(name))
 		return nil
 	case ledgerInfo:
-	case offlineInfo:
+	case offlineInfo, remoteInfo:
 		if passphrase != "yes" {
 			return fmt.Errorf("enter 'yes' exactly to delete the key - this cannot be undone")
 		}
@@ -420,7 +473,7 @@ func (kb dbKeybase) Update(name, oldpass string, getNewpass func() (string, erro
 		if err != nil {
 			return err
 		}
-		kb.writeLocalKey(key, name, newpass)
+		kb.writeLocalKey(key, name, newpass, linfo.GetAlgo())
 		return nil
 	default:
 		return fmt.Errorf("locally stored key required")
@@ -432,12 +485,12 @@ func (kb dbKeybase) CloseDB() {
 	kb.db.Close()
 }
 
-func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string) Info {
+func (kb dbKeybase) writeLocalKey(priv tmcrypto.PrivKey, name, passphrase string, algo SigningAlgo) Info {
 	// encrypt private key using passphrase
 	privArmor := mintkey.EncryptArmorPrivKey(priv, passphrase)
 	// make Info
 	pub := priv.PubKey()
-	info := newLocalInfo(name, pub, privArmor)
+	info := newLocalInfo(name, pub, privArmor, algo)
 	kb.writeInfo(info, name)
 	return info
 }
@@ -454,6 +507,12 @@ func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) Info {
 	return info
 }
 
+func (kb dbKeybase) writeRemoteKey(pub tmcrypto.PubKey, signerAddr, remoteName, name string) Info {
+	info := newRemoteInfo(name, pub, signerAddr, remoteName)
+	kb.writeInfo(info, name)
+	return info
+}
+
 func (kb dbKeybase) writeInfo(info Info, name string) {
 	// write the info by key
 	key := infoKey(name)
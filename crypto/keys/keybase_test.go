@@ -38,8 +38,8 @@ func TestKeyManagement(t *testing.T) {
 	require.Nil(t, err)
 	assert.Empty(t, l)
 
-	_, _, err = cstore.CreateMnemonic(n1, English, p1, Ed25519)
-	require.Error(t, err, "ed25519 keys are currently not supported by keybase")
+	_, _, err = cstore.CreateMnemonic(n1, English, p1, SigningAlgo("rsa"))
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
 
 	// create some keys
 	_, err = cstore.Get(n1)
@@ -345,13 +345,52 @@ func TestSeedPhrase(t *testing.T) {
 
 	// let us re-create it from the mnemonic-phrase
 	params := *hd.NewFundraiserParams(0, 0)
-	newInfo, err := cstore.Derive(n2, mnemonic, defaultBIP39Passphrase, p2, params)
+	newInfo, err := cstore.Derive(n2, mnemonic, defaultBIP39Passphrase, p2, params, algo)
 	require.NoError(t, err)
 	require.Equal(t, n2, newInfo.GetName())
 	require.Equal(t, info.GetPubKey().Address(), newInfo.GetPubKey().Address())
 	require.Equal(t, info.GetPubKey(), newInfo.GetPubKey())
 }
 
+func TestEd25519Keys(t *testing.T) {
+	cstore := New(dbm.NewMemDB())
+	n1, n2, p1 := "ed", "ed-recovered", "1234"
+
+	info, mnemonic, err := cstore.CreateMnemonic(n1, English, p1, Ed25519)
+	require.NoError(t, err)
+	require.IsType(t, ed25519.PubKeyEd25519{}, info.GetPubKey())
+	stored, err := cstore.Get(n1)
+	require.NoError(t, err)
+	require.Equal(t, Ed25519, stored.(localInfo).GetAlgo())
+
+	// signatures verify with the stored public key
+	msg := []byte("ed25519 msg")
+	sig, pub, err := cstore.Sign(n1, p1, msg)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(msg, sig))
+
+	// the key is recovered from the mnemonic, but differs from the secp256k1 key
+	recovered, err := cstore.CreateKey(n2, mnemonic, p1, Ed25519)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), recovered.GetPubKey())
+	secp, err := cstore.CreateKey("secp", mnemonic, p1, Secp256k1)
+	require.NoError(t, err)
+	require.NotEqual(t, info.GetAddress(), secp.GetAddress())
+
+	// the algorithm is kept when the passphrase changes
+	require.NoError(t, cstore.Update(n1, p1, func() (string, error) { return "5678", nil }))
+	stored, err = cstore.Get(n1)
+	require.NoError(t, err)
+	require.Equal(t, Ed25519, stored.(localInfo).GetAlgo())
+	_, _, err = cstore.Sign(n1, "5678", msg)
+	require.NoError(t, err)
+
+	// Ledger devices only hold secp256k1 keys
+	_, err = cstore.CreateLedger("ledger", nil, Ed25519)
+	require.Equal(t, ErrUnsupportedSigningAlgo, err)
+}
+
 func ExampleNew() {
 	// Select the encryption and storage for your cryptostore
 	cstore := New(
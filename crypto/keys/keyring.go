@@ -0,0 +1,127 @@
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// Keyring backends, i.e. the storages a Keybase can keep its keys in.
+const (
+	// BackendLevelDB stores armored keys in a LevelDB, the default.
+	BackendLevelDB = "leveldb"
+	// BackendFile stores all keys in a single file encrypted with a master
+	// password, see NewFileDB.
+	BackendFile = "file"
+	// BackendPass stores each entry in its own GPG encrypted file, in the
+	// layout of the pass password manager, see NewPassDB.
+	BackendPass = "pass"
+	// BackendMemory keeps keys in memory only, for tests.
+	BackendMemory = "memory"
+)
+
+// Backends lists the supported keyring backends.
+var Backends = []string{BackendLevelDB, BackendFile, BackendPass, BackendMemory}
+
+// Migrate copies all the entries of the src keyring to dst and returns the
+// number of keys copied. The keys are copied as stored, still encrypted with
+// their passphrases. It fails without writing anything if a key exists in
+// both keyrings.
+func Migrate(src, dst dbm.DB) (n int, err error) {
+	iter := src.Iterator(nil, nil)
+	defer iter.Close()
+
+	batch := dst.NewBatch()
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if strings.HasSuffix(string(key), infoSuffix) {
+			if dst.Has(key) {
+				name := strings.TrimSuffix(string(key), "."+infoSuffix)
+				return 0, fmt.Errorf("key %s already exists in the destination keyring", name)
+			}
+			n++
+		}
+		batch.Set(key, iter.Value())
+	}
+	batch.WriteSync()
+	return n, nil
+}
+
+//__________________________________________________________
+
+// persistentDB is an in-memory dbm.DB persisting every write with a backend,
+// for backends that are not databases themselves. The writes of a batch are
+// persisted at once. Persisting errors panic, as with the other dbm.DB
+// implementations.
+type persistentDB struct {
+	*dbm.MemDB
+	persist func(ops []batchOp) error
+}
+
+var _ dbm.DB = persistentDB{}
+
+func (db persistentDB) Set(key []byte, value []byte) {
+	db.MemDB.Set(key, value)
+	if err := db.persist([]batchOp{{key, value, false}}); err != nil {
+		panic(err)
+	}
+}
+
+func (db persistentDB) SetSync(key []byte, value []byte) {
+	db.Set(key, value)
+}
+
+func (db persistentDB) Delete(key []byte) {
+	db.MemDB.Delete(key)
+	if err := db.persist([]batchOp{{key, nil, true}}); err != nil {
+		panic(err)
+	}
+}
+
+func (db persistentDB) DeleteSync(key []byte) {
+	db.Delete(key)
+}
+
+func (db persistentDB) NewBatch() dbm.Batch {
+	return &persistentBatch{db: db}
+}
+
+// persistentBatch applies its writes to a persistentDB, and persists them
+// with a single call to its backend.
+type persistentBatch struct {
+	db  persistentDB
+	ops []batchOp
+}
+
+type batchOp struct {
+	key     []byte
+	value   []byte
+	deleted bool
+}
+
+func (b *persistentBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, batchOp{key, value, false})
+}
+
+func (b *persistentBatch) Delete(key []byte) {
+	b.ops = append(b.ops, batchOp{key, nil, true})
+}
+
+func (b *persistentBatch) Write() {
+	for _, op := range b.ops {
+		if op.deleted {
+			b.db.MemDB.Delete(op.key)
+		} else {
+			b.db.MemDB.Set(op.key, op.value)
+		}
+	}
+	if err := b.db.persist(b.ops); err != nil {
+		panic(err)
+	}
+	b.ops = nil
+}
+
+func (b *persistentBatch) WriteSync() {
+	b.Write()
+}
@@ -0,0 +1,177 @@
+package keys
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/keyerror"
+)
+
+func TestFileDB(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cosmos-sdk-keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "keyring", "keys")
+
+	_, err = NewFileDB(path, "")
+	require.Error(t, err)
+
+	db, err := NewFileDB(path, "master")
+	require.NoError(t, err)
+	kb := New(db)
+	info, _, err := kb.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	_, _, err = kb.CreateMnemonic("jane", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	require.NoError(t, kb.Delete("jane", "secretcpw"))
+
+	// the file is replaced by renaming a temporary file
+	tmps, err := filepath.Glob(path + ".tmp*")
+	require.NoError(t, err)
+	require.Empty(t, tmps)
+
+	// the keys persist and can only be read with the master password
+	_, err = NewFileDB(path, "wrong")
+	require.True(t, keyerror.IsErrWrongPassword(err))
+
+	db, err = NewFileDB(path, "master")
+	require.NoError(t, err)
+	kb = New(db)
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, info.GetPubKey(), infos[0].GetPubKey())
+	_, _, err = kb.Sign("john", "secretcpw", []byte("msg"))
+	require.NoError(t, err)
+}
+
+func TestPersistentBatch(t *testing.T) {
+	var persisted [][]batchOp
+	db := persistentDB{dbm.NewMemDB(), func(ops []batchOp) error {
+		persisted = append(persisted, ops)
+		return nil
+	}}
+	db.Set([]byte("a"), []byte("1"))
+
+	// the writes of a batch are persisted at once
+	batch := db.NewBatch()
+	batch.Set([]byte("b"), []byte("2"))
+	batch.Delete([]byte("a"))
+	batch.WriteSync()
+	require.Len(t, persisted, 2)
+	require.Equal(t, []batchOp{{[]byte("b"), []byte("2"), false}, {[]byte("a"), nil, true}}, persisted[1])
+	require.False(t, db.Has([]byte("a")))
+	require.Equal(t, []byte("2"), db.Get([]byte("b")))
+}
+
+func TestMigrate(t *testing.T) {
+	src, dst := dbm.NewMemDB(), dbm.NewMemDB()
+	srcKb, dstKb := New(src), New(dst)
+	info, _, err := srcKb.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	_, _, err = srcKb.CreateMnemonic("jane", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+
+	n, err := Migrate(src, dst)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	migrated, err := dstKb.GetByAddress(info.GetAddress())
+	require.NoError(t, err)
+	require.Equal(t, "john", migrated.GetName())
+	_, _, err = dstKb.Sign("john", "secretcpw", []byte("msg"))
+	require.NoError(t, err)
+
+	// existing keys aren't overwritten
+	_, _, err = srcKb.CreateMnemonic("bob", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	_, err = Migrate(src, dst)
+	require.Error(t, err)
+	_, err = dstKb.Get("bob")
+	require.Error(t, err)
+}
+
+// fakeGPG "encrypts" its input by prefixing it with its recipients, so that
+// the pass backend can be tested without GPG keys.
+const fakeGPG = `#!/bin/sh
+out= file= decrypt= recipients=
+while [ $# -gt 0 ]; do
+	case "$1" in
+	--output) out="$2"; shift ;;
+	--recipient) recipients="$recipients$2;"; shift ;;
+	--decrypt) decrypt=1 ;;
+	--*) ;;
+	*) file="$1" ;;
+	esac
+	shift
+done
+if [ -n "$decrypt" ]; then
+	exec tail -n +2 "$file"
+fi
+{ printf 'fake:%s\n' "$recipients"; cat; } > "$out"
+`
+
+func TestPassDB(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake gpg is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "cosmos-sdk-keyring")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	gpg := filepath.Join(dir, "gpg")
+	require.NoError(t, ioutil.WriteFile(gpg, []byte(fakeGPG), 0700))
+	defer func(gpgCommand string) { GPGCommand = gpgCommand }(GPGCommand)
+	GPGCommand = gpg
+	store := filepath.Join(dir, "store")
+	require.NoError(t, os.Mkdir(store, 0700))
+
+	// the store must be initialized with a recipient
+	_, err = NewPassDB(store)
+	require.Error(t, err)
+	err = ioutil.WriteFile(filepath.Join(store, passGPGIDFile), []byte("john@example.com\n"), 0600)
+	require.NoError(t, err)
+
+	db, err := NewPassDB(store)
+	require.NoError(t, err)
+	kb := New(db)
+	info, _, err := kb.CreateMnemonic("john", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	_, _, err = kb.CreateMnemonic("jane", English, "secretcpw", Secp256k1)
+	require.NoError(t, err)
+	require.NoError(t, kb.Delete("jane", "secretcpw"))
+
+	// every entry is encrypted to the recipient in its own file, and no
+	// temporary file is left behind
+	bz, err := ioutil.ReadFile(filepath.Join(store, "john.info"+passExt))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(bz, []byte("fake:john@example.com;\n")))
+	_, err = os.Stat(filepath.Join(store, "jane.info"+passExt))
+	require.True(t, os.IsNotExist(err))
+	tmps, err := filepath.Glob(filepath.Join(store, ".tmp*"))
+	require.NoError(t, err)
+	require.Empty(t, tmps)
+
+	// the keys are decrypted when the store is opened again
+	db, err = NewPassDB(store)
+	require.NoError(t, err)
+	kb = New(db)
+	infos, err := kb.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, info.GetPubKey(), infos[0].GetPubKey())
+	_, _, err = kb.Sign("john", "secretcpw", []byte("msg"))
+	require.NoError(t, err)
+
+	// gpg failures are reported
+	GPGCommand = filepath.Join(dir, "missing")
+	_, err = NewPassDB(store)
+	require.Error(t, err)
+}
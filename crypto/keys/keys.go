@@ -7,6 +7,6 @@ const (
 	// Secp256k1 uses the Bitcoin secp256k1 ECDSA parameters.
 	Secp256k1 = SigningAlgo("secp256k1")
 	// Ed25519 represents the Ed25519 signature system.
-	// It is currently not supported for end-user keys (wallets/ledgers).
+	// Keys are derived as specified by SLIP-0010. It is not supported by Ledger keys.
 	Ed25519 = SigningAlgo("ed25519")
 )
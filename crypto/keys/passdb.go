@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const (
+	passGPGIDFile = ".gpg-id"
+	passExt       = ".gpg"
+)
+
+// GPGCommand is the gpg binary used by the pass backend.
+var GPGCommand = "gpg"
+
+// NewPassDB opens the keyring stored in dir in the layout of the pass
+// password manager: each entry is a file named after its escaped key with a
+// .gpg extension, encrypted with GPG to the recipients listed in the .gpg-id
+// file, as written by `pass init`. Entries can thus be read and backed up with
+// pass and GPG tooling by pointing PASSWORD_STORE_DIR to dir. All entries are
+// decrypted when the keyring is opened, which may require the gpg-agent to
+// unlock the recipient's secret key.
+func NewPassDB(dir string) (dbm.DB, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(dir, passGPGIDFile))
+	if err != nil {
+		return nil, errors.Wrapf(err, "no GPG recipient for %s, initialize it with `PASSWORD_STORE_DIR=%s pass init <gpg-id>`", dir, dir)
+	}
+	recipients := strings.Fields(string(bz))
+	if len(recipients) == 0 {
+		return nil, errors.Errorf("no GPG recipient in %s", filepath.Join(dir, passGPGIDFile))
+	}
+
+	mem := dbm.NewMemDB()
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		name := file.Name()
+		if file.IsDir() || !strings.HasSuffix(name, passExt) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(name, passExt))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid entry %s", name)
+		}
+		value, err := runGPG(nil, "--quiet", "--decrypt", filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		mem.Set([]byte(key), value)
+	}
+
+	persist := func(ops []batchOp) error {
+		for _, op := range ops {
+			if err := persistPassEntry(dir, recipients, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return persistentDB{mem, persist}, nil
+}
+
+// persistPassEntry writes or removes the file of an entry. A written entry is
+// encrypted to a temporary file first, which then replaces the entry file.
+func persistPassEntry(dir string, recipients []string, op batchOp) error {
+	// escaping keeps entries in dir, whatever the key name
+	path := filepath.Join(dir, url.PathEscape(string(op.key))+passExt)
+	if op.deleted {
+		err := os.Remove(path)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".tmp")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	args := []string{"--yes", "--encrypt", "--output", tmp.Name()}
+	for _, recipient := range recipients {
+		args = append(args, "--recipient", recipient)
+	}
+	if _, err = runGPG(op.value, args...); err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// runGPG runs gpg in batch mode with stdin as input and returns its output.
+func runGPG(stdin []byte, args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(GPGCommand, append([]string{"--batch"}, args...)...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "%s failed: %s", GPGCommand, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package remotesigner
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// GetPubKey returns the public key of the named key of the signer listening
+// on addr.
+func GetPubKey(addr, keyName string) (crypto.PubKey, error) {
+	res, err := request(addr, PubKeyRequest{keyName})
+	if err != nil {
+		return nil, err
+	}
+	pubKeyRes, ok := res.(PubKeyResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response %T from signer", res)
+	}
+	if pubKeyRes.Error != "" {
+		return nil, errors.New(pubKeyRes.Error)
+	}
+	return pubKeyRes.PubKey, nil
+}
+
+// Sign signs the StdSignDoc bytes with the named key of the signer listening
+// on addr and returns the signature and the public key of the key.
+func Sign(addr, keyName string, signBytes []byte) ([]byte, crypto.PubKey, error) {
+	res, err := request(addr, SignRequest{keyName, signBytes})
+	if err != nil {
+		return nil, nil, err
+	}
+	signRes, ok := res.(SignResponse)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected response %T from signer", res)
+	}
+	if signRes.Error != "" {
+		return nil, nil, errors.New(signRes.Error)
+	}
+	return signRes.Signature, signRes.PubKey, nil
+}
+
+// request sends the request on a new connection to the signer and returns
+// its response.
+func request(addr string, req Msg) (Msg, error) {
+	network, address, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout(network, address, DefaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the signer at %s: %v", addr, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(DefaultTimeout)); err != nil {
+		return nil, err
+	}
+	if err := writeMsg(conn, req); err != nil {
+		return nil, err
+	}
+	return readMsg(conn)
+}
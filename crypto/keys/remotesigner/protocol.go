@@ -0,0 +1,127 @@
+// Package remotesigner implements the protocol used to sign with keys held by
+// a remote signing daemon. Each connection carries a single request and its
+// response, both amino encoded with a length prefix.
+//
+// The protocol has no authentication of its own: the daemon only listens on
+// unix sockets in directories which only their owner can access. Clients on
+// other hosts reach it through an authenticated tunnel, e.g. an SSH forwarding
+// of the socket to a local unix socket, which is only accessible to its owner
+// too.
+package remotesigner
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	amino "github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+)
+
+const (
+	// maximum size of an encoded request or response
+	maxMsgSize = 1024 * 1024
+
+	// DefaultTimeout bounds the duration of a request, including the time
+	// the signer takes to sign, e.g. with a hardware wallet.
+	DefaultTimeout = 30 * time.Second
+)
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+	cdc.RegisterInterface((*Msg)(nil), nil)
+	cdc.RegisterConcrete(PubKeyRequest{}, "cosmos-sdk/remotesigner/PubKeyRequest", nil)
+	cdc.RegisterConcrete(PubKeyResponse{}, "cosmos-sdk/remotesigner/PubKeyResponse", nil)
+	cdc.RegisterConcrete(SignRequest{}, "cosmos-sdk/remotesigner/SignRequest", nil)
+	cdc.RegisterConcrete(SignResponse{}, "cosmos-sdk/remotesigner/SignResponse", nil)
+}
+
+// Msg is a request or a response of the protocol.
+type Msg interface{}
+
+// PubKeyRequest asks for the public key of a key of the signer.
+type PubKeyRequest struct {
+	KeyName string
+}
+
+// PubKeyResponse returns the requested public key, or an error.
+type PubKeyResponse struct {
+	PubKey crypto.PubKey
+	Error  string
+}
+
+// SignRequest asks for the signature of StdSignDoc bytes with a key of the
+// signer.
+type SignRequest struct {
+	KeyName   string
+	SignBytes []byte
+}
+
+// SignResponse returns the signature and the public key of the signing key,
+// or an error.
+type SignResponse struct {
+	Signature []byte
+	PubKey    crypto.PubKey
+	Error     string
+}
+
+func writeMsg(w io.Writer, msg Msg) error {
+	_, err := cdc.MarshalBinaryWriter(w, msg)
+	return err
+}
+
+func readMsg(r io.Reader) (msg Msg, err error) {
+	_, err = cdc.UnmarshalBinaryReader(r, &msg, maxMsgSize)
+	return msg, err
+}
+
+// splitAddr splits a signer address, either unix:///path/to/socket or
+// tcp://host:port, into the network and address to dial or listen on.
+func splitAddr(addr string) (network, address string, err error) {
+	parts := strings.SplitN(addr, "://", 2)
+	if len(parts) != 2 || (parts[0] != "unix" && parts[0] != "tcp") {
+		return "", "", fmt.Errorf("invalid signer address %q, expected unix://<path> or tcp://<host>:<port>", addr)
+	}
+	return parts[0], parts[1], nil
+}
+
+// Listen listens for connections on the given signer address, which must be
+// a unix socket. The directory of the socket is created if it doesn't exist
+// and must only be accessible to its owner, so that no one else can connect
+// to the socket, whatever its permissions.
+func Listen(addr string) (net.Listener, error) {
+	network, address, err := splitAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	if network != "unix" {
+		return nil, errNotUnix(addr)
+	}
+	dir := filepath.Dir(address)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("the directory %s of the signer socket is accessible to other users (mode %v), "+
+			"it must only be accessible to its owner (mode 0700)", dir, fi.Mode().Perm())
+	}
+	return net.Listen(network, address)
+}
+
+// the error returned when the signer would serve on another network than
+// unix sockets, where anyone reaching it could get signatures
+func errNotUnix(addr string) error {
+	return fmt.Errorf("the signer only serves on unix sockets, not %s: "+
+		"forward the socket to remote clients over SSH, e.g. with ssh -L <local socket>:<path>", addr)
+}
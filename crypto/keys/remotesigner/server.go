@@ -0,0 +1,160 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/libs/log"
+)
+
+// AllowAllMsgTypes in the allowed message types of a key lets it sign any
+// message.
+const AllowAllMsgTypes = "*"
+
+// Signer signs bytes with named keys, as a keys.Keybase does.
+type Signer interface {
+	Sign(name, passphrase string, msg []byte) ([]byte, crypto.PubKey, error)
+}
+
+// KeyPolicy configures a key served by a Server.
+type KeyPolicy struct {
+	PubKey     crypto.PubKey
+	Passphrase string
+
+	// AllowedMsgTypes lists the amino names of the messages, such as
+	// cosmos-sdk/Send, that the key may sign. A transaction is only signed if
+	// all its messages are allowed.
+	AllowedMsgTypes []string
+}
+
+func (p KeyPolicy) allows(msgType string) bool {
+	for _, allowed := range p.AllowedMsgTypes {
+		if allowed == AllowAllMsgTypes || allowed == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+// Server serves signing requests for the keys it is configured with.
+type Server struct {
+	mtx      sync.Mutex // serializes signing, e.g. for hardware wallets
+	signer   Signer
+	policies map[string]KeyPolicy
+	logger   log.Logger
+}
+
+// NewServer returns a Server signing with signer, for the keys in policies
+// only.
+func NewServer(signer Signer, policies map[string]KeyPolicy, logger log.Logger) *Server {
+	return &Server{
+		signer:   signer,
+		policies: policies,
+		logger:   logger,
+	}
+}
+
+// Serve handles the connections accepted by the listener until it is closed.
+// The listener must listen on a unix socket, see Listen.
+func (s *Server) Serve(ln net.Listener) error {
+	if ln.Addr().Network() != "unix" {
+		return errNotUnix(ln.Addr().String())
+	}
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(DefaultTimeout)); err != nil {
+		s.logger.Error("could not set connection deadline", "err", err)
+		return
+	}
+	req, err := readMsg(conn)
+	if err != nil {
+		s.logger.Error("could not read request", "err", err)
+		return
+	}
+	if err := writeMsg(conn, s.handleRequest(req)); err != nil {
+		s.logger.Error("could not write response", "err", err)
+	}
+}
+
+func (s *Server) handleRequest(req Msg) Msg {
+	switch req := req.(type) {
+	case PubKeyRequest:
+		policy, ok := s.policies[req.KeyName]
+		if !ok {
+			return PubKeyResponse{Error: fmt.Sprintf("key %s is not served", req.KeyName)}
+		}
+		return PubKeyResponse{PubKey: policy.PubKey}
+
+	case SignRequest:
+		sig, pubKey, err := s.sign(req.KeyName, req.SignBytes)
+		if err != nil {
+			s.logger.Info("refused to sign", "key", req.KeyName, "err", err)
+			return SignResponse{Error: err.Error()}
+		}
+		s.logger.Info("signed", "key", req.KeyName)
+		return SignResponse{Signature: sig, PubKey: pubKey}
+
+	default:
+		return SignResponse{Error: fmt.Sprintf("unknown request %T", req)}
+	}
+}
+
+func (s *Server) sign(keyName string, signBytes []byte) ([]byte, crypto.PubKey, error) {
+	policy, ok := s.policies[keyName]
+	if !ok {
+		return nil, nil, fmt.Errorf("key %s is not served", keyName)
+	}
+
+	msgTypes, err := signDocMsgTypes(signBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, msgType := range msgTypes {
+		if !policy.allows(msgType) {
+			return nil, nil, fmt.Errorf("key %s may not sign %s messages", keyName, msgType)
+		}
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.signer.Sign(keyName, policy.Passphrase, signBytes)
+}
+
+// signDocMsgTypes returns the amino names of the messages of the StdSignDoc
+// JSON. Messages without a name are rejected, as they can't be checked.
+func signDocMsgTypes(signBytes []byte) ([]string, error) {
+	var doc struct {
+		Msgs []struct {
+			Type string `json:"type"`
+		} `json:"msgs"`
+	}
+	if err := json.Unmarshal(signBytes, &doc); err != nil {
+		return nil, fmt.Errorf("sign bytes are not a StdSignDoc: %v", err)
+	}
+	if len(doc.Msgs) == 0 {
+		return nil, fmt.Errorf("sign bytes hold no messages")
+	}
+
+	msgTypes := make([]string, len(doc.Msgs))
+	for i, msg := range doc.Msgs {
+		if msg.Type == "" {
+			return nil, fmt.Errorf("message %d has no type", i)
+		}
+		msgTypes[i] = msg.Type
+	}
+	return msgTypes, nil
+}
@@ -0,0 +1,104 @@
+package remotesigner_test
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
+	"github.com/yukimochizuki/cosmos-sdk/crypto/keys/remotesigner"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cosmos-sdk-remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+
+	signerKb := keys.New(dbm.NewMemDB())
+	info, _, err := signerKb.CreateMnemonic("validator", keys.English, "secretcpw", keys.Secp256k1)
+	require.NoError(t, err)
+	_, _, err = signerKb.CreateMnemonic("other", keys.English, "secretcpw", keys.Secp256k1)
+	require.NoError(t, err)
+
+	policies := map[string]remotesigner.KeyPolicy{
+		"validator": {
+			PubKey:          info.GetPubKey(),
+			Passphrase:      "secretcpw",
+			AllowedMsgTypes: []string{"cosmos-sdk/MsgUnjail"},
+		},
+	}
+	ln, err := remotesigner.Listen(addr)
+	require.NoError(t, err)
+	defer ln.Close()
+	go remotesigner.NewServer(signerKb, policies, log.NewNopLogger()).Serve(ln)
+
+	kb := keys.New(dbm.NewMemDB())
+	remote, err := kb.CreateRemote("val", addr, "validator")
+	require.NoError(t, err)
+	require.Equal(t, keys.TypeRemote, remote.GetType())
+	require.Equal(t, info.GetPubKey(), remote.GetPubKey())
+
+	// keys that aren't served can't be referenced
+	_, err = kb.CreateRemote("other", addr, "other")
+	require.Error(t, err)
+
+	signBytes := []byte(`{"msgs":[{"type":"cosmos-sdk/MsgUnjail","value":{}}]}`)
+	sig, pub, err := kb.Sign("val", "", signBytes)
+	require.NoError(t, err)
+	require.Equal(t, info.GetPubKey(), pub)
+	require.True(t, pub.VerifyBytes(signBytes, sig))
+
+	// all messages must be allowed
+	cases := []string{
+		`{"msgs":[{"type":"cosmos-sdk/MsgUnjail","value":{}},{"type":"cosmos-sdk/Send","value":{}}]}`,
+		`{"msgs":[{"value":{}}]}`,
+		`{"msgs":[]}`,
+		`not a sign doc`,
+	}
+	for _, tc := range cases {
+		_, _, err := kb.Sign("val", "", []byte(tc))
+		require.Error(t, err, tc)
+	}
+
+	// remote references are deleted like offline ones
+	require.Error(t, kb.Delete("val", ""))
+	require.NoError(t, kb.Delete("val", "yes"))
+}
+
+func TestRemoteSignerOnlyServesUnixSockets(t *testing.T) {
+	_, err := remotesigner.Listen("tcp://127.0.0.1:0")
+	require.Error(t, err)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	server := remotesigner.NewServer(keys.New(dbm.NewMemDB()), nil, log.NewNopLogger())
+	require.Error(t, server.Serve(ln))
+}
+
+func TestListenInPrivateDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cosmos-sdk-remotesigner")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// a missing directory is created for the owner only
+	sockDir := filepath.Join(dir, "signer")
+	ln, err := remotesigner.Listen("unix://" + filepath.Join(sockDir, "signer.sock"))
+	require.NoError(t, err)
+	ln.Close()
+	fi, err := os.Stat(sockDir)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	// other users could connect to a socket in a shared directory
+	require.NoError(t, os.Chmod(sockDir, 0755))
+	_, err = remotesigner.Listen("unix://" + filepath.Join(sockDir, "signer.sock"))
+	require.Error(t, err)
+}
@@ -23,7 +23,7 @@ type Keybase interface {
 	// key from that.
 	CreateMnemonic(name string, language Language, passwd string, algo SigningAlgo) (info Info, seed string, err error)
 	// CreateKey takes a mnemonic and derives, a password. This method is temporary
-	CreateKey(name, mnemonic, passwd string) (info Info, err error)
+	CreateKey(name, mnemonic, passwd string, algo SigningAlgo) (info Info, err error)
 	// CreateFundraiserKey takes a mnemonic and derives, a password
 	CreateFundraiserKey(name, mnemonic, passwd string) (info Info, err error)
 	// Compute a BIP39 seed from th mnemonic and bip39Passwd.
@@ -31,13 +31,16 @@ type Keybase interface {
 	// Encrypt the key to disk using encryptPasswd.
 	// See https://github.com/yukimochizuki/cosmos-sdk/issues/2095
 	Derive(name, mnemonic, bip39Passwd,
-		encryptPasswd string, params hd.BIP44Params) (Info, error)
+		encryptPasswd string, params hd.BIP44Params, algo SigningAlgo) (Info, error)
 	// Create, store, and return a new Ledger key reference
 	CreateLedger(name string, path ccrypto.DerivationPath, algo SigningAlgo) (info Info, err error)
 
 	// Create, store, and return a new offline key reference
 	CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error)
 
+	// Create, store, and return a new reference to a key of a remote signer
+	CreateRemote(name, signerAddr, remoteName string) (info Info, err error)
+
 	// The following operations will *only* work on locally-stored keys
 	Update(name, oldpass string, getNewpass func() (string, error)) error
 	Import(name string, armor string) (err error)
@@ -60,12 +63,14 @@ const (
 	TypeLocal   KeyType = 0
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
+	TypeRemote  KeyType = 3
 )
 
 var keyTypes = map[KeyType]string{
 	TypeLocal:   "local",
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
+	TypeRemote:  "remote",
 }
 
 // String implements the stringer interface for KeyType.
@@ -88,19 +93,22 @@ type Info interface {
 var _ Info = &localInfo{}
 var _ Info = &ledgerInfo{}
 var _ Info = &offlineInfo{}
+var _ Info = &remoteInfo{}
 
 // localInfo is the public information about a locally stored key
 type localInfo struct {
 	Name         string        `json:"name"`
 	PubKey       crypto.PubKey `json:"pubkey"`
 	PrivKeyArmor string        `json:"privkey.armor"`
+	Algo         SigningAlgo   `json:"algo"`
 }
 
-func newLocalInfo(name string, pub crypto.PubKey, privArmor string) Info {
+func newLocalInfo(name string, pub crypto.PubKey, privArmor string, algo SigningAlgo) Info {
 	return &localInfo{
 		Name:         name,
 		PubKey:       pub,
 		PrivKeyArmor: privArmor,
+		Algo:         algo,
 	}
 }
 
@@ -120,6 +128,15 @@ func (i localInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// GetAlgo returns the signing algorithm of the key. Keys stored before the
+// algorithm was recorded are secp256k1 keys.
+func (i localInfo) GetAlgo() SigningAlgo {
+	if i.Algo == "" {
+		return Secp256k1
+	}
+	return i.Algo
+}
+
 // ledgerInfo is the public information about a Ledger key
 type ledgerInfo struct {
 	Name   string                 `json:"name"`
@@ -180,6 +197,39 @@ func (i offlineInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// remoteInfo is the public information about a key held by a remote signer
+type remoteInfo struct {
+	Name       string        `json:"name"`
+	PubKey     crypto.PubKey `json:"pubkey"`
+	SignerAddr string        `json:"signer_addr"`
+	RemoteName string        `json:"remote_name"`
+}
+
+func newRemoteInfo(name string, pub crypto.PubKey, signerAddr, remoteName string) Info {
+	return &remoteInfo{
+		Name:       name,
+		PubKey:     pub,
+		SignerAddr: signerAddr,
+		RemoteName: remoteName,
+	}
+}
+
+func (i remoteInfo) GetType() KeyType {
+	return TypeRemote
+}
+
+func (i remoteInfo) GetName() string {
+	return i.Name
+}
+
+func (i remoteInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+func (i remoteInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
 // encoding info
 func writeInfo(i Info) []byte {
 	return cdc.MustMarshalBinary(i)
@@ -7,13 +7,30 @@ import (
 )
 
 const (
-	defaultMinimumFees = ""
+	defaultMinimumFees          = ""
+	defaultPrometheusListenAddr = ":26670"
 )
 
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
 	// Tx minimum fee
 	MinFees string `mapstructure:"minimum_fees"`
+
+	// Maximum number of pending txs per fee payer in the local mempool
+	MaxPendingTxs int `mapstructure:"max_pending_txs_per_account"`
+
+	// Serve the app metrics to Prometheus
+	Prometheus bool `mapstructure:"prometheus"`
+
+	// Address on which the app metrics are served to Prometheus
+	PrometheusListenAddr string `mapstructure:"prometheus_listen_addr"`
+
+	// Height at which the node stops after committing the block, e.g. for a
+	// coordinated upgrade
+	HaltHeight uint64 `mapstructure:"halt-height"`
+
+	// Unix time in seconds past which the node stops after committing a block
+	HaltTime uint64 `mapstructure:"halt-time"`
 }
 
 // Config defines the server's top level configuration
@@ -34,4 +51,9 @@ func (c *Config) MinimumFees() sdk.Coins {
 }
 
 // DefaultConfig returns server's default configuration.
-func DefaultConfig() *Config { return &Config{BaseConfig{MinFees: defaultMinimumFees}} }
+func DefaultConfig() *Config {
+	return &Config{BaseConfig{
+		MinFees:              defaultMinimumFees,
+		PrometheusListenAddr: defaultPrometheusListenAddr,
+	}}
+}
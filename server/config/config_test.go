@@ -1,8 +1,13 @@
 package config
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
+
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 )
@@ -17,3 +22,33 @@ func TestSetMinimumFees(t *testing.T) {
 	cfg.SetMinimumFees(sdk.Coins{sdk.NewCoin("foo", sdk.NewInt(100))})
 	require.Equal(t, "100foo", cfg.MinFees)
 }
+
+func TestDefaultPrometheusConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	require.False(t, cfg.Prometheus)
+	require.Equal(t, ":26670", cfg.PrometheusListenAddr)
+}
+
+func TestHaltConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	require.Equal(t, uint64(0), cfg.HaltHeight)
+	require.Equal(t, uint64(0), cfg.HaltTime)
+
+	// the halt settings are written to and read from the config file
+	dir, err := ioutil.TempDir("", "config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	configFile := filepath.Join(dir, "app.toml")
+	cfg.HaltHeight = 100
+	cfg.HaltTime = 1538395200
+	WriteConfigFile(configFile, cfg)
+
+	viper.Reset()
+	defer viper.Reset()
+	viper.SetConfigFile(configFile)
+	require.NoError(t, viper.ReadInConfig())
+	parsed, err := ParseConfig()
+	require.NoError(t, err)
+	require.Equal(t, uint64(100), parsed.HaltHeight)
+	require.Equal(t, uint64(1538395200), parsed.HaltTime)
+}
@@ -15,6 +15,28 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 # Validators reject any tx from the mempool with less than the minimum fee per gas.
 minimum_fees = "{{ .BaseConfig.MinFees }}"
+
+# Validators reject any tx from the mempool once its fee payer has this many
+# pending txs. A pending tx can still be replaced by a tx with the same sequence
+# and a higher fee per gas. Zero disables the limit.
+max_pending_txs_per_account = {{ .BaseConfig.MaxPendingTxs }}
+
+# The node stops cleanly after committing the block at this height, e.g. to be
+# upgraded together with the other validators. Zero disables the halt height.
+halt-height = {{ .BaseConfig.HaltHeight }}
+
+# The node stops cleanly after committing the first block with a time past this
+# unix time in seconds. Zero disables the halt time.
+halt-time = {{ .BaseConfig.HaltTime }}
+
+##### metrics options #####
+
+# Serve the metrics of the app (txs and gas per message route, gas per block,
+# and the gauges of the modules) to Prometheus.
+prometheus = {{ .BaseConfig.Prometheus }}
+
+# Address on which the app metrics are served to Prometheus.
+prometheus_listen_addr = "{{ .BaseConfig.PrometheusListenAddr }}"
 `
 
 var configTemplate *template.Template
@@ -19,7 +19,9 @@ type (
 
 	// AppExporter is a function that dumps all app state to
 	// JSON-serializable structure and returns the current validator set.
-	AppExporter func(log.Logger, dbm.DB, io.Writer) (json.RawMessage, []tmtypes.GenesisValidator, error)
+	// If the bool argument is set, the state is prepared to start a new chain
+	// from height zero.
+	AppExporter func(log.Logger, dbm.DB, io.Writer, bool) (json.RawMessage, []tmtypes.GenesisValidator, error)
 )
 
 func openDB(rootDir string) (dbm.DB, error) {
@@ -13,11 +13,22 @@ import (
 	"path"
 )
 
+const (
+	flagForZeroHeight = "for-zero-height"
+)
+
 // ExportCmd dumps app state to JSON.
 func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export state to JSON",
+		Long: `Export the application state and the validator set to a genesis file
+printed to stdout.
+
+With --for-zero-height, the state is prepared to start a new chain at height
+zero: all pending rewards are withdrawn and all recorded heights are reset, so
+that the exported genesis file can be used to restart the chain from scratch
+with the same balances.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			home := viper.GetString("home")
 			traceWriterFile := viper.GetString(flagTraceStore)
@@ -45,7 +56,8 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 			if err != nil {
 				return err
 			}
-			appState, validators, err := appExporter(ctx.Logger, db, traceWriter)
+			forZeroHeight := viper.GetBool(flagForZeroHeight)
+			appState, validators, err := appExporter(ctx.Logger, db, traceWriter, forZeroHeight)
 			if err != nil {
 				return errors.Errorf("error exporting state: %v\n", err)
 			}
@@ -67,6 +79,8 @@ func ExportCmd(ctx *Context, cdc *codec.Codec, appExporter AppExporter) *cobra.C
 			return nil
 		},
 	}
+	cmd.Flags().Bool(flagForZeroHeight, false, "Export state to start a new chain at height zero (withdraws all rewards and resets heights)")
+	return cmd
 }
 
 func isEmptyState(home string) (bool, error) {
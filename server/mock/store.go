@@ -125,6 +125,10 @@ func (kv kvStore) Delete(key []byte) {
 	delete(kv.store, string(key))
 }
 
+func (kv kvStore) DeleteRange(start, end []byte) {
+	panic("not implemented")
+}
+
 func (kv kvStore) Prefix(prefix []byte) sdk.KVStore {
 	panic("not implemented")
 }
@@ -1,14 +1,22 @@
 package server
 
 import (
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/abci/server"
+	abci "github.com/tendermint/tendermint/abci/types"
 
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	cmn "github.com/tendermint/tendermint/libs/common"
+	"github.com/tendermint/tendermint/libs/log"
 	"github.com/tendermint/tendermint/node"
 	"github.com/tendermint/tendermint/p2p"
 	pvm "github.com/tendermint/tendermint/privval"
@@ -21,6 +29,13 @@ const (
 	flagTraceStore     = "trace-store"
 	flagPruning        = "pruning"
 	flagMinimumFees    = "minimum_fees"
+	flagMaxPendingTxs  = "max_pending_txs_per_account"
+
+	flagPrometheus           = "prometheus"
+	flagPrometheusListenAddr = "prometheus_listen_addr"
+
+	flagHaltHeight = "halt-height"
+	flagHaltTime   = "halt-time"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -48,6 +63,11 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
 	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
 	cmd.Flags().String(flagMinimumFees, "", "Minimum fees validator will accept for transactions")
+	cmd.Flags().Int(flagMaxPendingTxs, 0, "Maximum number of pending transactions per account in the local mempool (0 for no limit)")
+	cmd.Flags().Bool(flagPrometheus, false, "Serve the app metrics to Prometheus")
+	cmd.Flags().String(flagPrometheusListenAddr, ":26670", "Address on which the app metrics are served to Prometheus")
+	cmd.Flags().Uint64(flagHaltHeight, 0, "Height at which to stop the node after committing the block (0 to disable)")
+	cmd.Flags().Uint64(flagHaltTime, 0, "Unix time in seconds past which to stop the node after committing a block (0 to disable)")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
@@ -69,6 +89,7 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	}
 
 	app := appCreator(ctx.Logger, db, traceWriter)
+	metricsSrv := startPrometheusServer(ctx.Logger)
 
 	svr, err := server.NewServer(addr, "socket", app)
 	if err != nil {
@@ -82,15 +103,13 @@ func startStandAlone(ctx *Context, appCreator AppCreator) error {
 		cmn.Exit(err.Error())
 	}
 
-	// wait forever
-	cmn.TrapSignal(func() {
-		// cleanup
-		err = svr.Stop()
-		if err != nil {
-			cmn.Exit(err.Error())
-		}
-	})
-	return nil
+	waitForShutdown(ctx, app)
+
+	// cleanup
+	if metricsSrv != nil {
+		metricsSrv.Close()
+	}
+	return svr.Stop()
 }
 
 // nolint: unparam
@@ -109,6 +128,7 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	}
 
 	app := appCreator(ctx.Logger, db, traceWriter)
+	metricsSrv := startPrometheusServer(ctx.Logger)
 
 	nodeKey, err := p2p.LoadOrGenNodeKey(cfg.NodeKeyFile())
 	if err != nil {
@@ -135,7 +155,55 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 		return nil, err
 	}
 
-	// trap signal (run forever)
-	tmNode.RunForever()
-	return tmNode, nil
+	waitForShutdown(ctx, app)
+
+	// cleanup
+	if metricsSrv != nil {
+		metricsSrv.Close()
+	}
+	return tmNode, tmNode.Stop()
+}
+
+// halter is implemented by the apps which halt, e.g. at a configured height,
+// see baseapp.BaseApp.Halted
+type halter interface {
+	Halted() <-chan struct{}
+}
+
+// waitForShutdown blocks until the process is interrupted or terminated, or
+// until the app halts.
+func waitForShutdown(ctx *Context, app abci.Application) {
+	var halted <-chan struct{}
+	if h, ok := app.(halter); ok {
+		halted = h.Halted()
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigs)
+
+	select {
+	case sig := <-sigs:
+		ctx.Logger.Info("Captured signal, stopping", "signal", sig)
+	case <-halted:
+		ctx.Logger.Info("App halted, stopping")
+	}
+}
+
+// startPrometheusServer serves the metrics of the default Prometheus registry,
+// in which the app registers its metrics, if enabled with --prometheus.
+func startPrometheusServer(logger log.Logger) *http.Server {
+	if !viper.GetBool(flagPrometheus) {
+		return nil
+	}
+	srv := &http.Server{
+		Addr:    viper.GetString(flagPrometheusListenAddr),
+		Handler: promhttp.Handler(),
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Error("Prometheus HTTP server ListenAndServe", "err", err)
+		}
+	}()
+	return srv
 }
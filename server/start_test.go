@@ -0,0 +1,39 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type haltingApp struct {
+	abci.BaseApplication
+	halted chan struct{}
+}
+
+func (app haltingApp) Halted() <-chan struct{} {
+	return app.halted
+}
+
+func TestWaitForShutdownOnHalt(t *testing.T) {
+	app := haltingApp{halted: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		waitForShutdown(NewDefaultContext(), app)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("returned before the app halted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(app.halted)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("didn't return once the app halted")
+	}
+}
@@ -22,6 +22,10 @@ type cacheKVStore struct {
 	mtx    sync.Mutex
 	cache  map[string]cValue
 	parent KVStore
+
+	// the domains deleted with DeleteRange, hiding the keys of the parent
+	// until Write deletes them there
+	deleted deletedRanges
 }
 
 var _ CacheKVStore = (*cacheKVStore)(nil)
@@ -47,6 +51,9 @@ func (ci *cacheKVStore) Get(key []byte) (value []byte) {
 
 	cacheValue, ok := ci.cache[string(key)]
 	if !ok {
+		if ci.deleted.contains(key) {
+			return nil
+		}
 		value = ci.parent.Get(key)
 		ci.setCacheValue(key, value, false, false)
 	} else {
@@ -80,6 +87,24 @@ func (ci *cacheKVStore) Delete(key []byte) {
 	ci.setCacheValue(key, nil, true, true)
 }
 
+// Implements KVStore.
+// The domain is recorded as a range tombstone, without reading its keys: the
+// cached values in the domain are dropped, the keys of the parent in it are
+// hidden, and Write deletes the domain from the parent before writing the
+// values set afterwards.
+func (ci *cacheKVStore) DeleteRange(start, end []byte) {
+	ci.mtx.Lock()
+	defer ci.mtx.Unlock()
+
+	dr := deletedRange{start: cp(start), end: cp(end)}
+	for key := range ci.cache {
+		if dr.contains([]byte(key)) {
+			delete(ci.cache, key)
+		}
+	}
+	ci.deleted = append(ci.deleted, dr)
+}
+
 // Implements KVStore
 func (ci *cacheKVStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{ci, prefix}
@@ -106,6 +131,12 @@ func (ci *cacheKVStore) Write() {
 
 	sort.Strings(keys)
 
+	// The deleted domains go first, as the cached values were all set after
+	// them.
+	for _, dr := range ci.deleted {
+		ci.parent.DeleteRange(dr.start, dr.end)
+	}
+
 	// TODO: Consider allowing usage of Batch, which would allow the write to
 	// at least happen atomically.
 	for _, key := range keys {
@@ -121,6 +152,7 @@ func (ci *cacheKVStore) Write() {
 
 	// Clear the cache
 	ci.cache = make(map[string]cValue)
+	ci.deleted = nil
 }
 
 //----------------------------------------
@@ -157,6 +189,7 @@ func (ci *cacheKVStore) iterator(start, end []byte, ascending bool) Iterator {
 	} else {
 		parent = ci.parent.ReverseIterator(start, end)
 	}
+	parent = newDeletedRangesIterator(parent, ci.deleted)
 
 	items := ci.dirtyItems(ascending)
 	cache = newMemIterator(start, end, items)
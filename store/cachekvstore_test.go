@@ -89,6 +89,56 @@ func TestCacheKVStoreNested(t *testing.T) {
 	require.Equal(t, valFmt(3), mem.Get(keyFmt(1)))
 }
 
+func TestCacheKVStoreDeleteRange(t *testing.T) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	for i := 0; i < 5; i++ {
+		mem.Set(keyFmt(i), valFmt(i))
+	}
+	st := NewCacheKVStore(mem)
+
+	// dirty keys in the range are deleted along with the parent's
+	st.Set(keyFmt(5), valFmt(5))
+	st.Set(keyFmt(6), valFmt(6))
+	st.DeleteRange(keyFmt(1), keyFmt(6))
+	require.Nil(t, st.Get(keyFmt(1)))
+	require.Nil(t, st.Get(keyFmt(5)))
+	require.Equal(t, valFmt(0), st.Get(keyFmt(0)))
+
+	// keys set after the deletion are kept
+	st.Set(keyFmt(3), valFmt(3))
+
+	// the cache shows the deletion before Write, the parent doesn't
+	assertIterateKeys(t, st.Iterator(nil, nil), []int{0, 3, 6})
+	assertIterateKeys(t, st.ReverseIterator(nil, nil), []int{6, 3, 0})
+	assertIterateKeys(t, st.Iterator(keyFmt(1), keyFmt(5)), []int{3})
+	require.Equal(t, valFmt(1), mem.Get(keyFmt(1)), "parent shouldn't change before Write")
+
+	st.Write()
+	assertIterateDomainCheck(t, st, mem, []keyRange{{0, 1}, {3, 4}, {6, 7}})
+
+	// nil bounds cover the whole domain
+	st.DeleteRange(nil, nil)
+	assertIterateDomain(t, st, 0)
+	require.Equal(t, valFmt(0), mem.Get(keyFmt(0)), "parent shouldn't change before Write")
+	st.Write()
+	assertIterateDomain(t, st, 0)
+	require.Nil(t, mem.Get(keyFmt(0)))
+}
+
+// assertIterateKeys checks that itr iterates over the items of the given
+// indexes, in order
+func assertIterateKeys(t *testing.T, itr Iterator, expected []int) {
+	defer itr.Close()
+	i := 0
+	for ; itr.Valid(); itr.Next() {
+		require.True(t, i < len(expected), "unexpected key %s", itr.Key())
+		require.Equal(t, keyFmt(expected[i]), itr.Key())
+		require.Equal(t, valFmt(expected[i]), itr.Value())
+		i++
+	}
+	require.Equal(t, len(expected), i)
+}
+
 func TestCacheKVIteratorBounds(t *testing.T) {
 	st := newCacheKVStore()
 
@@ -27,6 +27,11 @@ func (dsa dbStoreAdapter) CacheWrapWithTrace(w io.Writer, tc TraceContext) Cache
 	return NewCacheKVStore(NewTraceKVStore(dsa, w, tc))
 }
 
+// Implements KVStore
+func (dsa dbStoreAdapter) DeleteRange(start, end []byte) {
+	deleteKeys(dsa, start, end)
+}
+
 // Implements KVStore
 func (dsa dbStoreAdapter) Prefix(prefix []byte) KVStore {
 	return prefixStore{dsa, prefix}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"bytes"
+)
+
+// rangeKeys returns the keys of kvs in the domain [start, end). The keys are
+// collected before any deletion, as no writes may happen while an iterator
+// exists over a domain.
+func rangeKeys(kvs KVStore, start, end []byte) (keys [][]byte) {
+	iter := kvs.Iterator(start, end)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	return keys
+}
+
+// deleteKeys deletes the keys of kvs in the domain [start, end) one by one,
+// for the stores which can't delete a domain at once.
+func deleteKeys(kvs KVStore, start, end []byte) {
+	for _, key := range rangeKeys(kvs, start, end) {
+		kvs.Delete(key)
+	}
+}
+
+//----------------------------------------
+
+// deletedRange is a range tombstone: the keys of the parent store in the
+// domain [start, end) are deleted. Nil bounds are open.
+type deletedRange struct {
+	start, end []byte
+}
+
+func (dr deletedRange) contains(key []byte) bool {
+	return (dr.start == nil || bytes.Compare(key, dr.start) >= 0) &&
+		(dr.end == nil || bytes.Compare(key, dr.end) < 0)
+}
+
+// deletedRanges are the range tombstones of a cache
+type deletedRanges []deletedRange
+
+func (drs deletedRanges) contains(key []byte) bool {
+	for _, dr := range drs {
+		if dr.contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// deletedRangesIterator skips the keys of its parent iterator which are in
+// range tombstones.
+type deletedRangesIterator struct {
+	Iterator
+	deleted deletedRanges
+}
+
+var _ Iterator = (*deletedRangesIterator)(nil)
+
+func newDeletedRangesIterator(parent Iterator, deleted deletedRanges) Iterator {
+	if len(deleted) == 0 {
+		return parent
+	}
+	iter := &deletedRangesIterator{Iterator: parent, deleted: deleted}
+	iter.skipDeleted()
+	return iter
+}
+
+func (iter *deletedRangesIterator) skipDeleted() {
+	for iter.Iterator.Valid() && iter.deleted.contains(iter.Iterator.Key()) {
+		iter.Iterator.Next()
+	}
+}
+
+// Next implements Iterator.
+func (iter *deletedRangesIterator) Next() {
+	iter.Iterator.Next()
+	iter.skipDeleted()
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestDiffKVStores(t *testing.T) {
+	storeA := dbStoreAdapter{dbm.NewMemDB()}
+	storeB := dbStoreAdapter{dbm.NewMemDB()}
+
+	for _, store := range []sdk.KVStore{storeA, storeB} {
+		store.Set([]byte("a"), []byte("1"))
+		store.Set([]byte("b"), []byte("2"))
+		store.Set([]byte("skip/x"), []byte("3"))
+	}
+	kvAs, kvBs, count := sdk.DiffKVStores(storeA, storeB, nil, 0)
+	require.Empty(t, kvAs)
+	require.Empty(t, kvBs)
+	require.Equal(t, int64(3), count)
+
+	// differing value, keys missing from either store and skipped prefix
+	storeA.Set([]byte("b"), []byte("20"))
+	storeA.Set([]byte("c"), []byte("4"))
+	storeB.Set([]byte("d"), []byte("5"))
+	storeB.Set([]byte("skip/y"), []byte("6"))
+	kvAs, kvBs, count = sdk.DiffKVStores(storeA, storeB, [][]byte{[]byte("skip/")}, 0)
+	require.Equal(t, []cmn.KVPair{
+		{Key: []byte("b"), Value: []byte("20")},
+		{Key: []byte("c"), Value: []byte("4")},
+		{Key: []byte("d")},
+	}, kvAs)
+	require.Equal(t, []cmn.KVPair{
+		{Key: []byte("b"), Value: []byte("2")},
+		{Key: []byte("c")},
+		{Key: []byte("d"), Value: []byte("5")},
+	}, kvBs)
+	require.Equal(t, int64(4), count)
+
+	// only the first differences are returned
+	kvAs, kvBs, _ = sdk.DiffKVStores(storeA, storeB, [][]byte{[]byte("skip/")}, 2)
+	require.Equal(t, 2, len(kvAs))
+	require.Equal(t, 2, len(kvBs))
+	require.Equal(t, []byte("c"), kvAs[1].Key)
+}
@@ -64,6 +64,18 @@ func (gs *gasKVStore) Delete(key []byte) {
 	gs.parent.Delete(key)
 }
 
+// DeleteRange implements the KVStore interface. It incurs the gas cost of
+// iterating over the domain plus the flat delete cost for each deleted key.
+func (gs *gasKVStore) DeleteRange(start, end []byte) {
+	iter := gs.iterator(start, end, true)
+	for ; iter.Valid(); iter.Next() {
+		gs.gasMeter.ConsumeGas(gs.gasConfig.DeleteCost, sdk.GasDeleteDesc)
+	}
+	iter.Close()
+
+	gs.parent.DeleteRange(start, end)
+}
+
 // Implements KVStore
 func (gs *gasKVStore) Prefix(prefix []byte) KVStore {
 	// Keep gasstore layer at the top
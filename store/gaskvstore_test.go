@@ -52,6 +52,28 @@ func TestGasKVStoreIterator(t *testing.T) {
 	require.Equal(t, meter.GasConsumed(), sdk.Gas(384))
 }
 
+func TestGasKVStoreDeleteRange(t *testing.T) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	for i := 0; i < 3; i++ {
+		mem.Set(keyFmt(i), valFmt(i))
+	}
+
+	// gas of iterating over the domain
+	iterMeter := sdk.NewGasMeter(1000)
+	iter := NewGasKVStore(iterMeter, sdk.KVGasConfig(), mem).Iterator(keyFmt(0), keyFmt(2))
+	for ; iter.Valid(); iter.Next() {
+	}
+	iter.Close()
+
+	meter := sdk.NewGasMeter(1000)
+	st := NewGasKVStore(meter, sdk.KVGasConfig(), mem)
+	st.DeleteRange(keyFmt(0), keyFmt(2))
+	require.Nil(t, mem.Get(keyFmt(0)))
+	require.Nil(t, mem.Get(keyFmt(1)))
+	require.Equal(t, valFmt(2), mem.Get(keyFmt(2)))
+	require.Equal(t, iterMeter.GasConsumed()+2*sdk.KVGasConfig().DeleteCost, meter.GasConsumed())
+}
+
 func TestGasKVStoreOutOfGasSet(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(0)
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sync"
@@ -156,6 +157,11 @@ func (st *iavlStore) Delete(key []byte) {
 	st.tree.Remove(key)
 }
 
+// Implements KVStore.
+func (st *iavlStore) DeleteRange(start, end []byte) {
+	deleteKeys(st, start, end)
+}
+
 // Implements KVStore
 func (st *iavlStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{st, prefix}
@@ -238,11 +244,26 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		subspace := req.Data
 		res.Key = subspace
 		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
-		for ; iterator.Valid(); iterator.Next() {
-			KVs = append(KVs, KVPair{Key: iterator.Key(), Value: iterator.Value()})
+		if req.Prove {
+			// the pairs are read at the proved height, along with the proof
+			// that no pair of the subspace was left out
+			var proof *iavl.RangeProof
+			var err error
+			KVs, proof, err = getSubspaceWithProof(tree, subspace, res.Height)
+			if err != nil {
+				res.Log = err.Error()
+				break
+			}
+			if proof != nil {
+				res.Proof = cdc.MustMarshalBinary(proof)
+			}
+		} else {
+			iterator := sdk.KVStorePrefixIterator(st, subspace)
+			for ; iterator.Valid(); iterator.Next() {
+				KVs = append(KVs, KVPair{Key: iterator.Key(), Value: iterator.Value()})
+			}
+			iterator.Close()
 		}
-		iterator.Close()
 		res.Value = cdc.MustMarshalBinary(KVs)
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
@@ -251,6 +272,31 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	return
 }
 
+// getSubspaceWithProof returns the pairs of the subspace at the given version,
+// with a range proof which also covers the first leaf past the subspace. iavl
+// ends a range proof at the leaf right before the end of the range, which for
+// the end of a prefix would leave out the keys extending that leaf's key, so
+// the range is queried past the end of the subspace and then truncated.
+func getSubspaceWithProof(tree *iavl.MutableTree, subspace []byte, version int64) ([]KVPair, *iavl.RangeProof, error) {
+	end := sdk.PrefixEndBytes(subspace)
+	var queryEnd []byte
+	if end != nil {
+		queryEnd = append(cp(end), 0x00)
+	}
+	keys, values, proof, err := tree.GetVersionedRangeWithProof(subspace, queryEnd, 0, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	var kvs []KVPair
+	for i := range keys {
+		if end != nil && bytes.Compare(keys[i], end) >= 0 {
+			break
+		}
+		kvs = append(kvs, KVPair{Key: keys[i], Value: values[i]})
+	}
+	return kvs, proof, nil
+}
+
 //----------------------------------------
 
 // Implements Iterator.
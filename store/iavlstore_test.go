@@ -458,6 +458,14 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
 
+	// the subspace is proved at the queried height
+	qres = iavlStore.Query(abci.RequestQuery{Path: "/subspace", Data: ksub, Height: cid.Version, Prove: true})
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSub2, qres.Value)
+	var proof iavl.RangeProof
+	cdc.MustUnmarshalBinary(qres.Proof, &proof)
+	require.NoError(t, VerifySubspaceRangeProof(ksub, KVs2, cid.Hash, &proof))
+
 	// default (height 0) will show latest -1
 	query0 := abci.RequestQuery{Path: "/store", Data: k1}
 	qres = iavlStore.Query(query0)
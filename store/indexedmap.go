@@ -0,0 +1,144 @@
+package store
+
+import (
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// Index is a secondary index of an IndexedMap. It maps the index key derived
+// from each value to the primary key of that value.
+type Index struct {
+	keyCodec KeyCodec
+	indexKey func(value interface{}) interface{}
+}
+
+// NewIndex returns an Index on the key computed by indexKey from a value and
+// encoded with keyCodec. indexKey receives the same pointer type as the
+// newValue function of the IndexedMap.
+func NewIndex(keyCodec KeyCodec, indexKey func(value interface{}) interface{}) Index {
+	return Index{keyCodec, indexKey}
+}
+
+// IndexedMap is a Map whose values can also be looked up by secondary
+// indexes, which are updated along with the values. The values are stored
+// under the 0x00 prefix and the i-th index under the 0x01+i prefix, with one
+// empty entry per value keyed by the index key followed by the primary key.
+// It panics when the value type cannot be (un/)marshalled by the codec
+type IndexedMap struct {
+	Map
+	parent   sdk.KVStore // holds the values and the index entries
+	newValue func() interface{}
+	indexes  []Index
+}
+
+// IndexedMapKey returns the key under which an IndexedMap stores the value of
+// key, relative to the store of the IndexedMap, e.g. to query it from a client.
+func IndexedMapKey(keyCodec KeyCodec, key interface{}) []byte {
+	return append([]byte{0x00}, keyCodec.EncodeKey(key)...)
+}
+
+// NewIndexedMap constructs new IndexedMap. newValue returns a pointer to
+// unmarshal a stored value into, which is needed to update the indexes.
+func NewIndexedMap(cdc *codec.Codec, store sdk.KVStore, keyCodec KeyCodec,
+	newValue func() interface{}, indexes ...Index) IndexedMap {
+
+	if len(indexes) > 0xFF-1 {
+		panic("too many indexes")
+	}
+	return IndexedMap{
+		Map:      NewMap(cdc, store.Prefix([]byte{0x00}), keyCodec),
+		parent:   store,
+		newValue: newValue,
+		indexes:  indexes,
+	}
+}
+
+// Set stores the value under the key and updates the indexes
+func (m IndexedMap) Set(key interface{}, value interface{}) {
+	m.removeIndexes(key)
+	bz := m.cdc.MustMarshalBinary(value)
+	m.Map.store.Set(m.keyCodec.EncodeKey(key), bz)
+
+	// index the value as it would be read back
+	ptr := m.newValue()
+	m.cdc.MustUnmarshalBinary(bz, ptr)
+	for i := range m.indexes {
+		m.indexEntries(i).Set(m.indexEntryKey(i, key, ptr), []byte{})
+	}
+}
+
+// Delete deletes the value stored under the key and its index entries
+func (m IndexedMap) Delete(key interface{}) {
+	m.removeIndexes(key)
+	m.Map.Delete(key)
+}
+
+// DeleteRange deletes all values with keys in the range and their index
+// entries
+func (m IndexedMap) DeleteRange(rng *Range) {
+	var keys []interface{}
+	iter := m.Iterate(rng)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.DecodeKey())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		m.removeIndexes(key)
+	}
+	m.Map.DeleteRange(rng)
+}
+
+// IterateIndex returns an iterator over the values whose key in the i-th
+// index is in the range, ordered by index key and then by primary key
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (m IndexedMap) IterateIndex(i int, rng *Range) IndexIterator {
+	return IndexIterator{rng.iterator(m.indexEntries(i)), m, i}
+}
+
+func (m IndexedMap) removeIndexes(key interface{}) {
+	if len(m.indexes) == 0 {
+		return
+	}
+	ptr := m.newValue()
+	if !m.Map.Get(key, ptr) {
+		return
+	}
+	for i := range m.indexes {
+		m.indexEntries(i).Delete(m.indexEntryKey(i, key, ptr))
+	}
+}
+
+func (m IndexedMap) indexEntries(i int) sdk.KVStore {
+	return m.parent.Prefix([]byte{byte(0x01 + i)})
+}
+
+func (m IndexedMap) indexEntryKey(i int, key interface{}, ptr interface{}) []byte {
+	index := m.indexes[i]
+	return append(index.keyCodec.EncodeKey(index.indexKey(ptr)), m.keyCodec.EncodeKey(key)...)
+}
+
+// IndexIterator iterates over the entries of an index of an IndexedMap.
+type IndexIterator struct {
+	sdk.Iterator
+	m     IndexedMap
+	index int
+}
+
+// DecodeIndexKey returns the current index key
+func (iter IndexIterator) DecodeIndexKey() interface{} {
+	key, _ := iter.m.indexes[iter.index].keyCodec.DecodeKey(iter.Key())
+	return key
+}
+
+// DecodePrimaryKey returns the primary key of the current value
+func (iter IndexIterator) DecodePrimaryKey() interface{} {
+	_, n := iter.m.indexes[iter.index].keyCodec.DecodeKey(iter.Key())
+	key, _ := iter.m.keyCodec.DecodeKey(iter.Key()[n:])
+	return key
+}
+
+// DecodeValue unmarshals the current value into ptr
+func (iter IndexIterator) DecodeValue(ptr interface{}) {
+	iter.m.Map.Get(iter.DecodePrimaryKey(), ptr)
+}
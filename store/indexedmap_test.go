@@ -0,0 +1,70 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// newIndexedMap returns an IndexedMap of S by uint64 key, indexed by S.B
+func newIndexedMap() IndexedMap {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	return NewIndexedMap(cdc, ctx.KVStore(key), Uint64Key,
+		func() interface{} { return &S{} },
+		NewIndex(StringKey, func(value interface{}) interface{} {
+			if value.(*S).B {
+				return "true"
+			}
+			return "false"
+		}),
+	)
+}
+
+func indexedKeys(m IndexedMap, indexKey string) (keys []uint64) {
+	iter := m.IterateIndex(0, NewPrefixRange(StringKey.EncodeKey(indexKey)))
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.DecodePrimaryKey().(uint64))
+	}
+	iter.Close()
+	return keys
+}
+
+func TestIndexedMap(t *testing.T) {
+	m := newIndexedMap()
+
+	for i := uint64(0); i < 4; i++ {
+		m.Set(i, S{i, i%2 == 0})
+	}
+	require.Equal(t, []uint64{0, 2}, indexedKeys(m, "true"))
+	require.Equal(t, []uint64{1, 3}, indexedKeys(m, "false"))
+
+	// updating a value moves its index entry
+	m.Set(uint64(1), S{1, true})
+	require.Equal(t, []uint64{0, 1, 2}, indexedKeys(m, "true"))
+	require.Equal(t, []uint64{3}, indexedKeys(m, "false"))
+
+	m.Delete(uint64(0))
+	require.Equal(t, []uint64{1, 2}, indexedKeys(m, "true"))
+
+	// values are read through the index
+	var res S
+	iter := m.IterateIndex(0, NewPrefixRange(StringKey.EncodeKey("false")))
+	require.Equal(t, "false", iter.DecodeIndexKey())
+	iter.DecodeValue(&res)
+	require.Equal(t, S{3, false}, res)
+	iter.Close()
+
+	m.DeleteRange(NewRange(Uint64Key, uint64(2), nil))
+	require.Equal(t, []uint64{1}, indexedKeys(m, "true"))
+	require.Empty(t, indexedKeys(m, "false"))
+
+	// the values iterated over don't include the index entries
+	iter2 := m.Iterate(nil)
+	require.Equal(t, uint64(1), iter2.DecodeKey())
+	iter2.Next()
+	require.False(t, iter2.Valid())
+	iter2.Close()
+}
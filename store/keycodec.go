@@ -0,0 +1,145 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// KeyCodec encodes the keys of a Map into store keys. Encodings preserve the
+// ordering of the keys, so that a Range of keys can be iterated over, and are
+// self-delimiting, so that they can be concatenated into a Pair.
+type KeyCodec interface {
+	// EncodeKey encodes the key. It panics if the key has the wrong type.
+	EncodeKey(key interface{}) []byte
+
+	// DecodeKey decodes a key from the beginning of bz and returns it along
+	// with the number of bytes read.
+	DecodeKey(bz []byte) (key interface{}, n int)
+}
+
+// nolint
+var (
+	Int64Key  KeyCodec = int64Key{}
+	Uint64Key KeyCodec = uint64Key{}
+	BytesKey  KeyCodec = bytesKey{}
+	StringKey KeyCodec = stringKey{}
+	TimeKey   KeyCodec = timeKey{}
+)
+
+// Int64Key encodes int64 keys as 8 big endian bytes with the sign bit
+// flipped, so that negative keys sort before positive ones.
+type int64Key struct{}
+
+func (int64Key) EncodeKey(key interface{}) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(key.(int64))^(1<<63))
+	return bz
+}
+
+func (int64Key) DecodeKey(bz []byte) (interface{}, int) {
+	return int64(binary.BigEndian.Uint64(bz[:8]) ^ (1 << 63)), 8
+}
+
+// Uint64Key encodes uint64 keys as 8 big endian bytes.
+type uint64Key struct{}
+
+func (uint64Key) EncodeKey(key interface{}) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, key.(uint64))
+	return bz
+}
+
+func (uint64Key) DecodeKey(bz []byte) (interface{}, int) {
+	return binary.BigEndian.Uint64(bz[:8]), 8
+}
+
+// BytesKey encodes byte slice keys, such as addresses, prefixed with their
+// length. Keys are thus ordered by length first, and may be at most 255 bytes
+// long. Any type with a Bytes() []byte method is accepted as a key, keys are
+// decoded as []byte.
+type bytesKey struct{}
+
+func (bytesKey) EncodeKey(key interface{}) []byte {
+	var bz []byte
+	switch key := key.(type) {
+	case []byte:
+		bz = key
+	case interface{ Bytes() []byte }:
+		bz = key.Bytes()
+	default:
+		panic(fmt.Sprintf("invalid bytes key type %T", key))
+	}
+	if len(bz) > 255 {
+		panic(fmt.Sprintf("bytes key too long: %d bytes", len(bz)))
+	}
+	return append([]byte{byte(len(bz))}, bz...)
+}
+
+func (bytesKey) DecodeKey(bz []byte) (interface{}, int) {
+	l := int(bz[0])
+	key := make([]byte, l)
+	copy(key, bz[1:1+l])
+	return key, 1 + l
+}
+
+// StringKey encodes string keys the same way as BytesKey.
+type stringKey struct{}
+
+func (stringKey) EncodeKey(key interface{}) []byte {
+	return BytesKey.EncodeKey([]byte(key.(string)))
+}
+
+func (stringKey) DecodeKey(bz []byte) (interface{}, int) {
+	key, n := BytesKey.DecodeKey(bz)
+	return string(key.([]byte)), n
+}
+
+// TimeKey encodes time.Time keys with sdk.FormatTimeBytes.
+type timeKey struct{}
+
+func (timeKey) EncodeKey(key interface{}) []byte {
+	return sdk.FormatTimeBytes(key.(time.Time))
+}
+
+func (timeKey) DecodeKey(bz []byte) (interface{}, int) {
+	n := len(sdk.SortableTimeFormat)
+	t, err := sdk.ParseTimeBytes(bz[:n])
+	if err != nil {
+		panic(err)
+	}
+	return t, n
+}
+
+//__________________________________________________________
+
+// Pair is a composite key of a Map using a PairKey codec.
+type Pair struct {
+	K1 interface{}
+	K2 interface{}
+}
+
+// PairKey returns a KeyCodec for Pair keys. Pairs are ordered by their first
+// key, then by their second key, so all keys of a Map sharing the first key
+// can be iterated over with NewPrefixRange(k1.EncodeKey(key1)).
+func PairKey(k1, k2 KeyCodec) KeyCodec {
+	return pairKey{k1, k2}
+}
+
+type pairKey struct {
+	k1 KeyCodec
+	k2 KeyCodec
+}
+
+func (pk pairKey) EncodeKey(key interface{}) []byte {
+	pair := key.(Pair)
+	return append(pk.k1.EncodeKey(pair.K1), pk.k2.EncodeKey(pair.K2)...)
+}
+
+func (pk pairKey) DecodeKey(bz []byte) (interface{}, int) {
+	k1, n1 := pk.k1.DecodeKey(bz)
+	k2, n2 := pk.k2.DecodeKey(bz[n1:])
+	return Pair{k1, k2}, n1 + n2
+}
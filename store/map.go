@@ -0,0 +1,130 @@
+package store
+
+import (
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// Range is a domain of keys of a Map. A nil Range covers the whole Map.
+type Range struct {
+	start   []byte // encoded, inclusive; nil for unbounded
+	end     []byte // encoded, exclusive; nil for unbounded
+	reverse bool
+}
+
+// NewRange returns the Range of keys in [start, end) encoded with kc. A nil
+// bound is unbounded.
+func NewRange(kc KeyCodec, start, end interface{}) *Range {
+	rng := &Range{}
+	if start != nil {
+		rng.start = kc.EncodeKey(start)
+	}
+	if end != nil {
+		rng.end = kc.EncodeKey(end)
+	}
+	return rng
+}
+
+// NewPrefixRange returns the Range of keys whose encoding starts with prefix.
+func NewPrefixRange(prefix []byte) *Range {
+	return &Range{
+		start: prefix,
+		end:   sdk.PrefixEndBytes(prefix),
+	}
+}
+
+// Reverse returns the same Range iterated over in descending order.
+func (rng *Range) Reverse() *Range {
+	return &Range{rng.start, rng.end, !rng.reverse}
+}
+
+func (rng *Range) iterator(store sdk.KVStore) sdk.Iterator {
+	if rng == nil {
+		return store.Iterator(nil, nil)
+	}
+	if rng.reverse {
+		return store.ReverseIterator(rng.start, rng.end)
+	}
+	return store.Iterator(rng.start, rng.end)
+}
+
+//__________________________________________________________
+
+// Map is a key-value mapping whose keys are encoded with a KeyCodec and whose
+// values are encoded with the codec. It owns every key of its store, which is
+// usually prefixed.
+// It panics when the value type cannot be (un/)marshalled by the codec
+type Map struct {
+	cdc      *codec.Codec
+	store    sdk.KVStore
+	keyCodec KeyCodec
+}
+
+// NewMap constructs new Map
+func NewMap(cdc *codec.Codec, store sdk.KVStore, keyCodec KeyCodec) Map {
+	return Map{
+		cdc:      cdc,
+		store:    store,
+		keyCodec: keyCodec,
+	}
+}
+
+// Has returns true if a value is stored under the key
+func (m Map) Has(key interface{}) bool {
+	return m.store.Has(m.keyCodec.EncodeKey(key))
+}
+
+// Get unmarshals the value stored under the key into ptr and returns true if
+// it exists
+func (m Map) Get(key interface{}, ptr interface{}) bool {
+	bz := m.store.Get(m.keyCodec.EncodeKey(key))
+	if bz == nil {
+		return false
+	}
+	m.cdc.MustUnmarshalBinary(bz, ptr)
+	return true
+}
+
+// Set stores the value under the key
+func (m Map) Set(key interface{}, value interface{}) {
+	m.store.Set(m.keyCodec.EncodeKey(key), m.cdc.MustMarshalBinary(value))
+}
+
+// Delete deletes the value stored under the key
+func (m Map) Delete(key interface{}) {
+	m.store.Delete(m.keyCodec.EncodeKey(key))
+}
+
+// Iterate returns an iterator over the values with keys in the range
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (m Map) Iterate(rng *Range) MapIterator {
+	return MapIterator{rng.iterator(m.store), m.cdc, m.keyCodec}
+}
+
+// DeleteRange deletes all values with keys in the range
+func (m Map) DeleteRange(rng *Range) {
+	if rng == nil {
+		m.store.DeleteRange(nil, nil)
+		return
+	}
+	m.store.DeleteRange(rng.start, rng.end)
+}
+
+// MapIterator iterates over the entries of a Map. Key and Value return the
+// encoded key and value.
+type MapIterator struct {
+	sdk.Iterator
+	cdc      *codec.Codec
+	keyCodec KeyCodec
+}
+
+// DecodeKey returns the current key
+func (iter MapIterator) DecodeKey() interface{} {
+	key, _ := iter.keyCodec.DecodeKey(iter.Key())
+	return key
+}
+
+// DecodeValue unmarshals the current value into ptr
+func (iter MapIterator) DecodeValue(ptr interface{}) {
+	iter.cdc.MustUnmarshalBinary(iter.Value(), ptr)
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestKeyCodecs(t *testing.T) {
+	cases := []struct {
+		kc         KeyCodec
+		lower, key interface{}
+	}{
+		{Int64Key, int64(-5), int64(3)},
+		{Uint64Key, uint64(3), uint64(256)},
+		{BytesKey, []byte{0xFF}, []byte{0x01, 0x00}},
+		{StringKey, "b", "ab"},
+		{TimeKey, time.Unix(10, 0).UTC(), time.Unix(20, 0).UTC()},
+		{PairKey(Int64Key, BytesKey), Pair{int64(1), []byte{0x02}}, Pair{int64(2), []byte{0x01}}},
+	}
+
+	for i, tc := range cases {
+		bz := tc.kc.EncodeKey(tc.key)
+		key, n := tc.kc.DecodeKey(append(bz, 0xAB))
+		require.Equal(t, tc.key, key, "case %d", i)
+		require.Equal(t, len(bz), n, "case %d", i)
+		require.True(t, bytes.Compare(tc.kc.EncodeKey(tc.lower), bz) < 0, "case %d", i)
+	}
+
+	// addresses are bytes keys
+	addr := sdk.AccAddress([]byte("addr"))
+	require.Equal(t, BytesKey.EncodeKey([]byte("addr")), BytesKey.EncodeKey(addr))
+	require.Panics(t, func() { BytesKey.EncodeKey("addr") })
+	require.Panics(t, func() { BytesKey.EncodeKey(make([]byte, 256)) })
+}
+
+func TestMap(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	m := NewMap(cdc, ctx.KVStore(key).Prefix([]byte("map")), Uint64Key)
+
+	var res S
+	require.False(t, m.Has(uint64(1)))
+	require.False(t, m.Get(uint64(1), &res))
+
+	for i := uint64(0); i < 5; i++ {
+		m.Set(i, S{i, true})
+	}
+	require.True(t, m.Has(uint64(1)))
+	require.True(t, m.Get(uint64(1), &res))
+	require.Equal(t, S{1, true}, res)
+
+	m.Delete(uint64(1))
+	require.False(t, m.Has(uint64(1)))
+
+	iter := m.Iterate(nil)
+	expected := []uint64{0, 2, 3, 4}
+	for i := 0; iter.Valid(); iter.Next() {
+		require.Equal(t, expected[i], iter.DecodeKey())
+		iter.DecodeValue(&res)
+		require.Equal(t, S{expected[i], true}, res)
+		i++
+	}
+	iter.Close()
+
+	iter = m.Iterate(NewRange(Uint64Key, uint64(2), uint64(4)).Reverse())
+	require.Equal(t, uint64(3), iter.DecodeKey())
+	iter.Next()
+	require.Equal(t, uint64(2), iter.DecodeKey())
+	iter.Next()
+	require.False(t, iter.Valid())
+	iter.Close()
+
+	m.DeleteRange(NewRange(Uint64Key, uint64(3), nil))
+	require.True(t, m.Has(uint64(2)))
+	require.False(t, m.Has(uint64(3)))
+	require.False(t, m.Has(uint64(4)))
+}
+
+func TestMapPrefixRange(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	m := NewMap(cdc, ctx.KVStore(key), PairKey(Int64Key, StringKey))
+
+	m.Set(Pair{int64(1), "a"}, S{1, true})
+	m.Set(Pair{int64(2), "a"}, S{2, true})
+	m.Set(Pair{int64(2), "b"}, S{3, true})
+	m.Set(Pair{int64(3), "a"}, S{4, true})
+
+	var res S
+	iter := m.Iterate(NewPrefixRange(Int64Key.EncodeKey(int64(2))))
+	for i := uint64(2); i <= 3; i++ {
+		require.True(t, iter.Valid())
+		iter.DecodeValue(&res)
+		require.Equal(t, i, res.I)
+		iter.Next()
+	}
+	require.False(t, iter.Valid())
+	iter.Close()
+}
+
+func TestSequence(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	seq := NewSequence(cdc, ctx.KVStore(key), []byte("seq"))
+
+	require.False(t, seq.Has())
+	require.Equal(t, uint64(0), seq.Peek())
+	require.Equal(t, uint64(0), seq.Next())
+	require.True(t, seq.Has())
+	require.Equal(t, uint64(1), seq.Next())
+
+	seq.Set(10)
+	require.Equal(t, uint64(10), seq.Peek())
+	require.Equal(t, uint64(10), seq.Next())
+	require.Equal(t, uint64(11), seq.Peek())
+}
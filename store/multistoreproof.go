@@ -6,6 +6,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tendermint/iavl"
 	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 )
 
 // MultiStoreProof defines a collection of store proofs in a multi-store
@@ -15,10 +17,13 @@ type MultiStoreProof struct {
 	RangeProof iavl.RangeProof
 }
 
-// buildMultiStoreProof build MultiStoreProof based on iavl proof and storeInfos
+// buildMultiStoreProof build MultiStoreProof based on iavl proof and storeInfos.
+// The iavl proof of an empty substore is empty, as is then the range proof.
 func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []storeInfo) []byte {
 	var rangeProof iavl.RangeProof
-	cdc.MustUnmarshalBinary(iavlProof, &rangeProof)
+	if len(iavlProof) != 0 {
+		cdc.MustUnmarshalBinary(iavlProof, &rangeProof)
+	}
 
 	msp := MultiStoreProof{
 		StoreInfos: storeInfos,
@@ -30,17 +35,20 @@ func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []store
 	return proof
 }
 
-// VerifyMultiStoreCommitInfo verify multiStoreCommitInfo against appHash
+// VerifyMultiStoreCommitInfo verify multiStoreCommitInfo against appHash. The
+// returned commit hash of the substore is empty if the substore is empty.
 func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHash []byte) ([]byte, error) {
 	var substoreCommitHash []byte
 	var height int64
+	var found bool
 	for _, storeInfo := range storeInfos {
 		if storeInfo.Name == storeName {
 			substoreCommitHash = storeInfo.Core.CommitID.Hash
 			height = storeInfo.Core.CommitID.Version
+			found = true
 		}
 	}
-	if len(substoreCommitHash) == 0 {
+	if !found {
 		return nil, cmn.NewError("failed to get substore root commit hash by store name")
 	}
 
@@ -54,6 +62,53 @@ func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHas
 	return substoreCommitHash, nil
 }
 
+// VerifyMultiStoreProof verifies the proof returned by the root multistore for
+// a query of subpath ("/key" or "/subspace") in the named store: it must prove,
+// against the appHash, the value of the queried key, or the pairs of the
+// queried subspace. The key and the store must be the ones the client asked
+// for, not the ones of the response, which a node could choose.
+func VerifyMultiStoreProof(storeName, subpath string, key, value []byte, appHash []byte, proof MultiStoreProof) error {
+	if proof.StoreName != storeName {
+		return errors.Errorf("proof is for store %s instead of %s", proof.StoreName, storeName)
+	}
+
+	// verify the substore commit hash against trusted appHash
+	substoreCommitHash, err := VerifyMultiStoreCommitInfo(storeName, proof.StoreInfos, appHash)
+	if err != nil {
+		return errors.Wrap(err, "failed in verifying the proof against appHash")
+	}
+
+	if subpath == "/subspace" {
+		// the pairs of an empty subspace are encoded as empty bytes
+		var kvs []KVPair
+		if len(value) != 0 {
+			if err := cdc.UnmarshalBinary(value, &kvs); err != nil {
+				return errors.Wrap(err, "failed to unmarshalBinary subspace pairs")
+			}
+		}
+		// an empty substore has no commit hash nor range proof
+		if len(substoreCommitHash) == 0 {
+			if len(kvs) != 0 {
+				return errors.New("got pairs of an empty substore")
+			}
+			return nil
+		}
+		err = VerifySubspaceRangeProof(key, kvs, substoreCommitHash, &proof.RangeProof)
+	} else {
+		if len(substoreCommitHash) == 0 {
+			if len(value) != 0 {
+				return errors.New("got a value of an empty substore")
+			}
+			return nil
+		}
+		err = VerifyRangeProof(key, value, substoreCommitHash, &proof.RangeProof)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed in the range proof verification")
+	}
+	return nil
+}
+
 // VerifyRangeProof verify iavl RangeProof
 func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
 
@@ -80,11 +135,70 @@ func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *
 	return nil
 }
 
+// VerifySubspaceRangeProof verifies that the iavl RangeProof proves kvs to be
+// all the pairs of the subspace, i.e. that none was altered or left out
+func VerifySubspaceRangeProof(subspace []byte, kvs []KVPair, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return errors.Wrap(err, "proof root hash doesn't equal to substore commit root hash")
+	}
+
+	// the leaves of a range proof are contiguous, so all the pairs of the
+	// subspace are in the proof if it covers both ends of the subspace
+	start, end := subspace, sdk.PrefixEndBytes(subspace)
+	proofKeys := rangeProof.Keys()
+	if len(proofKeys) == 0 {
+		return errors.New("empty range proof")
+	}
+	first, last := proofKeys[0], proofKeys[0]
+	var inSubspace [][]byte
+	for _, key := range proofKeys {
+		if bytes.Compare(key, first) < 0 {
+			first = key
+		}
+		if bytes.Compare(key, last) > 0 {
+			last = key
+		}
+		if bytes.Compare(key, start) >= 0 && (end == nil || bytes.Compare(key, end) < 0) {
+			inSubspace = append(inSubspace, key)
+		}
+	}
+	// nothing precedes the first key if it is after the start of the subspace
+	if bytes.Compare(first, start) > 0 {
+		if err := rangeProof.VerifyAbsence(start); err != nil {
+			return errors.Wrap(err, "range proof doesn't cover the start of the subspace")
+		}
+	}
+	// without a key past the end of the subspace, the proof must reach the
+	// end of the tree, which the absence of the subspace end proves
+	if end == nil || bytes.Compare(last, end) < 0 {
+		rightBound := end
+		if rightBound == nil {
+			rightBound = append(cp(last), 0x00)
+		}
+		if err := rangeProof.VerifyAbsence(rightBound); err != nil {
+			return errors.Wrap(err, "range proof doesn't cover the end of the subspace")
+		}
+	}
+
+	if len(inSubspace) != len(kvs) {
+		return errors.Errorf("expected %d pairs in the subspace, got %d", len(inSubspace), len(kvs))
+	}
+	for _, kv := range kvs {
+		err = rangeProof.VerifyItem(kv.Key, kv.Value)
+		if err != nil {
+			return errors.Wrap(err, "failed in existence verification")
+		}
+	}
+	return nil
+}
+
 // RequireProof return whether proof is require for the subpath
 func RequireProof(subpath string) bool {
-	// Currently, only when query subpath is "/store" or "/key", will proof be included in response.
+	// Currently, only when query subpath is "/store", "/key" or "/subspace", will proof be included in response.
 	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go:212
-	if subpath == "/store" || subpath == "/key" {
+	switch subpath {
+	case "/store", "/key", "/subspace":
 		return true
 	}
 	return false
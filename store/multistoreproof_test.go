@@ -7,8 +7,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/iavl"
+	abci "github.com/tendermint/tendermint/abci/types"
 	cmn "github.com/tendermint/tendermint/libs/common"
 	"github.com/tendermint/tendermint/libs/db"
+
+	"github.com/yukimochizuki/cosmos-sdk/types"
 )
 
 func TestVerifyMultiStoreCommitInfo(t *testing.T) {
@@ -121,3 +124,95 @@ func TestVerifyRangeProof(t *testing.T) {
 	err = VerifyRangeProof(key, val, root, proof)
 	assert.Nil(t, err)
 }
+
+func TestVerifySubspaceRangeProof(t *testing.T) {
+	tree := iavl.NewMutableTree(db.NewMemDB(), 0)
+	for _, key := range [][]byte{{0x11}, {0x32, 0x01}, {0x32, 0x02}, {0x50}, {0x72}} {
+		tree.Set(key, append([]byte("value"), key...))
+	}
+	root, version, err := tree.SaveVersion()
+	require.NoError(t, err)
+
+	rangeWithProof := func(start, end []byte) ([]KVPair, *iavl.RangeProof) {
+		keys, values, proof, err := tree.GetVersionedRangeWithProof(start, end, 0, version)
+		require.NoError(t, err)
+		kvs := make([]KVPair, len(keys))
+		for i := range keys {
+			kvs[i] = KVPair{Key: keys[i], Value: values[i]}
+		}
+		return kvs, proof
+	}
+
+	// the subspaces: extending a key, starting at a key, at the end of the
+	// tree, past it, before it and the whole tree
+	for _, subspace := range [][]byte{{0x32}, {0x11}, {0x50}, {0x72}, {0x99}, {0x01}, nil} {
+		kvs, proof, err := getSubspaceWithProof(tree, subspace, version)
+		require.NoError(t, err)
+		require.NoError(t, VerifySubspaceRangeProof(subspace, kvs, root, proof), "%X", subspace)
+	}
+
+	subspace := []byte{0x32}
+	kvs, proof, err := getSubspaceWithProof(tree, subspace, version)
+	require.NoError(t, err)
+	require.Len(t, kvs, 2)
+
+	// pairs can't be left out or altered
+	require.Error(t, VerifySubspaceRangeProof(subspace, kvs[:1], root, proof))
+	altered := []KVPair{kvs[0], {Key: kvs[1].Key, Value: []byte("altered")}}
+	require.Error(t, VerifySubspaceRangeProof(subspace, altered, root, proof))
+	require.Error(t, VerifySubspaceRangeProof(subspace, kvs, []byte("root"), proof))
+
+	// nor hidden by proving a part of the subspace only
+	kvs, proof = rangeWithProof([]byte{0x32, 0x02}, types.PrefixEndBytes(subspace))
+	require.Error(t, VerifySubspaceRangeProof(subspace, kvs, root, proof))
+
+	// nor by a proof ending at the key of the subspace, which leaves out the
+	// keys extending it
+	subspace = []byte{0x11}
+	tree.Set([]byte{0x11, 0x05}, []byte("value"))
+	root, version, err = tree.SaveVersion()
+	require.NoError(t, err)
+	kvs, proof = rangeWithProof(subspace, types.PrefixEndBytes(subspace))
+	require.Len(t, kvs, 1)
+	require.Error(t, VerifySubspaceRangeProof(subspace, kvs, root, proof))
+}
+
+func TestVerifyMultiStoreProof(t *testing.T) {
+	multi := newMultiStoreWithMounts(db.NewMemDB())
+	require.Nil(t, multi.LoadLatestVersion())
+	store1 := multi.getStoreByName("store1").(KVStore)
+	store1.Set([]byte("wind"), []byte("blows"))
+	store1.Set([]byte("water"), []byte("flows"))
+	cid := multi.Commit()
+
+	query := func(path string, data []byte) ([]byte, MultiStoreProof) {
+		res := multi.Query(abci.RequestQuery{Path: path, Data: data, Height: cid.Version, Prove: true})
+		require.True(t, res.IsOK(), res.Log)
+		var proof MultiStoreProof
+		require.NoError(t, cdc.UnmarshalBinary(res.Proof, &proof))
+		return res.Value, proof
+	}
+
+	// values and absences are proved for the queried key only
+	value, proof := query("/store1/key", []byte("wind"))
+	require.NoError(t, VerifyMultiStoreProof("store1", "/key", []byte("wind"), value, cid.Hash, proof))
+	require.Error(t, VerifyMultiStoreProof("store1", "/key", []byte("water"), value, cid.Hash, proof))
+	require.Error(t, VerifyMultiStoreProof("store2", "/key", []byte("wind"), value, cid.Hash, proof))
+	value, proof = query("/store1/key", []byte("fire"))
+	require.NoError(t, VerifyMultiStoreProof("store1", "/key", []byte("fire"), value, cid.Hash, proof))
+
+	// the pairs of a subspace are proved for the queried subspace only
+	wValue, wProof := query("/store1/subspace", []byte("w"))
+	require.NoError(t, VerifyMultiStoreProof("store1", "/subspace", []byte("w"), wValue, cid.Hash, wProof))
+	zValue, zProof := query("/store1/subspace", []byte("z"))
+	require.NoError(t, VerifyMultiStoreProof("store1", "/subspace", []byte("z"), zValue, cid.Hash, zProof))
+	require.Error(t, VerifyMultiStoreProof("store1", "/subspace", []byte("w"), zValue, cid.Hash, zProof))
+
+	// an empty substore is proved to be empty
+	value, proof = query("/store2/subspace", []byte("w"))
+	require.NoError(t, VerifyMultiStoreProof("store2", "/subspace", []byte("w"), value, cid.Hash, proof))
+	require.Error(t, VerifyMultiStoreProof("store2", "/subspace", []byte("w"), wValue, cid.Hash, proof))
+	value, proof = query("/store2/key", []byte("wind"))
+	require.NoError(t, VerifyMultiStoreProof("store2", "/key", []byte("wind"), value, cid.Hash, proof))
+	require.Error(t, VerifyMultiStoreProof("store2", "/key", []byte("wind"), []byte("blows"), cid.Hash, proof))
+}
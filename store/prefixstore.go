@@ -69,6 +69,20 @@ func (s prefixStore) Delete(key []byte) {
 	s.parent.Delete(s.key(key))
 }
 
+// Implements KVStore
+func (s prefixStore) DeleteRange(start, end []byte) {
+	newstart := cloneAppend(s.prefix, start)
+
+	var newend []byte
+	if end == nil {
+		newend = cpIncr(s.prefix)
+	} else {
+		newend = cloneAppend(s.prefix, end)
+	}
+
+	s.parent.DeleteRange(newstart, newend)
+}
+
 // Implements KVStore
 func (s prefixStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{s, prefix}
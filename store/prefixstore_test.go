@@ -118,6 +118,30 @@ func TestPrefixStoreIterate(t *testing.T) {
 	pIter.Close()
 }
 
+func TestPrefixStoreDeleteRange(t *testing.T) {
+	baseStore := dbStoreAdapter{dbm.NewMemDB()}
+	baseStore.Set([]byte("tesa"), []byte("before"))
+	baseStore.Set([]byte("tesu"), []byte("after"))
+	prefixStore := baseStore.Prefix([]byte("test"))
+	setRandomKVPairs(t, prefixStore)
+
+	// nil bounds only cover the keys with the prefix
+	prefixStore.DeleteRange(nil, nil)
+	iter := prefixStore.Iterator(nil, nil)
+	require.False(t, iter.Valid())
+	iter.Close()
+	require.Equal(t, []byte("before"), baseStore.Get([]byte("tesa")))
+	require.Equal(t, []byte("after"), baseStore.Get([]byte("tesu")))
+
+	prefixStore.Set([]byte{0x01}, []byte{0x01})
+	prefixStore.Set([]byte{0x02}, []byte{0x02})
+	prefixStore.Set([]byte{0x03}, []byte{0x03})
+	prefixStore.DeleteRange([]byte{0x02}, nil)
+	require.True(t, prefixStore.Has([]byte{0x01}))
+	require.False(t, prefixStore.Has([]byte{0x02}))
+	require.False(t, prefixStore.Has([]byte{0x03}))
+}
+
 func incFirstByte(bz []byte) {
 	bz[0]++
 }
@@ -291,7 +291,9 @@ func (rs *rootMultiStore) Query(req abci.RequestQuery) abci.ResponseQuery {
 	req.Path = subpath
 	res := queryable.Query(req)
 
-	if !req.Prove || !RequireProof(subpath) {
+	// the proof of an empty substore only holds the commit info, proving that
+	// the substore is empty
+	if !req.Prove || !RequireProof(subpath) || !res.IsOK() {
 		return res
 	}
 
@@ -0,0 +1,52 @@
+package store
+
+import (
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// Sequence is a monotonic counter stored under a single key, used to assign
+// IDs
+// It panics when the counter cannot be (un/)marshalled by the codec
+type Sequence struct {
+	cdc   *codec.Codec
+	store sdk.KVStore
+	key   []byte
+}
+
+// NewSequence constructs new Sequence
+func NewSequence(cdc *codec.Codec, store sdk.KVStore, key []byte) Sequence {
+	return Sequence{
+		cdc:   cdc,
+		store: store,
+		key:   key,
+	}
+}
+
+// Has returns true if the sequence was initialized with Set
+func (s Sequence) Has() bool {
+	return s.store.Has(s.key)
+}
+
+// Peek returns the next value of the sequence without incrementing it
+// The sequence starts at 0 if it was never set
+func (s Sequence) Peek() (res uint64) {
+	bz := s.store.Get(s.key)
+	if bz == nil {
+		return 0
+	}
+	s.cdc.MustUnmarshalBinary(bz, &res)
+	return
+}
+
+// Next returns the next value of the sequence and increments it
+func (s Sequence) Next() uint64 {
+	next := s.Peek()
+	s.Set(next + 1)
+	return next
+}
+
+// Set sets the next value of the sequence
+func (s Sequence) Set(next uint64) {
+	s.store.Set(s.key, s.cdc.MustMarshalBinary(next))
+}
@@ -0,0 +1,85 @@
+package store
+
+import (
+	"time"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// TimeQueue is a queue of values ordered by time. Values enqueued with the
+// same time are dequeued in insertion order. The values are stored in a Map
+// under the 0x01 prefix, keyed by time and an insertion Sequence stored under
+// 0x00.
+// It panics when the value type cannot be (un/)marshalled by the codec
+type TimeQueue struct {
+	seq     Sequence
+	entries Map
+}
+
+// NewTimeQueue constructs new TimeQueue
+func NewTimeQueue(cdc *codec.Codec, store sdk.KVStore) TimeQueue {
+	return TimeQueue{
+		seq:     NewSequence(cdc, store, []byte{0x00}),
+		entries: NewMap(cdc, store.Prefix([]byte{0x01}), PairKey(TimeKey, Uint64Key)),
+	}
+}
+
+// Enqueue inserts the value at the given time
+func (q TimeQueue) Enqueue(t time.Time, value interface{}) {
+	q.entries.Set(Pair{t, q.seq.Next()}, value)
+}
+
+// IsEmpty checks if the queue is empty
+func (q TimeQueue) IsEmpty() bool {
+	iter := q.entries.Iterate(nil)
+	defer iter.Close()
+	return !iter.Valid()
+}
+
+// Peek unmarshals the earliest value into ptr and returns its time. It
+// returns false if the queue is empty.
+func (q TimeQueue) Peek(ptr interface{}) (t time.Time, ok bool) {
+	iter := q.entries.Iterate(nil)
+	defer iter.Close()
+	if !iter.Valid() {
+		return t, false
+	}
+	iter.DecodeValue(ptr)
+	return iter.DecodeKey().(Pair).K1.(time.Time), true
+}
+
+// IterateMature returns an iterator over the values with a time before or
+// equal to endTime, earliest first
+// CONTRACT: No writes may happen within a domain while iterating over it.
+func (q TimeQueue) IterateMature(endTime time.Time) MapIterator {
+	return q.entries.Iterate(q.matureRange(endTime))
+}
+
+// DequeueMature removes the values with a time before or equal to endTime,
+// calling fn with each of them unmarshalled into ptr, earliest first. Values
+// may be enqueued by fn.
+func (q TimeQueue) DequeueMature(endTime time.Time, ptr interface{}, fn func(t time.Time)) {
+	rng := q.matureRange(endTime)
+
+	var (
+		times  []time.Time
+		values [][]byte
+	)
+	iter := q.entries.Iterate(rng)
+	for ; iter.Valid(); iter.Next() {
+		times = append(times, iter.DecodeKey().(Pair).K1.(time.Time))
+		values = append(values, iter.Value())
+	}
+	iter.Close()
+	q.entries.DeleteRange(rng)
+
+	for i, bz := range values {
+		q.entries.cdc.MustUnmarshalBinary(bz, ptr)
+		fn(times[i])
+	}
+}
+
+func (q TimeQueue) matureRange(endTime time.Time) *Range {
+	return &Range{end: sdk.PrefixEndBytes(TimeKey.EncodeKey(endTime))}
+}
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestTimeQueue(t *testing.T) {
+	key := sdk.NewKVStoreKey("test")
+	ctx, cdc := defaultComponents(key)
+	q := NewTimeQueue(cdc, ctx.KVStore(key))
+
+	t0 := time.Unix(0, 0).UTC()
+	var res S
+	require.True(t, q.IsEmpty())
+	_, ok := q.Peek(&res)
+	require.False(t, ok)
+
+	q.Enqueue(t0.Add(2*time.Second), S{3, true})
+	q.Enqueue(t0.Add(time.Second), S{1, true})
+	q.Enqueue(t0.Add(time.Second), S{2, true})
+	q.Enqueue(t0.Add(3*time.Second), S{4, true})
+	require.False(t, q.IsEmpty())
+
+	peekTime, ok := q.Peek(&res)
+	require.True(t, ok)
+	require.Equal(t, t0.Add(time.Second), peekTime)
+	require.Equal(t, S{1, true}, res)
+
+	// values with the same time keep their insertion order
+	iter := q.IterateMature(t0.Add(2 * time.Second))
+	for i := uint64(1); i <= 3; i++ {
+		require.True(t, iter.Valid())
+		iter.DecodeValue(&res)
+		require.Equal(t, i, res.I)
+		iter.Next()
+	}
+	require.False(t, iter.Valid())
+	iter.Close()
+
+	var dequeued []uint64
+	q.DequeueMature(t0.Add(2*time.Second), &res, func(time.Time) {
+		dequeued = append(dequeued, res.I)
+		// enqueueing while dequeueing is allowed
+		if res.I == 1 {
+			q.Enqueue(t0.Add(5*time.Second), S{5, true})
+		}
+	})
+	require.Equal(t, []uint64{1, 2, 3}, dequeued)
+
+	peekTime, ok = q.Peek(&res)
+	require.True(t, ok)
+	require.Equal(t, t0.Add(3*time.Second), peekTime)
+
+	dequeued = nil
+	q.DequeueMature(t0.Add(time.Hour), &res, func(time.Time) {
+		dequeued = append(dequeued, res.I)
+	})
+	require.Equal(t, []uint64{4, 5}, dequeued)
+	require.True(t, q.IsEmpty())
+}
@@ -71,6 +71,16 @@ func (tkv *TraceKVStore) Delete(key []byte) {
 	tkv.parent.Delete(key)
 }
 
+// DeleteRange implements the KVStore interface. It traces a delete operation
+// for each key in the domain and delegates the DeleteRange call to the parent
+// KVStore.
+func (tkv *TraceKVStore) DeleteRange(start, end []byte) {
+	for _, key := range rangeKeys(tkv.parent, start, end) {
+		writeOperation(tkv.writer, deleteOp, tkv.context, key, nil)
+	}
+	tkv.parent.DeleteRange(start, end)
+}
+
 // Has implements the KVStore interface. It delegates the Has call to the
 // parent KVStore.
 func (tkv *TraceKVStore) Has(key []byte) bool {
@@ -33,6 +33,11 @@ func (ts *transientStore) LastCommitID() (id CommitID) {
 	return
 }
 
+// Implements KVStore
+func (ts *transientStore) DeleteRange(start, end []byte) {
+	deleteKeys(ts, start, end)
+}
+
 // Implements KVStore
 func (ts *transientStore) Prefix(prefix []byte) KVStore {
 	return prefixStore{ts, prefix}
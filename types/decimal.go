@@ -252,6 +252,13 @@ func (d Dec) IsInteger() bool {
 	return new(big.Int).Rem(d.Int, precisionReuse).Sign() == 0
 }
 
+// Float64 returns the float64 nearest to the decimal, e.g. to report it as a
+// metric. It must not be used in the state machine.
+func (d Dec) Float64() float64 {
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(d.Int), new(big.Float).SetInt(precisionReuse)).Float64()
+	return f
+}
+
 func (d Dec) String() string {
 	bz, err := d.Int.MarshalText()
 	if err != nil {
@@ -364,3 +364,19 @@ func TestDecMulInt(t *testing.T) {
 		require.Equal(t, tc.want, got, "Incorrect result on test case %d", i)
 	}
 }
+
+func TestDecFloat64(t *testing.T) {
+	tests := []struct {
+		d   Dec
+		exp float64
+	}{
+		{ZeroDec(), 0},
+		{NewDecWithPrec(25, 2), 0.25},
+		{NewDecWithPrec(-5, 10), -0.0000000005},
+		{NewDec(1000000), 1000000},
+		{mustNewDecFromStr(t, "-7.5"), -7.5},
+	}
+	for tcIndex, tc := range tests {
+		require.Equal(t, tc.exp, tc.d.Float64(), "tc %d", tcIndex)
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import "math"
+
+// FeePriorityScale scales the fee per gas ratio computed by FeePriority so
+// that prices below one coin unit per gas remain distinguishable once
+// truncated to an integer.
+const FeePriorityScale = 1000000
+
+// TxPriorityFunc computes the mempool priority of a transaction that passed
+// the AnteHandler in CheckTx. Higher priorities are more valuable.
+type TxPriorityFunc func(ctx Context, tx Tx) int64
+
+// FeeTx is implemented by transactions paying a fee for the gas they use,
+// e.g. auth.StdTx.
+type FeeTx interface {
+	Tx
+
+	// GetFee returns the fee paid by the transaction.
+	GetFee() Coins
+
+	// GetGas returns the maximum gas the fee pays for.
+	GetGas() int64
+}
+
+// FeePriority returns the amount paid per unit of gas, scaled by
+// FeePriorityScale, or zero if no gas is paid for.
+func FeePriority(amount Int, gas int64) int64 {
+	if gas <= 0 {
+		return 0
+	}
+	priority := amount.MulRaw(FeePriorityScale).DivRaw(gas)
+	if !priority.IsInt64() {
+		return math.MaxInt64
+	}
+	return priority.Int64()
+}
+
+// DefaultTxPriority is the TxPriorityFunc used by BaseApp when none is set
+// and the AnteHandler doesn't report a priority either. FeeTxs are ordered by
+// the fee they pay per unit of gas, summing the amounts of all denominations;
+// other transactions have a zero priority.
+func DefaultTxPriority(ctx Context, tx Tx) int64 {
+	feeTx, ok := tx.(FeeTx)
+	if !ok {
+		return 0
+	}
+	amount := ZeroInt()
+	for _, coin := range feeTx.GetFee() {
+		amount = amount.Add(coin.Amount)
+	}
+	return FeePriority(amount, feeTx.GetGas())
+}
+
+// MempoolPolicy decides whether a transaction that passed the AnteHandler in
+// CheckTx is admitted to the local mempool. It lets validators protect
+// themselves against spam without affecting consensus: it is never consulted
+// in DeliverTx.
+type MempoolPolicy interface {
+	// Sender returns the key grouping the pending transactions whose changes
+	// to the check state build on each other, e.g. their fee payer.
+	Sender(tx Tx) string
+
+	// Replaced returns the hash of the pending transaction tx competes with for
+	// the same slot (e.g. same sender and sequence), or nil if there is none.
+	// The AnteHandler then runs against the check state without the changes
+	// of the transactions of the same sender admitted from that slot on.
+	Replaced(tx Tx) []byte
+
+	// Admit records tx as pending or returns an error if it is rejected. A
+	// replaced transaction can't be removed from the Tendermint mempool, so
+	// it must be rejected when it is rechecked after the next Commit.
+	Admit(tx Tx, txHash []byte, priority int64) Error
+
+	// Reset forgets all pending transactions. It is called on Commit, after
+	// which Tendermint rechecks the remaining mempool transactions.
+	Reset()
+}
@@ -0,0 +1,13 @@
+package types
+
+// GaugeFunc computes the value of a gauge from the state of a module.
+type GaugeFunc func(ctx Context) float64
+
+// GaugeRegistry lets modules expose metrics of their state. The registered
+// gauges are set from the state at the end of every block, and only computed
+// if the application exposes metrics.
+type GaugeRegistry interface {
+	// RegisterGauge registers the gauge with the given name in the subsystem
+	// of the module, e.g. "stake".
+	RegisterGauge(subsystem, name, help string, value GaugeFunc)
+}
@@ -1,5 +1,9 @@
 package types
 
+import (
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+)
+
 // Result is the union of ResponseDeliverTx and ResponseCheckTx.
 type Result struct {
 
@@ -22,6 +26,10 @@ type Result struct {
 	FeeAmount int64
 	FeeDenom  string
 
+	// Priority is a hint for ordering txs in the mempool, higher is better.
+	// It is only set in CheckTx.
+	Priority int64
+
 	// Tags are used for transaction indexing and pubsub.
 	Tags Tags
 }
@@ -30,3 +38,53 @@ type Result struct {
 func (res Result) IsOK() bool {
 	return res.Code.IsOK()
 }
+
+// MsgResult is the result of a single Msg of a transaction.
+type MsgResult struct {
+	// MsgIndex is the position of the Msg in the transaction.
+	MsgIndex int `json:"msg_index"`
+
+	// Route is the route the Msg was dispatched to.
+	Route string `json:"route"`
+
+	Code    ABCICodeType `json:"code"`
+	Data    []byte       `json:"data"`
+	Log     string       `json:"log"`
+	GasUsed int64        `json:"gas_used"`
+	Tags    Tags         `json:"tags"`
+}
+
+// IsOK returns true if the Msg succeeded.
+func (res MsgResult) IsOK() bool {
+	return res.Code.IsOK()
+}
+
+// MsgResults are the results of the Msgs of a transaction, in order.
+// Execution stops at the first failing Msg, which is then the last result.
+// They are returned in the Data of a DeliverTx response.
+type MsgResults []MsgResult
+
+// Bytes returns the deterministic binary encoding of the results.
+func (results MsgResults) Bytes() []byte {
+	return codec.Cdc.MustMarshalBinaryBare(results)
+}
+
+// DecodeMsgResults decodes results encoded with MsgResults.Bytes.
+func DecodeMsgResults(bz []byte) (results MsgResults, err error) {
+	err = codec.Cdc.UnmarshalBinaryBare(bz, &results)
+	return results, err
+}
+
+// DecodeMsgData returns the Data of each Msg of a transaction, in order, from
+// the Data of its DeliverTx response.
+func DecodeMsgData(txData []byte) ([][]byte, error) {
+	results, err := DecodeMsgResults(txData)
+	if err != nil {
+		return nil, err
+	}
+	data := make([][]byte, len(results))
+	for i, result := range results {
+		data[i] = result.Data
+	}
+	return data, nil
+}
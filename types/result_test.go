@@ -16,3 +16,40 @@ func TestResult(t *testing.T) {
 	res.Code = ABCICodeType(1)
 	require.False(t, res.IsOK())
 }
+
+func TestMsgResultsEncoding(t *testing.T) {
+	results := MsgResults{
+		{MsgIndex: 0, Route: "bank", Data: []byte("data"), Log: "ok", GasUsed: 10,
+			Tags: NewTags(TagAction, []byte("send"))},
+		{MsgIndex: 1, Route: "stake", Code: ABCICodeType(1), Log: "failed", GasUsed: 5},
+	}
+	require.True(t, results[0].IsOK())
+	require.False(t, results[1].IsOK())
+
+	bz := results.Bytes()
+	require.Equal(t, bz, results.Bytes())
+
+	decoded, err := DecodeMsgResults(bz)
+	require.NoError(t, err)
+	require.Equal(t, len(results), len(decoded))
+	for i := range results {
+		require.Equal(t, results[i].Route, decoded[i].Route)
+		require.Equal(t, results[i].Code, decoded[i].Code)
+		require.Equal(t, results[i].Log, decoded[i].Log)
+		require.Equal(t, results[i].GasUsed, decoded[i].GasUsed)
+		require.Equal(t, results[i].Tags.ToKVPairs(), decoded[i].Tags.ToKVPairs())
+	}
+
+	_, err = DecodeMsgResults([]byte("not results"))
+	require.Error(t, err)
+}
+
+func TestDecodeMsgData(t *testing.T) {
+	results := MsgResults{{Data: []byte("data")}, {MsgIndex: 1}}
+	data, err := DecodeMsgData(results.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("data"), nil}, data)
+
+	_, err = DecodeMsgData([]byte("not results"))
+	require.Error(t, err)
+}
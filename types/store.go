@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 
@@ -133,6 +134,11 @@ type KVStore interface {
 	// Delete deletes the key. Panics on nil key.
 	Delete(key []byte)
 
+	// DeleteRange deletes all keys in a domain. End is exclusive.
+	// As for Iterator, a nil start or end is unbounded.
+	// CONTRACT: No iterator may exist over the domain.
+	DeleteRange(start, end []byte)
+
 	// Iterator over a domain of keys in ascending order. End is exclusive.
 	// Start must be less than end, or the Iterator is invalid.
 	// Iterator must be closed by caller.
@@ -176,6 +182,73 @@ func KVStoreReversePrefixIterator(kvs KVStore, prefix []byte) Iterator {
 	return kvs.ReverseIterator(prefix, PrefixEndBytes(prefix))
 }
 
+// DiffKVStores compares two KVStores key by key, skipping the keys which start
+// with any of prefixesToSkip, and returns the pairs of each store which differ,
+// up to limit differences (no limit if it is zero), along with the number of
+// keys compared. The n-th pairs of kvAs and kvBs have the same key, and a key
+// missing from one of the stores is returned with a nil value for that store.
+func DiffKVStores(a KVStore, b KVStore, prefixesToSkip [][]byte, limit int) (kvAs, kvBs []cmn.KVPair, count int64) {
+	iterA := a.Iterator(nil, nil)
+	defer iterA.Close()
+	iterB := b.Iterator(nil, nil)
+	defer iterB.Close()
+
+	for limit <= 0 || len(kvAs) < limit {
+		skipPrefixes(iterA, prefixesToSkip)
+		skipPrefixes(iterB, prefixesToSkip)
+		if !iterA.Valid() && !iterB.Valid() {
+			break
+		}
+		count++
+
+		var cmp int
+		switch {
+		case !iterA.Valid():
+			cmp = 1
+		case !iterB.Valid():
+			cmp = -1
+		default:
+			cmp = bytes.Compare(iterA.Key(), iterB.Key())
+		}
+
+		switch {
+		case cmp < 0:
+			kvAs = append(kvAs, cmn.KVPair{Key: iterA.Key(), Value: iterA.Value()})
+			kvBs = append(kvBs, cmn.KVPair{Key: iterA.Key()})
+			iterA.Next()
+		case cmp > 0:
+			kvAs = append(kvAs, cmn.KVPair{Key: iterB.Key()})
+			kvBs = append(kvBs, cmn.KVPair{Key: iterB.Key(), Value: iterB.Value()})
+			iterB.Next()
+		default:
+			if !bytes.Equal(iterA.Value(), iterB.Value()) {
+				kvAs = append(kvAs, cmn.KVPair{Key: iterA.Key(), Value: iterA.Value()})
+				kvBs = append(kvBs, cmn.KVPair{Key: iterB.Key(), Value: iterB.Value()})
+			}
+			iterA.Next()
+			iterB.Next()
+		}
+	}
+	return kvAs, kvBs, count
+}
+
+// advance the iterator past the keys starting with any of the prefixes
+func skipPrefixes(iter Iterator, prefixes [][]byte) {
+	for iter.Valid() {
+		skip := false
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(iter.Key(), prefix) {
+				skip = true
+				break
+			}
+		}
+		if !skip {
+			return
+		}
+		iter.Next()
+	}
+}
+
 // CacheKVStore cache-wraps a KVStore.  After calling .Write() on
 // the CacheKVStore, all previously created CacheKVStores on the
 // object expire.
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"time"
+
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	"github.com/tendermint/tendermint/crypto/tmhash"
 )
 
 const (
@@ -22,7 +25,10 @@ const (
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
+// Txs whose signatures were verified in CheckTx are remembered by hash, so
+// that they are not verified again in DeliverTx on the same node.
 func NewAnteHandler(am AccountKeeper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	sigCache := newSigCache(defaultSigCacheSize)
 	return func(
 		ctx sdk.Context, tx sdk.Tx, simulate bool,
 	) (newCtx sdk.Context, res sdk.Result, abort bool) {
@@ -88,21 +94,46 @@ func NewAnteHandler(am AccountKeeper, fck FeeCollectionKeeper) sdk.AnteHandler {
 		// first sig pays the fees
 		if !stdTx.Fee.Amount.IsZero() {
 			// signerAccs[0] is the fee payer
-			signerAccs[0], res = deductFees(signerAccs[0], stdTx.Fee)
+			signerAccs[0], res = deductFees(ctx.BlockHeader().Time, signerAccs[0], stdTx.Fee)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
 			fck.AddCollectedFees(newCtx, stdTx.Fee.Amount)
 		}
 
+		// the signatures of a tx with the same hash were already verified by
+		// this node, e.g. in CheckTx; gas is consumed regardless
+		var txHash []byte
+		if !simulate && len(newCtx.TxBytes()) > 0 {
+			txHash = tmhash.Sum(newCtx.TxBytes())
+		}
+		verified := txHash != nil && sigCache.Has(txHash)
+
+		sigVerifications := make([]sigVerification, 0, len(stdSigs))
 		for i := 0; i < len(stdSigs); i++ {
-			// check signature, return account with incremented nonce
-			signerAccs[i], res = processSig(newCtx, signerAccs[i], stdSigs[i], signBytesList[i], simulate)
+			// return account with pubkey set and incremented nonce
+			var pubKey crypto.PubKey
+			signerAccs[i], pubKey, res = processSig(newCtx, signerAccs[i], stdSigs[i], simulate)
 			if !res.IsOK() {
 				return newCtx, res, true
 			}
+			if !simulate && !verified {
+				sigVerifications = append(sigVerifications,
+					sigVerification{pubKey, signBytesList[i], stdSigs[i].Signature})
+			}
+		}
 
-			// Save the account.
+		// check signatures
+		if idx := batchVerifySigs(sigVerifications); idx >= 0 {
+			return newCtx, sdk.ErrUnauthorized(
+				fmt.Sprintf("signature verification failed for signer %d", idx)).Result(), true
+		}
+		if txHash != nil && !verified {
+			sigCache.Add(txHash)
+		}
+
+		// Save the accounts.
+		for i := 0; i < len(signerAccs); i++ {
 			am.SetAccount(newCtx, signerAccs[i])
 		}
 
@@ -173,23 +204,21 @@ func validateAccNumAndSequence(ctx sdk.Context, accs []Account, sigs []StdSignat
 	return sdk.Result{}
 }
 
-// verify the signature and increment the sequence.
+// charge gas for the signature verification and increment the sequence.
 // if the account doesn't have a pubkey, set it.
+// The signature itself is verified by the caller with the returned pubkey.
 func processSig(ctx sdk.Context,
-	acc Account, sig StdSignature, signBytes []byte, simulate bool) (updatedAcc Account, res sdk.Result) {
-	pubKey, res := processPubKey(acc, sig, simulate)
+	acc Account, sig StdSignature, simulate bool) (updatedAcc Account, pubKey crypto.PubKey, res sdk.Result) {
+	pubKey, res = processPubKey(acc, sig, simulate)
 	if !res.IsOK() {
-		return nil, res
+		return nil, nil, res
 	}
 	err := acc.SetPubKey(pubKey)
 	if err != nil {
-		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
+		return nil, nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
 
 	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
-	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
-	}
 
 	// increment the sequence number
 	err = acc.SetSequence(acc.GetSequence() + 1)
@@ -198,7 +227,7 @@ func processSig(ctx sdk.Context,
 		panic(err)
 	}
 
-	return acc, res
+	return acc, pubKey, res
 }
 
 var dummySecp256k1Pubkey secp256k1.PubKeySecp256k1
@@ -259,15 +288,17 @@ func adjustFeesByGas(fees sdk.Coins, gas int64) sdk.Coins {
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountKeeper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+func deductFees(blockTime time.Time, acc Account, fee StdFee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
-	newCoins := coins.Minus(feeAmount)
-	if !newCoins.IsNotNegative() {
-		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
+	// the coins which are still vesting can't pay the fees
+	spendableCoins := SpendableCoins(acc, blockTime)
+	if !spendableCoins.Minus(feeAmount).IsNotNegative() {
+		errMsg := fmt.Sprintf("%s < %s", spendableCoins, feeAmount)
 		return nil, sdk.ErrInsufficientFunds(errMsg).Result()
 	}
+	newCoins := coins.Minus(feeAmount)
 	err := acc.SetCoins(newCoins)
 	if err != nil {
 		// Handle w/ #870
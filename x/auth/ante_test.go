@@ -634,6 +634,39 @@ func TestAnteHandlerSetPubKey(t *testing.T) {
 	require.Nil(t, acc2.GetPubKey())
 }
 
+func TestAnteHandlerMixedKeyTypes(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+	ctx = ctx.WithBlockHeight(1)
+
+	// an ed25519 and a secp256k1 signer
+	priv1, addr1 := privAndAddr()
+	priv2 := secp256k1.GenPrivKey()
+	addr2 := sdk.AccAddress(priv2.PubKey().Address())
+
+	// set the accounts
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc2)
+
+	msgs := []sdk.Msg{newTestMsg(addr1, addr2)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1, priv2}, []int64{0, 1}, []int64{0, 0}
+	tx := newTestTx(ctx, msgs, privs, accnums, seqs, newStdFee())
+	checkValidTx(t, anteHandler, ctx, tx, false)
+
+	require.IsType(t, ed25519.PubKeyEd25519{}, mapper.GetAccount(ctx, addr1).GetPubKey())
+	require.IsType(t, secp256k1.PubKeySecp256k1{}, mapper.GetAccount(ctx, addr2).GetPubKey())
+}
+
 func TestProcessPubKey(t *testing.T) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
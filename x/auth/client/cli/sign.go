@@ -20,6 +20,7 @@ const (
 	flagAppend    = "append"
 	flagPrintSigs = "print-sigs"
 	flagOffline   = "offline"
+	flagBatch     = "batch"
 )
 
 // GetSignCommand returns the sign command
@@ -32,7 +33,14 @@ Read a transaction from <file>, sign it, and print its JSON encoding.
 
 The --offline flag makes sure that the client will not reach out to the local cache.
 Thus account number or sequence number lookups will not be performed and it is
-recommended to set such parameters manually.`,
+recommended to set such parameters manually.
+
+The --batch flag signs all the transactions of <file>, one per line, as written
+by appending the output of commands run with --generate-only to the same file.
+They must be sent from the same account: the first transaction is signed with
+the account's sequence, or --sequence, and the sequence is incremented for each
+of the following ones. The signed transactions are printed one per line, so that
+they can be broadcast with the broadcast command.`,
 		RunE: makeSignCmd(codec, decoder),
 		Args: cobra.ExactArgs(1),
 	}
@@ -40,11 +48,16 @@ recommended to set such parameters manually.`,
 	cmd.Flags().Bool(flagAppend, true, "Append the signature to the existing ones. If disabled, old signatures would be overwritten")
 	cmd.Flags().Bool(flagPrintSigs, false, "Print the addresses that must sign the transaction and those who have already signed it, then exit")
 	cmd.Flags().Bool(flagOffline, false, "Offline mode. Do not query local cache.")
+	cmd.Flags().Bool(flagBatch, false, "Sign a batch of transactions, one per line, with consecutive sequences")
 	return cmd
 }
 
 func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) (err error) {
+		if viper.GetBool(flagBatch) {
+			return signBatch(cdc, decoder, args[0])
+		}
+
 		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
 		if err != nil {
 			return
@@ -77,6 +90,40 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 	}
 }
 
+// signBatch signs the txs of a batch file and prints them one per line.
+func signBatch(cdc *amino.Codec, decoder auth.AccountDecoder, filename string) error {
+	stdTxs, err := utils.ReadStdTxs(cdc, filename)
+	if err != nil {
+		return err
+	}
+
+	if viper.GetBool(flagPrintSigs) {
+		for i, stdTx := range stdTxs {
+			fmt.Printf("Transaction %d:\n", i)
+			printSignatures(stdTx)
+		}
+		return nil
+	}
+
+	name := viper.GetString(client.FlagName)
+	cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
+	txBldr := authtxb.NewTxBuilderFromCLI()
+
+	signedTxs, err := utils.SignStdTxs(txBldr, cliCtx, name, stdTxs, viper.GetBool(flagAppend), viper.GetBool(flagOffline))
+	if err != nil {
+		return err
+	}
+	for _, signedTx := range signedTxs {
+		// the txs are never indented to be read back
+		json, err := cdc.MarshalJSON(signedTx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", json)
+	}
+	return nil
+}
+
 func printSignatures(stdTx auth.StdTx) {
 	fmt.Println("Signers:")
 	for i, signer := range stdTx.GetSigners() {
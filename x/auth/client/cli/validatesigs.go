@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/yukimochizuki/cosmos-sdk/client"
+	"github.com/yukimochizuki/cosmos-sdk/client/utils"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	amino "github.com/tendermint/go-amino"
+)
+
+// GetValidateSignaturesCommand returns the validate-signatures command
+func GetValidateSignaturesCommand(codec *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate-signatures <file>",
+		Short: "Validate the signatures of transactions signed offline",
+		Long: `Read a transaction, or a batch of transactions one per line, from <file> and
+report for each transaction whether each of its signers has signed it with a
+valid signature, along with the account number and sequence of the signature.
+
+Signatures are verified against the chain ID given by --chain-id, no node is
+queried. The command fails if any signature is missing or invalid.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chainID := viper.GetString(client.FlagChainID)
+			if chainID == "" {
+				return errors.New("chain ID required but not specified")
+			}
+
+			stdTxs, err := utils.ReadStdTxs(codec, args[0])
+			if err != nil {
+				return err
+			}
+
+			invalid := 0
+			for i, stdTx := range stdTxs {
+				fmt.Printf("Transaction %d:\n", i)
+				if !printSignersStatus(chainID, stdTx) {
+					invalid++
+				}
+			}
+
+			if invalid > 0 {
+				return errors.Errorf("%d of %d transactions have missing or invalid signatures", invalid, len(stdTxs))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().String(client.FlagChainID, "", "Chain ID of tendermint node")
+	return cmd
+}
+
+// printSignersStatus prints whether each signer of a tx has signed it and
+// returns true if all the signatures are valid. As the ante handler does, the
+// signature of the i-th signer is expected to be the i-th one.
+func printSignersStatus(chainID string, stdTx auth.StdTx) bool {
+	valid := true
+	sigs := stdTx.GetSignatures()
+	for i, signer := range stdTx.GetSigners() {
+		status := signatureStatus(chainID, stdTx, signer, sigs, i)
+		if status != "" {
+			valid = false
+			fmt.Printf(" %v: %v %s\n", i, signer.String(), status)
+			continue
+		}
+		fmt.Printf(" %v: %v OK (account number %d, sequence %d)\n", i, signer.String(), sigs[i].AccountNumber, sigs[i].Sequence)
+	}
+
+	if extra := len(sigs) - len(stdTx.GetSigners()); extra > 0 {
+		valid = false
+		fmt.Printf(" %d unexpected signatures\n", extra)
+	}
+	return valid
+}
+
+// signatureStatus returns why the i-th signature doesn't validly sign the tx
+// for signer, or an empty string if it does.
+func signatureStatus(chainID string, stdTx auth.StdTx, signer sdk.AccAddress, sigs []auth.StdSignature, i int) string {
+	if i >= len(sigs) {
+		return "MISSING SIGNATURE"
+	}
+	sig := sigs[i]
+	if sig.PubKey == nil {
+		return "MISSING PUBLIC KEY"
+	}
+	if !bytes.Equal(sig.PubKey.Address(), signer) {
+		return fmt.Sprintf("SIGNED BY %v", sdk.AccAddress(sig.PubKey.Address()).String())
+	}
+
+	signBytes := auth.StdSignBytes(chainID, sig.AccountNumber, sig.Sequence, stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo())
+	if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+		return fmt.Sprintf("INVALID SIGNATURE (account number %d, sequence %d)", sig.AccountNumber, sig.Sequence)
+	}
+	return ""
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
@@ -14,19 +15,26 @@ import (
 )
 
 // register REST routes
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, storeName string) {
-	r.HandleFunc(
-		"/auth/accounts/{address}",
-		QueryAccountRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx),
-	).Methods("GET")
-	r.HandleFunc(
-		"/bank/balances/{address}",
-		QueryBalancesRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx),
-	).Methods("GET")
-	r.HandleFunc(
-		"/tx/sign",
-		SignTxRequestHandlerFn(cdc, cliCtx),
-	).Methods("POST")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec, storeName string) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/auth/accounts/{address}",
+		Summary:  "Get the account of an address",
+		Response: (*auth.Account)(nil),
+	}, QueryAccountRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/bank/balances/{address}",
+		Summary:  "Get the coins of an address",
+		Response: sdk.Coins{},
+	}, QueryBalancesRequestHandlerFn(storeName, cdc, authcmd.GetAccountDecoder(cdc), cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/tx/sign",
+		Summary:  "Sign a tx",
+		Request:  SignBody{},
+		Response: auth.StdTx{},
+	}, SignTxRequestHandlerFn(cdc, cliCtx))
 }
 
 // query accountREST Handler
@@ -6,7 +6,13 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 )
 
-var globalAccountNumberKey = []byte("globalAccountNumber")
+var (
+	// AddressStoreKeyPrefix prefix for account-by-address store
+	AddressStoreKeyPrefix = []byte("account:")
+
+	// GlobalAccountNumberKey key for the global account number
+	GlobalAccountNumberKey = []byte("globalAccountNumber")
+)
 
 // This AccountKeeper encodes/decodes accounts using the
 // go-amino (binary) encoding/decoding library.
@@ -61,7 +67,7 @@ func (am AccountKeeper) NewAccount(ctx sdk.Context, acc Account) Account {
 
 // Turn an address to key used to get it from the account store
 func AddressStoreKey(addr sdk.AccAddress) []byte {
-	return append([]byte("account:"), addr.Bytes()...)
+	return append(AddressStoreKeyPrefix, addr.Bytes()...)
 }
 
 // Implements sdk.AccountKeeper.
@@ -93,7 +99,7 @@ func (am AccountKeeper) RemoveAccount(ctx sdk.Context, acc Account) {
 // Implements sdk.AccountKeeper.
 func (am AccountKeeper) IterateAccounts(ctx sdk.Context, process func(Account) (stop bool)) {
 	store := ctx.KVStore(am.key)
-	iter := sdk.KVStorePrefixIterator(store, []byte("account:"))
+	iter := sdk.KVStorePrefixIterator(store, AddressStoreKeyPrefix)
 	defer iter.Close()
 	for {
 		if !iter.Valid() {
@@ -144,7 +150,7 @@ func (am AccountKeeper) setSequence(ctx sdk.Context, addr sdk.AccAddress, newSeq
 func (am AccountKeeper) GetNextAccountNumber(ctx sdk.Context) int64 {
 	var accNumber int64
 	store := ctx.KVStore(am.key)
-	bz := store.Get(globalAccountNumberKey)
+	bz := store.Get(GlobalAccountNumberKey)
 	if bz == nil {
 		accNumber = 0
 	} else {
@@ -155,11 +161,19 @@ func (am AccountKeeper) GetNextAccountNumber(ctx sdk.Context) int64 {
 	}
 
 	bz = am.cdc.MustMarshalBinary(accNumber + 1)
-	store.Set(globalAccountNumberKey, bz)
+	store.Set(GlobalAccountNumberKey, bz)
 
 	return accNumber
 }
 
+// Sets the global account number counter, e.g. past the account numbers
+// loaded from a genesis file
+func (am AccountKeeper) SetNextAccountNumber(ctx sdk.Context, accNumber int64) {
+	store := ctx.KVStore(am.key)
+	bz := am.cdc.MustMarshalBinary(accNumber)
+	store.Set(GlobalAccountNumberKey, bz)
+}
+
 //----------------------------------------
 // misc.
 
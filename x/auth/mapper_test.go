@@ -25,6 +25,23 @@ func setupMultiStore() (sdk.MultiStore, *sdk.KVStoreKey, *sdk.KVStoreKey) {
 	return ms, capKey, capKey2
 }
 
+func TestAccountMapperNextAccountNumber(t *testing.T) {
+	ms, capKey, _ := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+
+	ctx := sdk.NewContext(ms, abci.Header{}, false, log.NewNopLogger())
+	mapper := NewAccountKeeper(cdc, capKey, ProtoBaseAccount)
+
+	require.Equal(t, int64(0), mapper.GetNextAccountNumber(ctx))
+	require.Equal(t, int64(1), mapper.GetNextAccountNumber(ctx))
+
+	mapper.SetNextAccountNumber(ctx, 10)
+	acc := mapper.NewAccountWithAddress(ctx, sdk.AccAddress([]byte("some-address")))
+	require.Equal(t, int64(10), acc.GetAccountNumber())
+	require.Equal(t, int64(11), mapper.GetNextAccountNumber(ctx))
+}
+
 func TestAccountMapperGetSet(t *testing.T) {
 	ms, capKey, _ := setupMultiStore()
 	cdc := codec.New()
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+var _ sdk.MempoolPolicy = (*MempoolPolicy)(nil)
+
+// MempoolPolicy is a local mempool admission policy for StdTxs. Pending txs
+// are tracked by fee payer (the first signer) and sequence. A tx with the same
+// fee payer and sequence as a pending tx replaces it only if it has a strictly
+// higher priority (replace-by-fee), and a fee payer may have at most
+// maxPending txs pending at once. A maxPending of zero disables the limit.
+//
+// A replaced tx stays in the Tendermint mempool, and may still be included in
+// a block by a proposer, until it is rechecked after the next commit: it is
+// then rejected, which evicts it from the mempool.
+type MempoolPolicy struct {
+	maxPending int
+	pending    map[string]map[int64]pendingTx // fee payer -> sequence -> tx
+	replaced   map[string]bool                // hashes of the txs replaced since the last reset
+	evicted    map[string]bool                // hashes of the txs replaced before the last reset
+}
+
+type pendingTx struct {
+	hash     []byte
+	priority int64
+}
+
+// NewMempoolPolicy returns a new MempoolPolicy allowing at most maxPending
+// pending txs per fee payer.
+func NewMempoolPolicy(maxPending int) *MempoolPolicy {
+	return &MempoolPolicy{
+		maxPending: maxPending,
+		pending:    make(map[string]map[int64]pendingTx),
+		replaced:   make(map[string]bool),
+		evicted:    make(map[string]bool),
+	}
+}
+
+// Sender implements sdk.MempoolPolicy. Txs are grouped by fee payer.
+func (mp *MempoolPolicy) Sender(tx sdk.Tx) string {
+	payer, _, _ := feePayerSlot(tx)
+	return payer
+}
+
+// Replaced implements sdk.MempoolPolicy.
+func (mp *MempoolPolicy) Replaced(tx sdk.Tx) []byte {
+	payer, seq, ok := feePayerSlot(tx)
+	if !ok {
+		return nil
+	}
+	prev, found := mp.pending[payer][seq]
+	if !found {
+		return nil
+	}
+	return prev.hash
+}
+
+// Admit implements sdk.MempoolPolicy.
+func (mp *MempoolPolicy) Admit(tx sdk.Tx, txHash []byte, priority int64) sdk.Error {
+	if mp.replaced[string(txHash)] || mp.evicted[string(txHash)] {
+		return sdk.ErrInsufficientFee("tx was replaced by a tx with a higher priority")
+	}
+
+	payer, seq, ok := feePayerSlot(tx)
+	if !ok {
+		// nothing to track, e.g. the tx has no signatures
+		return nil
+	}
+
+	slots, ok := mp.pending[payer]
+	if !ok {
+		slots = make(map[int64]pendingTx)
+		mp.pending[payer] = slots
+	}
+
+	if prev, found := slots[seq]; found {
+		if bytes.Equal(prev.hash, txHash) {
+			return nil
+		}
+		if priority <= prev.priority {
+			return sdk.ErrInsufficientFee(fmt.Sprintf(
+				"replacement tx priority %d must exceed pending tx priority %d", priority, prev.priority))
+		}
+		mp.replaced[string(prev.hash)] = true
+		slots[seq] = pendingTx{hash: txHash, priority: priority}
+		return nil
+	}
+
+	if mp.maxPending > 0 && len(slots) >= mp.maxPending {
+		return sdk.ErrMempoolFull(fmt.Sprintf(
+			"account %s already has %d pending txs", payer, len(slots)))
+	}
+	slots[seq] = pendingTx{hash: txHash, priority: priority}
+	return nil
+}
+
+// Reset implements sdk.MempoolPolicy. The txs replaced since the previous
+// reset are still rejected until the next one, so that they are evicted when
+// Tendermint rechecks its mempool after the commit.
+func (mp *MempoolPolicy) Reset() {
+	mp.pending = make(map[string]map[int64]pendingTx)
+	mp.evicted = mp.replaced
+	mp.replaced = make(map[string]bool)
+}
+
+// NewFeePriorityFunc returns a TxPriorityFunc ordering StdTxs by the fee they
+// pay per unit of gas in the denomination returned by denom, e.g. the bond
+// denomination of the stake module.
+func NewFeePriorityFunc(denom func(ctx sdk.Context) string) sdk.TxPriorityFunc {
+	return func(ctx sdk.Context, tx sdk.Tx) int64 {
+		stdTx, ok := tx.(StdTx)
+		if !ok {
+			return 0
+		}
+		return stdTx.Fee.Priority(denom(ctx))
+	}
+}
+
+// feePayerSlot returns the fee payer and the sequence of its signature.
+func feePayerSlot(tx sdk.Tx) (payer string, seq int64, ok bool) {
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return "", 0, false
+	}
+	signers := stdTx.GetSigners()
+	sigs := stdTx.GetSignatures()
+	if len(signers) == 0 || len(sigs) == 0 {
+		return "", 0, false
+	}
+	return signers[0].String(), sigs[0].Sequence, true
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func newMempoolTestTx(priv crypto.PrivKey, seq int64, fee StdFee) StdTx {
+	addr := sdk.AccAddress(priv.PubKey().Address())
+	sigs := []StdSignature{{PubKey: priv.PubKey(), Sequence: seq}}
+	return NewStdTx([]sdk.Msg{newTestMsg(addr)}, fee, sigs, "")
+}
+
+func TestStdFeePriority(t *testing.T) {
+	require.Equal(t, int64(0), NewStdFee(0, sdk.NewInt64Coin("atom", 150)).Priority("atom"))
+	require.Equal(t, int64(0), NewStdFee(5000).Priority("atom"))
+	require.Equal(t, int64(30000), newStdFee().Priority("atom"))
+	require.Equal(t, int64(0), newStdFee().Priority("photon"))
+
+	// other denominations are ignored
+	require.Equal(t, int64(30000), NewStdFee(5000,
+		sdk.NewInt64Coin("atom", 150), sdk.NewInt64Coin("photon", 1000000)).Priority("atom"))
+}
+
+func TestFeePriorityFunc(t *testing.T) {
+	priv1, _ := privAndAddr()
+	priorityFunc := NewFeePriorityFunc(func(ctx sdk.Context) string { return "atom" })
+	require.Equal(t, int64(30000), priorityFunc(sdk.Context{}, newMempoolTestTx(priv1, 0, newStdFee())))
+	require.Equal(t, int64(0), priorityFunc(sdk.Context{}, nil))
+}
+
+func TestDefaultTxPriority(t *testing.T) {
+	priv1, _ := privAndAddr()
+
+	// all denominations are summed
+	fee := NewStdFee(5000, sdk.NewInt64Coin("atom", 150), sdk.NewInt64Coin("photon", 50))
+	require.Equal(t, int64(40000), sdk.DefaultTxPriority(sdk.Context{}, newMempoolTestTx(priv1, 0, fee)))
+	require.Equal(t, int64(0), sdk.DefaultTxPriority(sdk.Context{}, newMempoolTestTx(priv1, 0, NewStdFee(0))))
+}
+
+func TestMempoolPolicySender(t *testing.T) {
+	priv1, addr1 := privAndAddr()
+	mp := NewMempoolPolicy(0)
+	require.Equal(t, addr1.String(), mp.Sender(newMempoolTestTx(priv1, 0, newStdFee())))
+	require.Equal(t, "", mp.Sender(NewStdTx(nil, newStdFee(), nil, "")))
+}
+
+func TestMempoolPolicyReplaceByFee(t *testing.T) {
+	priv1, _ := privAndAddr()
+	mp := NewMempoolPolicy(0)
+
+	tx := newMempoolTestTx(priv1, 0, newStdFee())
+	require.Nil(t, mp.Replaced(tx))
+	require.Nil(t, mp.Admit(tx, []byte("hash1"), 10))
+
+	// resubmitting the same tx is a no-op
+	require.Equal(t, []byte("hash1"), mp.Replaced(tx))
+	require.Nil(t, mp.Admit(tx, []byte("hash1"), 10))
+
+	// a replacement must pay a strictly higher priority
+	err := mp.Admit(tx, []byte("hash2"), 10)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeInsufficientFee, err.Code())
+	require.Nil(t, mp.Admit(tx, []byte("hash2"), 11))
+	require.Equal(t, []byte("hash2"), mp.Replaced(tx))
+
+	// a different sequence is not a replacement
+	require.Nil(t, mp.Replaced(newMempoolTestTx(priv1, 1, newStdFee())))
+
+	// the replaced tx is rejected until it is rechecked after the next commit
+	require.NotNil(t, mp.Admit(tx, []byte("hash1"), 20))
+	mp.Reset()
+	require.Nil(t, mp.Replaced(tx))
+	require.NotNil(t, mp.Admit(tx, []byte("hash1"), 20))
+	require.Nil(t, mp.Admit(tx, []byte("hash2"), 11))
+	mp.Reset()
+	require.Nil(t, mp.Admit(tx, []byte("hash1"), 20))
+}
+
+func TestMempoolPolicyMaxPending(t *testing.T) {
+	priv1, _ := privAndAddr()
+	priv2, _ := privAndAddr()
+	mp := NewMempoolPolicy(2)
+
+	require.Nil(t, mp.Admit(newMempoolTestTx(priv1, 0, newStdFee()), []byte("hash1"), 10))
+	require.Nil(t, mp.Admit(newMempoolTestTx(priv1, 1, newStdFee()), []byte("hash2"), 10))
+
+	err := mp.Admit(newMempoolTestTx(priv1, 2, newStdFee()), []byte("hash3"), 10)
+	require.NotNil(t, err)
+	require.Equal(t, sdk.CodeMempoolFull, err.Code())
+
+	// replacements and other accounts are not affected by the limit
+	require.Nil(t, mp.Admit(newMempoolTestTx(priv1, 1, newStdFee()), []byte("hash4"), 20))
+	require.Nil(t, mp.Admit(newMempoolTestTx(priv2, 0, newStdFee()), []byte("hash5"), 10))
+}
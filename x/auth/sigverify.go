@@ -0,0 +1,111 @@
+package auth
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// number of verified tx hashes remembered by an AnteHandler
+const defaultSigCacheSize = 20000
+
+// sigCache remembers the hashes of txs whose signatures were all verified,
+// so that a tx checked in CheckTx isn't verified again in DeliverTx. Once the
+// cache is full the oldest entries are evicted first.
+type sigCache struct {
+	mtx    sync.Mutex
+	hashes map[string]struct{}
+	order  []string // ring buffer of cached hashes in insertion order
+	next   int
+}
+
+func newSigCache(size int) *sigCache {
+	return &sigCache{
+		hashes: make(map[string]struct{}, size),
+		order:  make([]string, size),
+	}
+}
+
+// Has returns true if the signatures of the tx with the given hash were
+// verified.
+func (c *sigCache) Has(txHash []byte) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	_, ok := c.hashes[string(txHash)]
+	return ok
+}
+
+// Add records that the signatures of the tx with the given hash are valid.
+func (c *sigCache) Add(txHash []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	key := string(txHash)
+	if _, ok := c.hashes[key]; ok {
+		return
+	}
+	if evicted := c.order[c.next]; evicted != "" {
+		delete(c.hashes, evicted)
+	}
+	c.order[c.next] = key
+	c.next = (c.next + 1) % len(c.order)
+	c.hashes[key] = struct{}{}
+}
+
+//__________________________________________________________
+
+// sigVerification is a signature over signBytes to verify with pubKey.
+type sigVerification struct {
+	pubKey    crypto.PubKey
+	signBytes []byte
+	signature []byte
+}
+
+// batchVerifySigs verifies the signatures of a tx and returns the index of
+// the first invalid one, or -1 if all are valid. A single signature is
+// verified directly. The signatures of multi-signer txs are verified as a
+// batch, spread over the available CPUs since the ed25519 and secp256k1
+// implementations don't provide algebraic batch verification.
+func batchVerifySigs(sigs []sigVerification) int {
+	switch len(sigs) {
+	case 0:
+		return -1
+	case 1:
+		if !sigs[0].pubKey.VerifyBytes(sigs[0].signBytes, sigs[0].signature) {
+			return 0
+		}
+		return -1
+	}
+
+	workers := runtime.NumCPU()
+	if workers > len(sigs) {
+		workers = len(sigs)
+	}
+
+	valid := make([]bool, len(sigs))
+	jobs := make(chan int, len(sigs))
+	for i := range sigs {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				valid[i] = sigs[i].pubKey.VerifyBytes(sigs[i].signBytes, sigs[i].signature)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// report the lowest index so that the result is deterministic
+	for i, ok := range valid {
+		if !ok {
+			return i
+		}
+	}
+	return -1
+}
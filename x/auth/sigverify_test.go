@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func TestSigCache(t *testing.T) {
+	cache := newSigCache(2)
+	require.False(t, cache.Has([]byte("hash1")))
+
+	cache.Add([]byte("hash1"))
+	cache.Add([]byte("hash2"))
+	cache.Add([]byte("hash2"))
+	require.True(t, cache.Has([]byte("hash1")))
+	require.True(t, cache.Has([]byte("hash2")))
+
+	// the oldest hash is evicted first
+	cache.Add([]byte("hash3"))
+	require.False(t, cache.Has([]byte("hash1")))
+	require.True(t, cache.Has([]byte("hash2")))
+	require.True(t, cache.Has([]byte("hash3")))
+}
+
+func TestBatchVerifySigs(t *testing.T) {
+	privs := []crypto.PrivKey{
+		ed25519.GenPrivKey(), secp256k1.GenPrivKey(), ed25519.GenPrivKey(), secp256k1.GenPrivKey(),
+	}
+	msg := []byte("sign bytes")
+
+	sigs := make([]sigVerification, len(privs))
+	for i, priv := range privs {
+		sig, err := priv.Sign(msg)
+		require.NoError(t, err)
+		sigs[i] = sigVerification{priv.PubKey(), msg, sig}
+	}
+
+	require.Equal(t, -1, batchVerifySigs(nil))
+	require.Equal(t, -1, batchVerifySigs(sigs[:1]))
+	require.Equal(t, -1, batchVerifySigs(sigs))
+
+	// the lowest invalid index is reported
+	sigs[3].signBytes = []byte("other bytes")
+	require.Equal(t, 3, batchVerifySigs(sigs))
+	sigs[1].signature = sigs[0].signature
+	require.Equal(t, 1, batchVerifySigs(sigs))
+	require.Equal(t, 0, batchVerifySigs([]sigVerification{sigs[3]}))
+}
@@ -0,0 +1,30 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the account store and
+// returns it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.HasPrefix(kv.Key, auth.AddressStoreKeyPrefix):
+		var acc auth.Account
+		cdc.MustUnmarshalBinaryBare(kv.Value, &acc)
+		return fmt.Sprintf("%v", acc)
+
+	case bytes.Equal(kv.Key, auth.GlobalAccountNumberKey):
+		var accNumber int64
+		cdc.MustUnmarshalBinary(kv.Value, &accNumber)
+		return fmt.Sprintf("next account number: %d", accNumber)
+
+	default:
+		panic(fmt.Sprintf("invalid account key %X", kv.Key))
+	}
+}
@@ -7,16 +7,101 @@ import (
 	"math/rand"
 
 	"github.com/yukimochizuki/cosmos-sdk/baseapp"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 )
 
+// MsgDeductFee deducts a fee from an account, the state change of
+// SimulateDeductFee. It is only delivered by the simulation, and recorded so
+// that replays of operation logs can deduct the fee again.
+type MsgDeductFee struct {
+	Address sdk.AccAddress `json:"address"`
+	Fee     sdk.Coins      `json:"fee"`
+}
+
+var _ sdk.Msg = MsgDeductFee{}
+
+// Implements Msg
+func (msg MsgDeductFee) Route() string { return "auth" }
+
+// Implements Msg
+func (msg MsgDeductFee) Type() string { return "deduct_fee" }
+
+// Implements Msg
+func (msg MsgDeductFee) ValidateBasic() sdk.Error {
+	if len(msg.Address) == 0 {
+		return sdk.ErrInvalidAddress(msg.Address.String())
+	}
+	if !msg.Fee.IsValid() || !msg.Fee.IsPositive() {
+		return sdk.ErrInvalidCoins(msg.Fee.String())
+	}
+	return nil
+}
+
+// Implements Msg
+func (msg MsgDeductFee) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg
+func (msg MsgDeductFee) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Address}
+}
+
+// RegisterCodec registers the msgs of the auth simulation, for the codecs of
+// operation logs
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgDeductFee{}, "simulation/auth/MsgDeductFee", nil)
+}
+
+var msgCdc = codec.New()
+
+func init() {
+	RegisterCodec(msgCdc)
+}
+
+// NewHandler returns the handler of the auth simulation msgs, which moves the
+// fee of a MsgDeductFee from the account to the collected fees
+func NewHandler(m auth.AccountKeeper, f auth.FeeCollectionKeeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		deductFee, ok := msg.(MsgDeductFee)
+		if !ok {
+			return sdk.ErrUnknownRequest("unrecognized auth simulation msg type").Result()
+		}
+		if err := deductFee.ValidateBasic(); err != nil {
+			return err.Result()
+		}
+
+		acc := m.GetAccount(ctx, deductFee.Address)
+		if acc == nil {
+			return sdk.ErrUnknownAddress(deductFee.Address.String()).Result()
+		}
+		newCoins := acc.GetCoins().Minus(deductFee.Fee)
+		if !newCoins.IsNotNegative() {
+			return sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), deductFee.Fee)).Result()
+		}
+		err := acc.SetCoins(newCoins)
+		if err != nil {
+			panic(err)
+		}
+		m.SetAccount(ctx, acc)
+		f.AddCollectedFees(ctx, deductFee.Fee)
+		return sdk.Result{}
+	}
+}
+
 // SimulateDeductFee
 func SimulateDeductFee(m auth.AccountKeeper, f auth.FeeCollectionKeeper) simulation.Operation {
+	handler := NewHandler(m, f)
 	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 		accs []simulation.Account, event func(string)) (
-		action string, fOp []simulation.FutureOperation, err error) {
+		opMsg simulation.OperationMsg, fOp []simulation.FutureOperation, err error) {
 
 		account := simulation.RandomAcc(r, accs)
 		stored := m.GetAccount(ctx, account.Address)
@@ -24,32 +109,27 @@ func SimulateDeductFee(m auth.AccountKeeper, f auth.FeeCollectionKeeper) simulat
 
 		if len(initCoins) == 0 {
 			event(fmt.Sprintf("auth/SimulateDeductFee/false"))
-			return action, nil, nil
+			return simulation.NewOperationMsgBasic("auth", "deduct_fee", "", false), nil, nil
 		}
 
 		denomIndex := r.Intn(len(initCoins))
 		amt, err := randPositiveInt(r, initCoins[denomIndex].Amount)
 		if err != nil {
 			event(fmt.Sprintf("auth/SimulateDeductFee/false"))
-			return action, nil, nil
+			return simulation.NewOperationMsgBasic("auth", "deduct_fee", "", false), nil, nil
 		}
 
 		coins := sdk.Coins{sdk.NewCoin(initCoins[denomIndex].Denom, amt)}
-		err = stored.SetCoins(initCoins.Minus(coins))
-		if err != nil {
-			panic(err)
+		msg := MsgDeductFee{Address: account.Address, Fee: coins}
+		result := handler(ctx, msg)
+		if !result.IsOK() {
+			panic(result.Log)
 		}
-		m.SetAccount(ctx, stored)
-		if !coins.IsNotNegative() {
-			panic("setting negative fees")
-		}
-
-		f.AddCollectedFees(ctx, coins)
 
 		event(fmt.Sprintf("auth/SimulateDeductFee/true"))
 
-		action = "TestDeductFee"
-		return action, nil, nil
+		opMsg = simulation.NewOperationMsg(msg, true, coins.String())
+		return opMsg, nil, nil
 	}
 }
 
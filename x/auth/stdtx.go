@@ -8,7 +8,7 @@ import (
 	"github.com/tendermint/tendermint/crypto"
 )
 
-var _ sdk.Tx = (*StdTx)(nil)
+var _ sdk.FeeTx = (*StdTx)(nil)
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
 // NOTE: the first signature is the fee payer (Signatures must not be nil).
@@ -53,6 +53,12 @@ func (tx StdTx) GetSigners() []sdk.AccAddress {
 //nolint
 func (tx StdTx) GetMemo() string { return tx.Memo }
 
+// GetFee implements sdk.FeeTx.
+func (tx StdTx) GetFee() sdk.Coins { return tx.Fee.Amount }
+
+// GetGas implements sdk.FeeTx.
+func (tx StdTx) GetGas() int64 { return tx.Fee.Gas }
+
 // Signatures returns the signature of signers who signed the Msg.
 // GetSignatures returns the signature of signers who signed the Msg.
 // CONTRACT: Length returned is same as length of
@@ -80,6 +86,13 @@ func NewStdFee(gas int64, amount ...sdk.Coin) StdFee {
 	}
 }
 
+// Priority returns the fee paid in the given denomination per unit of gas,
+// scaled by sdk.FeePriorityScale. Amounts of different denominations can't be
+// compared, so the others are ignored. It is used to order txs in the mempool.
+func (fee StdFee) Priority(denom string) int64 {
+	return sdk.FeePriority(fee.Amount.AmountOf(denom), fee.Gas)
+}
+
 // fee bytes for signing later
 func (fee StdFee) Bytes() []byte {
 	// normalize. XXX
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"time"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// VestingAccount is an account holding coins which vest over time. The coins
+// which haven't vested yet can't be sent, delegated or paid as fees, the
+// other coins of the account, e.g. the ones it received, can.
+type VestingAccount interface {
+	Account
+
+	// coins which were vesting when the account was created
+	GetOriginalVesting() sdk.Coins
+	// unix times of the start and the end of the vesting period
+	GetStartTime() int64
+	GetEndTime() int64
+
+	// coins which haven't vested yet at the given time
+	GetVestingCoins(blockTime time.Time) sdk.Coins
+}
+
+// SpendableCoins returns the coins of an account which can be spent at the
+// given time, i.e. its coins minus the ones still vesting.
+func SpendableCoins(acc Account, blockTime time.Time) sdk.Coins {
+	vacc, ok := acc.(VestingAccount)
+	if !ok {
+		return acc.GetCoins()
+	}
+
+	vesting := vacc.GetVestingCoins(blockTime)
+	spendable := sdk.Coins{}
+	for _, coin := range acc.GetCoins() {
+		amount := coin.Amount.Sub(vesting.AmountOf(coin.Denom))
+		if amount.Sign() > 0 {
+			spendable = append(spendable, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return spendable
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount vests its original vesting coins linearly between
+// its start and end times.
+type ContinuousVestingAccount struct {
+	BaseAccount
+	OriginalVesting sdk.Coins `json:"original_vesting"`
+	StartTime       int64     `json:"start_time"`
+	EndTime         int64     `json:"end_time"`
+}
+
+func NewContinuousVestingAccount(acc BaseAccount, originalVesting sdk.Coins, startTime, endTime int64) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseAccount:     acc,
+		OriginalVesting: originalVesting,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}
+}
+
+// Implements VestingAccount
+func (acc *ContinuousVestingAccount) GetOriginalVesting() sdk.Coins {
+	return acc.OriginalVesting
+}
+
+// Implements VestingAccount
+func (acc *ContinuousVestingAccount) GetStartTime() int64 {
+	return acc.StartTime
+}
+
+// Implements VestingAccount
+func (acc *ContinuousVestingAccount) GetEndTime() int64 {
+	return acc.EndTime
+}
+
+// Implements VestingAccount
+func (acc *ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	now := blockTime.Unix()
+	switch {
+	case now <= acc.StartTime:
+		return acc.OriginalVesting
+	case now >= acc.EndTime:
+		return nil
+	}
+
+	// the vested amounts are rounded down, so the coins vest no sooner than
+	// the period allows
+	elapsed, period := now-acc.StartTime, acc.EndTime-acc.StartTime
+	vesting := sdk.Coins{}
+	for _, coin := range acc.OriginalVesting {
+		vested := coin.Amount.MulRaw(elapsed).DivRaw(period)
+		if amount := coin.Amount.Sub(vested); amount.Sign() > 0 {
+			vesting = append(vesting, sdk.Coin{Denom: coin.Denom, Amount: amount})
+		}
+	}
+	return vesting
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount vests all its original vesting coins at its end time.
+type DelayedVestingAccount struct {
+	BaseAccount
+	OriginalVesting sdk.Coins `json:"original_vesting"`
+	EndTime         int64     `json:"end_time"`
+}
+
+func NewDelayedVestingAccount(acc BaseAccount, originalVesting sdk.Coins, endTime int64) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseAccount:     acc,
+		OriginalVesting: originalVesting,
+		EndTime:         endTime,
+	}
+}
+
+// Implements VestingAccount
+func (acc *DelayedVestingAccount) GetOriginalVesting() sdk.Coins {
+	return acc.OriginalVesting
+}
+
+// Implements VestingAccount
+func (acc *DelayedVestingAccount) GetStartTime() int64 {
+	return 0
+}
+
+// Implements VestingAccount
+func (acc *DelayedVestingAccount) GetEndTime() int64 {
+	return acc.EndTime
+}
+
+// Implements VestingAccount
+func (acc *DelayedVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	if blockTime.Unix() >= acc.EndTime {
+		return nil
+	}
+	return acc.OriginalVesting
+}
@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	codec "github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestContinuousVestingAccount(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.Coins = sdk.Coins{sdk.NewInt64Coin("fee", 10), sdk.NewInt64Coin("stake", 100)}
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	acc := NewContinuousVestingAccount(bacc, sdk.Coins{sdk.NewInt64Coin("stake", 100)}, start.Unix(), end.Unix())
+
+	// nothing has vested before the start time
+	require.Equal(t, acc.OriginalVesting, acc.GetVestingCoins(start.Add(-time.Hour)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 10)}, SpendableCoins(acc, start))
+
+	// the coins vest linearly, rounded down
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 75)}, acc.GetVestingCoins(start.Add(250*time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 100)}, acc.GetVestingCoins(start.Add(9*time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("fee", 10), sdk.NewInt64Coin("stake", 25)},
+		SpendableCoins(acc, start.Add(250*time.Second)))
+
+	// all the coins have vested at the end time
+	require.True(t, acc.GetVestingCoins(end).IsZero())
+	require.Equal(t, acc.Coins, SpendableCoins(acc, end))
+
+	// the vesting coins which were spent aren't locked twice
+	acc.Coins = sdk.Coins{sdk.NewInt64Coin("stake", 50)}
+	require.Equal(t, sdk.Coins{}, SpendableCoins(acc, start.Add(250*time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 25)}, SpendableCoins(acc, start.Add(750*time.Second)))
+}
+
+func TestDelayedVestingAccount(t *testing.T) {
+	_, _, addr := keyPubAddr()
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.Coins = sdk.Coins{sdk.NewInt64Coin("stake", 100)}
+	end := time.Unix(2000, 0)
+	acc := NewDelayedVestingAccount(bacc, sdk.Coins{sdk.NewInt64Coin("stake", 60)}, end.Unix())
+
+	require.Equal(t, acc.OriginalVesting, acc.GetVestingCoins(end.Add(-time.Second)))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("stake", 40)}, SpendableCoins(acc, end.Add(-time.Second)))
+	require.True(t, acc.GetVestingCoins(end).IsZero())
+	require.Equal(t, acc.Coins, SpendableCoins(acc, end))
+}
+
+func TestVestingAccountMarshal(t *testing.T) {
+	_, pub, addr := keyPubAddr()
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.Coins = sdk.Coins{sdk.NewInt64Coin("stake", 100)}
+	bacc.PubKey = pub
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	for _, acc := range []Account{
+		NewContinuousVestingAccount(bacc, bacc.Coins, 1000, 2000),
+		NewDelayedVestingAccount(bacc, bacc.Coins, 2000),
+	} {
+		bz, err := cdc.MarshalBinaryBare(acc)
+		require.Nil(t, err)
+		var acc2 Account
+		require.Nil(t, cdc.UnmarshalBinaryBare(bz, &acc2))
+		require.Equal(t, acc, acc2)
+	}
+}
@@ -1,55 +1,49 @@
 package cli
 
 import (
-	"io/ioutil"
-	"os"
-
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
-	"github.com/yukimochizuki/cosmos-sdk/x/auth"
+	"github.com/yukimochizuki/cosmos-sdk/client/utils"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	amino "github.com/tendermint/go-amino"
 )
 
-// GetSignCommand returns the sign command
+// GetBroadcastCommand returns the broadcast command
 func GetBroadcastCommand(codec *amino.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "broadcast <file>",
 		Short: "Broadcast transactions generated offline",
 		Long: `Broadcast transactions created with the --generate-only flag and signed with the sign command.
 Read a transaction from <file> and broadcast it to a node. If you supply a dash (-) argument
-in place of an input filename, the command reads from standard input.`,
+in place of an input filename, the command reads from standard input.
+
+If <file> holds a batch of transactions, one per line, as signed by sign --batch,
+they are broadcast in order. Unless --async is set, each transaction is committed
+before the next one is broadcast, and the command stops at the first failure.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) (err error) {
 			cliCtx := context.NewCLIContext().WithCodec(codec)
-			stdTx, err := readAndUnmarshalStdTx(cliCtx.Codec, args[0])
-			if err != nil {
-				return
-			}
-			txBytes, err := cliCtx.Codec.MarshalBinary(stdTx)
+			stdTxs, err := utils.ReadStdTxs(cliCtx.Codec, args[0])
 			if err != nil {
 				return
 			}
 
-			_, err = cliCtx.BroadcastTx(txBytes)
-			return err
+			for i, stdTx := range stdTxs {
+				txBytes, err := cliCtx.Codec.MarshalBinary(stdTx)
+				if err != nil {
+					return err
+				}
+
+				if _, err = cliCtx.BroadcastTx(txBytes); err != nil {
+					if len(stdTxs) == 1 {
+						return err
+					}
+					return errors.Wrapf(err, "transaction %d of %d", i, len(stdTxs))
+				}
+			}
+			return nil
 		},
 	}
 
 	return cmd
 }
-
-func readAndUnmarshalStdTx(cdc *amino.Codec, filename string) (stdTx auth.StdTx, err error) {
-	var bytes []byte
-	if filename == "-" {
-		bytes, err = ioutil.ReadAll(os.Stdin)
-	} else {
-		bytes, err = ioutil.ReadFile(filename)
-	}
-	if err != nil {
-		return
-	}
-	if err = cdc.UnmarshalJSON(bytes, &stdTx); err != nil {
-		return
-	}
-	return
-}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/keys"
+	"github.com/yukimochizuki/cosmos-sdk/client/utils"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	authcmd "github.com/yukimochizuki/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/yukimochizuki/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/yukimochizuki/cosmos-sdk/x/bank/client"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const flagMaxRetries = "max-retries"
+
+// transfer is a row of a send-batch file
+type transfer struct {
+	row   int
+	to    sdk.AccAddress
+	coins sdk.Coins
+}
+
+// SendBatchTxCmd sends the transfers listed in a CSV file, one tx each.
+func SendBatchTxCmd(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "send-batch <file>",
+		Short: "Send the transfers listed in a CSV file",
+		Long: `Send a tx for each row of a CSV file of recipients and amounts, e.g.
+
+    cosmos1g9ahr6xhht5rmqven628nklxluzyv8z9jqjcmc,10steak
+    cosmos1jawd35d9aq4u76sr3fjalmcqc8hqygs90d0g0v,5steak,2photino
+
+Lines starting with # are ignored. If you supply a dash (-) argument in place of
+an input filename, the command reads from standard input.
+
+The txs are broadcast without waiting for them to be committed. Their sequences
+are reserved locally, and a tx whose sequence is rejected, e.g. because another
+client sent a tx from the same account, is signed again and rebroadcast.
+
+With --generate-only, the unsigned txs are printed one per line instead, to be
+signed offline with sign --batch and broadcast with broadcast.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc)).
+				WithSequenceManager()
+
+			transfers, err := readTransfers(args[0])
+			if err != nil {
+				return err
+			}
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			account, err := cliCtx.GetAccount(from)
+			if err != nil {
+				return err
+			}
+			if account == nil {
+				return context.ErrInvalidAccount(from)
+			}
+
+			// ensure account has enough coins for all the transfers
+			total := sdk.Coins{}
+			for _, t := range transfers {
+				total = total.Plus(t.coins)
+			}
+			if !account.GetCoins().IsGTE(total) {
+				return errors.Errorf("Address %s doesn't have enough coins to send %s.", from, total)
+			}
+
+			name, err := cliCtx.GetFromName()
+			if err != nil {
+				return err
+			}
+			txBldr = txBldr.WithAccountNumber(account.GetAccountNumber())
+			if txBldr.SimulateGas {
+				// all the txs hold a single send, estimate the gas once
+				msgs := []sdk.Msg{client.CreateMsg(from, transfers[0].to, transfers[0].coins)}
+				txBldr, err = utils.EnrichCtxWithGas(txBldr.WithSequence(account.GetSequence()), cliCtx, name, msgs)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "estimated gas = %v\n", txBldr.Gas)
+				txBldr.SimulateGas = false
+			}
+
+			if cliCtx.GenerateOnly {
+				// the unsigned txs are signed with consecutive sequences by
+				// sign --batch
+				for _, t := range transfers {
+					msgs := []sdk.Msg{client.CreateMsg(from, t.to, t.coins)}
+					if err := utils.PrintUnsignedStdTx(txBldr, cliCtx, msgs, true); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			passphrase, err := keys.GetPassphrase(name)
+			if err != nil {
+				return err
+			}
+
+			maxRetries := viper.GetInt(flagMaxRetries)
+			failed := 0
+			for _, t := range transfers {
+				msgs := []sdk.Msg{client.CreateMsg(from, t.to, t.coins)}
+				res, err := utils.BroadcastTxWithRetry(txBldr, cliCtx, name, passphrase, msgs, maxRetries)
+				if err != nil {
+					failed++
+					fmt.Fprintf(cliCtx.Output, "row %d: failed to send %s to %s: %v\n", t.row, t.coins, t.to, err)
+					continue
+				}
+				fmt.Fprintf(cliCtx.Output, "row %d: sent %s to %s (tx hash: %s)\n", t.row, t.coins, t.to, res.Hash)
+			}
+
+			if failed > 0 {
+				return errors.Errorf("%d of %d transfers failed", failed, len(transfers))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int(flagMaxRetries, 5, "Number of times a tx rejected for its sequence or a full mempool is sent again")
+
+	return cmd
+}
+
+// readTransfers reads and validates all the rows of a send-batch file.
+func readTransfers(filename string) ([]transfer, error) {
+	var in io.Reader = os.Stdin
+	if filename != "-" {
+		f, err := os.Open(filename)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+	return parseTransfers(in)
+}
+
+// parseTransfers reads and validates all the rows of a send-batch input.
+func parseTransfers(in io.Reader) ([]transfer, error) {
+	var transfers []transfer
+	scanner := bufio.NewScanner(in)
+	for row := 1; scanner.Scan(); row++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.SplitN(line, ",", 2)
+		if len(fields) != 2 {
+			return nil, errors.Errorf("row %d: expected a recipient and an amount", row)
+		}
+
+		to, err := sdk.AccAddressFromBech32(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "row %d", row)
+		}
+		// amounts of several denominations are comma separated too
+		coins, err := sdk.ParseCoins(strings.Replace(fields[1], " ", "", -1))
+		if err != nil {
+			return nil, errors.Wrapf(err, "row %d", row)
+		}
+		if !coins.IsPositive() {
+			return nil, errors.Errorf("row %d: amount must be positive", row)
+		}
+		transfers = append(transfers, transfer{row: row, to: to, coins: coins})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(transfers) == 0 {
+		return nil, errors.New("no transfer to send")
+	}
+	return transfers, nil
+}
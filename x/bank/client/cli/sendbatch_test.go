@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestParseTransfers(t *testing.T) {
+	addr1 := sdk.AccAddress([]byte("addr1_______________"))
+	addr2 := sdk.AccAddress([]byte("addr2_______________"))
+
+	cases := []struct {
+		name      string
+		input     string
+		transfers []transfer
+		err       string
+	}{
+		{"empty input", "", nil, "no transfer to send"},
+		{"only comments", "# recipient,amount\n\n", nil, "no transfer to send"},
+		{"missing amount", addr1.String() + "\n", nil, "row 1: expected a recipient and an amount"},
+		{"bad address", "cosmos1nope,10steak\n", nil, "row 1"},
+		{"bad amount", addr1.String() + ",ten steak\n", nil, "row 1"},
+		{"empty amount", addr1.String() + ",\n", nil, "row 1: amount must be positive"},
+		{"zero amount", addr1.String() + ",0steak\n", nil, "row 1"},
+		{"malformed row after valid ones", addr1.String() + ",10steak\n" + addr2.String() + ",\n", nil, "row 2"},
+		{
+			"batch",
+			"# payroll\n" + addr1.String() + ",10steak\n\n" + addr2.String() + ", 5steak, 2photino\n",
+			[]transfer{
+				{row: 2, to: addr1, coins: sdk.Coins{sdk.NewInt64Coin("steak", 10)}},
+				{row: 4, to: addr2, coins: sdk.Coins{sdk.NewInt64Coin("photino", 2), sdk.NewInt64Coin("steak", 5)}},
+			},
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		transfers, err := parseTransfers(strings.NewReader(tc.input))
+		if tc.err != "" {
+			require.Error(t, err, tc.name)
+			require.Contains(t, err.Error(), tc.err, tc.name)
+			continue
+		}
+		require.NoError(t, err, tc.name)
+		require.Equal(t, tc.transfers, transfers, tc.name)
+	}
+}
+
+func TestReadTransfers(t *testing.T) {
+	addr := sdk.AccAddress([]byte("addr1_______________"))
+
+	_, err := readTransfers("/nonexistent/transfers.csv")
+	require.Error(t, err)
+
+	f, err := ioutil.TempFile("", "send_batch_")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(addr.String() + ",10steak\n" + addr.String() + ",20steak\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	transfers, err := readTransfers(f.Name())
+	require.NoError(t, err)
+	require.Len(t, transfers, 2)
+	require.Equal(t, 2, transfers[1].row)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("steak", 20)}, transfers[1].coins)
+}
@@ -11,7 +11,8 @@ import (
 )
 
 type broadcastBody struct {
-	Tx auth.StdTx `json:"tx"`
+	Tx            auth.StdTx `json:"tx"`
+	BroadcastMode string     `json:"broadcast_mode"`
 }
 
 // BroadcastTxRequestHandlerFn returns the broadcast tx REST handler
@@ -22,12 +23,17 @@ func BroadcastTxRequestHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) ht
 			return
 		}
 
+		if err := utils.ValidateBroadcastMode(m.BroadcastMode); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		txBytes, err := cliCtx.Codec.MarshalBinary(m.Tx)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
-		res, err := cliCtx.BroadcastTx(txBytes)
+		res, err := utils.BroadcastTx(cliCtx, m.BroadcastMode, txBytes)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
@@ -12,12 +13,26 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/bank/client"
 
 	"github.com/gorilla/mux"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
-	r.HandleFunc("/bank/accounts/{address}/transfers", SendRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
-	r.HandleFunc("/tx/broadcast", BroadcastTxRequestHandlerFn(cdc, cliCtx)).Methods("POST")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec, kb keys.Keybase) {
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/bank/accounts/{address}/transfers",
+		Summary:  "Send coins to an address",
+		Query:    utils.TxQueryParams,
+		Request:  sendReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, SendRequestHandlerFn(cdc, kb, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/tx/broadcast",
+		Summary:  "Broadcast a signed StdTx",
+		Request:  broadcastBody{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, BroadcastTxRequestHandlerFn(cdc, cliCtx))
 }
 
 type sendReq struct {
@@ -196,11 +196,17 @@ func hasCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt s
 // SubtractCoins subtracts amt from the coins at the addr.
 func subtractCoins(ctx sdk.Context, am auth.AccountKeeper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
-	oldCoins := getCoins(ctx, am, addr)
-	newCoins := oldCoins.Minus(amt)
-	if !newCoins.IsNotNegative() {
-		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+	ctx.GasMeter().ConsumeGas(costGetCoins, "getCoins")
+	oldCoins, spendableCoins := sdk.Coins{}, sdk.Coins{}
+	if acc := am.GetAccount(ctx, addr); acc != nil {
+		oldCoins = acc.GetCoins()
+		spendableCoins = auth.SpendableCoins(acc, ctx.BlockHeader().Time)
+	}
+	// the coins which are still vesting can't be subtracted
+	if !spendableCoins.Minus(amt).IsNotNegative() {
+		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", spendableCoins, amt))
 	}
+	newCoins := oldCoins.Minus(amt)
 	err := setCoins(ctx, am, addr, newCoins)
 	tags := sdk.NewTags("sender", []byte(addr.String()))
 	return newCoins, tags, err
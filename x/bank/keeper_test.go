@@ -2,6 +2,7 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -111,6 +112,47 @@ func TestKeeper(t *testing.T) {
 
 }
 
+func TestKeeperVestingAccount(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	accountKeeper := auth.NewAccountKeeper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountKeeper)
+
+	addr := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+	bacc := auth.NewBaseAccountWithAddress(addr)
+	bacc.Coins = sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}
+	vacc := auth.NewDelayedVestingAccount(bacc, sdk.Coins{sdk.NewInt64Coin("foocoin", 60)}, 2000)
+
+	ctx := sdk.NewContext(ms, abci.Header{Time: time.Unix(1000, 0)}, false, log.NewNopLogger())
+	accountKeeper.SetAccount(ctx, vacc)
+
+	// the vesting coins are held but can't be sent
+	require.True(t, bankKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 100)}))
+	_, err := bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 41)})
+	require.NotNil(t, err)
+	_, _, err = bankKeeper.SubtractCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 41)})
+	require.NotNil(t, err)
+	_, err = bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 40)})
+	require.Nil(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, addr).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 60)}))
+
+	// the received coins can be sent
+	bankKeeper.AddCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 5)})
+	_, err = bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 5)})
+	require.Nil(t, err)
+
+	// all the coins can be sent once vested
+	ctx = ctx.WithBlockHeader(abci.Header{Time: time.Unix(2000, 0)})
+	_, err = bankKeeper.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.NewInt64Coin("foocoin", 60)})
+	require.Nil(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(sdk.Coins{sdk.NewInt64Coin("foocoin", 105)}))
+}
+
 func TestSendKeeper(t *testing.T) {
 	ms, authKey := setupMultiStore()
 
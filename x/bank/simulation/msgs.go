@@ -18,18 +18,18 @@ import (
 // SingleInputSendTx tests and runs a single msg send w/ auth, with one input and one output, where both
 // accounts already exist.
 func SingleInputSendTx(mapper auth.AccountKeeper) simulation.Operation {
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOps []simulation.FutureOperation, err error) {
-		fromAcc, action, msg, abort := createSingleInputSendMsg(r, ctx, accs, mapper)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+		fromAcc, comment, msg, abort := createSingleInputSendMsg(r, ctx, accs, mapper)
 		if abort {
-			return action, nil, nil
+			return simulation.NewOperationMsgBasic("bank", "no-operation", comment, false), nil, nil
 		}
-		err = sendAndVerifyMsgSend(app, mapper, msg, ctx, []crypto.PrivKey{fromAcc.PrivKey}, nil)
+		tx, err := sendAndVerifyMsgSend(app, mapper, msg, ctx, []crypto.PrivKey{fromAcc.PrivKey}, nil)
 		if err != nil {
-			return "", nil, err
+			return simulation.NoOpMsg("bank"), nil, err
 		}
 		event("bank/sendAndVerifyTxSend/ok")
 
-		return action, nil, nil
+		return simulation.NewOperationMsgTx(tx, true, comment), nil, nil
 	}
 }
 
@@ -37,22 +37,22 @@ func SingleInputSendTx(mapper auth.AccountKeeper) simulation.Operation {
 // accounts already exist.
 func SingleInputSendMsg(mapper auth.AccountKeeper, bk bank.Keeper) simulation.Operation {
 	handler := bank.NewHandler(bk)
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOps []simulation.FutureOperation, err error) {
-		fromAcc, action, msg, abort := createSingleInputSendMsg(r, ctx, accs, mapper)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+		fromAcc, comment, msg, abort := createSingleInputSendMsg(r, ctx, accs, mapper)
 		if abort {
-			return action, nil, nil
+			return simulation.NewOperationMsgBasic("bank", "no-operation", comment, false), nil, nil
 		}
-		err = sendAndVerifyMsgSend(app, mapper, msg, ctx, []crypto.PrivKey{fromAcc.PrivKey}, handler)
+		_, err = sendAndVerifyMsgSend(app, mapper, msg, ctx, []crypto.PrivKey{fromAcc.PrivKey}, handler)
 		if err != nil {
-			return "", nil, err
+			return simulation.NoOpMsg("bank"), nil, err
 		}
 		event("bank/sendAndVerifyMsgSend/ok")
 
-		return action, nil, nil
+		return simulation.NewOperationMsg(msg, true, comment), nil, nil
 	}
 }
 
-func createSingleInputSendMsg(r *rand.Rand, ctx sdk.Context, accs []simulation.Account, mapper auth.AccountKeeper) (fromAcc simulation.Account, action string, msg bank.MsgSend, abort bool) {
+func createSingleInputSendMsg(r *rand.Rand, ctx sdk.Context, accs []simulation.Account, mapper auth.AccountKeeper) (fromAcc simulation.Account, comment string, msg bank.MsgSend, abort bool) {
 	fromAcc = simulation.RandomAcc(r, accs)
 	toAcc := simulation.RandomAcc(r, accs)
 	// Disallow sending money to yourself
@@ -75,7 +75,7 @@ func createSingleInputSendMsg(r *rand.Rand, ctx sdk.Context, accs []simulation.A
 		return fromAcc, "skipping bank send due to account having no coins of denomination " + initFromCoins[denomIndex].Denom, msg, true
 	}
 
-	action = fmt.Sprintf("%s is sending %s %s to %s",
+	comment = fmt.Sprintf("%s is sending %s %s to %s",
 		fromAcc.Address.String(),
 		amt.String(),
 		initFromCoins[denomIndex].Denom,
@@ -91,8 +91,8 @@ func createSingleInputSendMsg(r *rand.Rand, ctx sdk.Context, accs []simulation.A
 }
 
 // Sends and verifies the transition of a msg send. This fails if there are repeated inputs or outputs
-// pass in handler as nil to handle txs, otherwise handle msgs
-func sendAndVerifyMsgSend(app *baseapp.BaseApp, mapper auth.AccountKeeper, msg bank.MsgSend, ctx sdk.Context, privkeys []crypto.PrivKey, handler sdk.Handler) error {
+// pass in handler as nil to handle txs, otherwise handle msgs. The delivered tx is returned when handling txs.
+func sendAndVerifyMsgSend(app *baseapp.BaseApp, mapper auth.AccountKeeper, msg bank.MsgSend, ctx sdk.Context, privkeys []crypto.PrivKey, handler sdk.Handler) (tx sdk.Tx, err error) {
 	initialInputAddrCoins := make([]sdk.Coins, len(msg.Inputs))
 	initialOutputAddrCoins := make([]sdk.Coins, len(msg.Outputs))
 	AccountNumbers := make([]int64, len(msg.Inputs))
@@ -112,33 +112,33 @@ func sendAndVerifyMsgSend(app *baseapp.BaseApp, mapper auth.AccountKeeper, msg b
 		res := handler(ctx, msg)
 		if !res.IsOK() {
 			// TODO: Do this in a more 'canonical' way
-			return fmt.Errorf("handling msg failed %v", res)
+			return nil, fmt.Errorf("handling msg failed %v", res)
 		}
 	} else {
-		tx := mock.GenTx([]sdk.Msg{msg},
+		tx = mock.GenTx([]sdk.Msg{msg},
 			AccountNumbers,
 			SequenceNumbers,
 			privkeys...)
 		res := app.Deliver(tx)
 		if !res.IsOK() {
 			// TODO: Do this in a more 'canonical' way
-			return fmt.Errorf("Deliver failed %v", res)
+			return nil, fmt.Errorf("Deliver failed %v", res)
 		}
 	}
 
 	for i := 0; i < len(msg.Inputs); i++ {
 		terminalInputCoins := mapper.GetAccount(ctx, msg.Inputs[i].Address).GetCoins()
 		if !initialInputAddrCoins[i].Minus(msg.Inputs[i].Coins).IsEqual(terminalInputCoins) {
-			return fmt.Errorf("input #%d had an incorrect amount of coins", i)
+			return nil, fmt.Errorf("input #%d had an incorrect amount of coins", i)
 		}
 	}
 	for i := 0; i < len(msg.Outputs); i++ {
 		terminalOutputCoins := mapper.GetAccount(ctx, msg.Outputs[i].Address).GetCoins()
 		if !terminalOutputCoins.IsEqual(initialOutputAddrCoins[i].Plus(msg.Outputs[i].Coins)) {
-			return fmt.Errorf("output #%d had an incorrect amount of coins", i)
+			return nil, fmt.Errorf("output #%d had an incorrect amount of coins", i)
 		}
 	}
-	return nil
+	return tx, nil
 }
 
 func randPositiveInt(r *rand.Rand, max sdk.Int) (sdk.Int, error) {
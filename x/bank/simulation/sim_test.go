@@ -1,10 +1,14 @@
 package simulation
 
 import (
+	"bytes"
 	"encoding/json"
 	"math/rand"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/bank"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock"
@@ -44,3 +48,58 @@ func TestBankWithRandomMessages(t *testing.T) {
 		false,
 	)
 }
+
+func TestBankReplayOperationLog(t *testing.T) {
+	newApp := func() (*mock.App, bank.Keeper) {
+		mapp := mock.NewApp()
+		bank.RegisterCodec(mapp.Cdc)
+		bankKeeper := bank.NewBaseKeeper(mapp.AccountKeeper)
+		mapp.Router().AddRoute("bank", bank.NewHandler(bankKeeper))
+		require.Nil(t, mapp.CompleteSetup())
+		return mapp, bankKeeper
+	}
+	mapp, bankKeeper := newApp()
+
+	appStateFn := func(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+		simulation.RandomSetGenesis(r, mapp, accs, []string{"stake"})
+		return json.RawMessage("{}")
+	}
+
+	var buf bytes.Buffer
+	err := simulation.SimulateFromSeedWithOperationLog(
+		t, mapp.BaseApp, appStateFn, 7,
+		[]simulation.WeightedOperation{
+			{1, SingleInputSendTx(mapp.AccountKeeper)},
+			{1, SingleInputSendMsg(mapp.AccountKeeper, bankKeeper)},
+		},
+		[]simulation.RandSetup{},
+		[]simulation.Invariant{NonnegativeBalanceInvariant(mapp.AccountKeeper)},
+		10, 20,
+		true,
+		simulation.NewOperationLog(mapp.Cdc, &buf),
+	)
+	require.Nil(t, err)
+	entries, err := simulation.ReadOperationLog(&buf)
+	require.Nil(t, err)
+
+	// the sends with auth are recorded as signed txs
+	txs := 0
+	for _, entry := range entries {
+		if entry.Tx != nil {
+			txs++
+		}
+	}
+	require.True(t, txs > 0, "no tx recorded")
+
+	// the genesis accounts of the mock app aren't part of the app state
+	replayed, replayedBankKeeper := newApp()
+	replayed.GenesisAccounts = mapp.GenesisAccounts
+	err = simulation.ReplayOperationLog(replayed.BaseApp, replayed.Cdc, entries,
+		simulation.MsgDeliverer(bank.NewHandler(replayedBankKeeper)), []simulation.Invariant{NonnegativeBalanceInvariant(replayed.AccountKeeper)})
+	require.Nil(t, err)
+
+	// the coins and the sequences of the accounts match the simulation's
+	ctx := mapp.BaseApp.NewContext(true, abci.Header{})
+	replayedCtx := replayed.BaseApp.NewContext(true, abci.Header{})
+	require.Equal(t, mock.GetAllAccounts(mapp.AccountKeeper, ctx), mock.GetAllAccounts(replayed.AccountKeeper, replayedCtx))
+}
@@ -16,6 +16,7 @@ type (
 	ValidatorDistInfo     = types.ValidatorDistInfo
 	TotalAccum            = types.TotalAccum
 	FeePool               = types.FeePool
+	DecCoins              = types.DecCoins
 
 	MsgSetWithdrawAddress          = types.MsgSetWithdrawAddress
 	MsgWithdrawDelegatorRewardsAll = types.MsgWithdrawDelegatorRewardsAll
@@ -33,6 +34,8 @@ type (
 var (
 	NewKeeper = keeper.NewKeeper
 
+	NewDecCoins = types.NewDecCoins
+
 	GetValidatorDistInfoKey     = keeper.GetValidatorDistInfoKey
 	GetDelegationDistInfoKey    = keeper.GetDelegationDistInfoKey
 	GetDelegationDistInfosKey   = keeper.GetDelegationDistInfosKey
@@ -1,6 +1,8 @@
 package distribution
 
 import (
+	"fmt"
+
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/distribution/types"
 )
@@ -36,3 +38,22 @@ func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	return NewGenesisState(feePool, communityTax, baseProposerRewards,
 		bonusProposerRewards, vdis, ddis, dwis)
 }
+
+// ValidateGenesis validates the provided distribution genesis state to ensure
+// the expected invariants holds (i.e. reward fractions in correct bounds)
+func ValidateGenesis(data types.GenesisState) error {
+	if data.CommunityTax.LT(sdk.ZeroDec()) || data.CommunityTax.GT(sdk.OneDec()) {
+		return fmt.Errorf("distribution parameter CommunityTax should be between zero and one, is %s", data.CommunityTax.String())
+	}
+	if data.BaseProposerReward.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("distribution parameter BaseProposerReward can't be negative, is %s", data.BaseProposerReward.String())
+	}
+	if data.BonusProposerReward.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("distribution parameter BonusProposerReward can't be negative, is %s", data.BonusProposerReward.String())
+	}
+	if data.BaseProposerReward.Add(data.BonusProposerReward).GT(sdk.OneDec()) {
+		return fmt.Errorf("distribution parameters BaseProposerReward and BonusProposerReward can't sum to more than one, are %s and %s",
+			data.BaseProposerReward.String(), data.BonusProposerReward.String())
+	}
+	return nil
+}
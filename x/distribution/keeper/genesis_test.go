@@ -0,0 +1,27 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAllDelegatorWithdrawInfos(t *testing.T) {
+	ctx, _, keeper, _, _ := CreateTestInputDefault(t, false, 100)
+	require.Empty(t, keeper.GetAllDelegatorWithdrawInfos(ctx))
+
+	keeper.SetDelegatorWithdrawAddr(ctx, delAddr1, delAddr2)
+	keeper.SetDelegatorWithdrawAddr(ctx, delAddr2, delAddr3)
+	dwis := keeper.GetAllDelegatorWithdrawInfos(ctx)
+	require.Len(t, dwis, 2)
+	for _, dwi := range dwis {
+		require.Equal(t, keeper.GetDelegatorWithdrawAddr(ctx, dwi.DelegatorAddr), dwi.WithdrawAddr)
+	}
+
+	// the exported infos import into the same withdraw addresses
+	ctx2, _, keeper2, _, _ := CreateTestInputDefault(t, false, 100)
+	for _, dwi := range dwis {
+		keeper2.SetDelegatorWithdrawAddr(ctx2, dwi.DelegatorAddr, dwi.WithdrawAddr)
+	}
+	require.Equal(t, dwis, keeper2.GetAllDelegatorWithdrawInfos(ctx2))
+}
@@ -0,0 +1,13 @@
+package distribution
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// RegisterGauges registers the gauges of the fee pool
+func RegisterGauges(registry sdk.GaugeRegistry, k Keeper, sk StakeKeeper) {
+	registry.RegisterGauge("distr", "community_pool", "Tokens of the bond denomination in the community pool.",
+		func(ctx sdk.Context) float64 {
+			return k.GetFeePool(ctx).CommunityPool.AmountOf(sk.BondDenom(ctx)).Float64()
+		})
+}
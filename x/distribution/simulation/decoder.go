@@ -0,0 +1,44 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	distr "github.com/yukimochizuki/cosmos-sdk/x/distribution"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the distribution store and
+// returns it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kv.Key, distr.FeePoolKey):
+		var feePool distr.FeePool
+		cdc.MustUnmarshalBinary(kv.Value, &feePool)
+		return fmt.Sprintf("%v", feePool)
+
+	case bytes.HasPrefix(kv.Key, distr.ValidatorDistInfoKey):
+		var vdi distr.ValidatorDistInfo
+		cdc.MustUnmarshalBinary(kv.Value, &vdi)
+		return fmt.Sprintf("%v", vdi)
+
+	case bytes.HasPrefix(kv.Key, distr.DelegationDistInfoKey):
+		var ddi distr.DelegationDistInfo
+		cdc.MustUnmarshalBinary(kv.Value, &ddi)
+		return fmt.Sprintf("%v", ddi)
+
+	case bytes.HasPrefix(kv.Key, distr.DelegatorWithdrawInfoKey):
+		return fmt.Sprintf("withdraw address: %s", sdk.AccAddress(kv.Value))
+
+	case bytes.Equal(kv.Key, distr.ProposerKey):
+		var consAddr sdk.ConsAddress
+		cdc.MustUnmarshalBinary(kv.Value, &consAddr)
+		return fmt.Sprintf("previous proposer: %s", consAddr)
+
+	default:
+		panic(fmt.Sprintf("invalid distribution key %X", kv.Key))
+	}
+}
@@ -0,0 +1,17 @@
+package simulation
+
+import (
+	"math/rand"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+)
+
+// RandomParams returns a random community tax and proposer rewards, which
+// never add up to more than the collected fees
+func RandomParams(r *rand.Rand) (communityTax, baseProposerReward, bonusProposerReward sdk.Dec) {
+	communityTax = simulation.RandDecBetween(r, 0, 50, 2)
+	baseProposerReward = simulation.RandDecBetween(r, 0, 10, 2)
+	bonusProposerReward = simulation.RandDecBetween(r, 0, 20, 2)
+	return
+}
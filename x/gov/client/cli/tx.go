@@ -258,16 +258,7 @@ func GetCmdQueryProposal(queryRoute string, cdc *codec.Codec) *cobra.Command {
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
 			proposalID := viper.GetInt64(flagProposalID)
 
-			params := gov.QueryProposalParams{
-				ProposalID: proposalID,
-			}
-
-			bz, err := cdc.MarshalJSON(params)
-			if err != nil {
-				return err
-			}
-
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/proposal", queryRoute), bz)
+			res, err := client.QueryProposalByID(proposalID, cliCtx, cdc, queryRoute)
 			if err != nil {
 				return err
 			}
@@ -375,16 +366,7 @@ func GetCmdQueryVote(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			params := gov.QueryVoteParams{
-				Voter:      voterAddr,
-				ProposalID: proposalID,
-			}
-			bz, err := cdc.MarshalJSON(params)
-			if err != nil {
-				return err
-			}
-
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/vote", queryRoute), bz)
+			res, err := client.QueryVoteByVoter(proposalID, voterAddr, cliCtx, cdc, queryRoute)
 			if err != nil {
 				return err
 			}
@@ -447,16 +429,7 @@ func GetCmdQueryDeposit(queryRoute string, cdc *codec.Codec) *cobra.Command {
 				return err
 			}
 
-			params := gov.QueryDepositParams{
-				Depositer:  depositerAddr,
-				ProposalID: proposalID,
-			}
-			bz, err := cdc.MarshalJSON(params)
-			if err != nil {
-				return err
-			}
-
-			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/deposit", queryRoute), bz)
+			res, err := client.QueryDepositByDepositer(proposalID, depositerAddr, cliCtx, cdc, queryRoute)
 			if err != nil {
 				return err
 			}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
@@ -13,6 +14,7 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/gov/client"
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // REST Variable names
@@ -27,17 +29,74 @@ const (
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
-	r.HandleFunc("/gov/proposals", postProposalHandlerFn(cdc, cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), depositHandlerFn(cdc, cliCtx)).Methods("POST")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), voteHandlerFn(cdc, cliCtx)).Methods("POST")
-
-	r.HandleFunc("/gov/proposals", queryProposalsWithParameterFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}", RestProposalID), queryProposalHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID), queryDepositsHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer), queryDepositHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID), queryVotesOnProposalHandlerFn(cdc, cliCtx)).Methods("GET")
-	r.HandleFunc(fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter), queryVoteHandlerFn(cdc, cliCtx)).Methods("GET")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec) {
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/gov/proposals",
+		Summary:  "Submit a proposal",
+		Query:    utils.TxQueryParams,
+		Request:  postProposalReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, postProposalHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID),
+		Summary:  "Deposit coins on a proposal",
+		Query:    utils.TxQueryParams,
+		Request:  depositReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, depositHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID),
+		Summary:  "Vote on a proposal",
+		Query:    utils.TxQueryParams,
+		Request:  voteReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, voteHandlerFn(cdc, cliCtx))
+
+	r.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/gov/proposals",
+		Summary: "Query proposals",
+		Query: []openapi.Param{
+			{Name: RestVoter, Description: "Only the proposals voted on by this address"},
+			{Name: RestDepositer, Description: "Only the proposals deposited on by this address"},
+			{Name: RestProposalStatus, Description: "Only the proposals with this status"},
+			{Name: RestNumLatest, Type: "integer", Description: "Only the latest proposals"},
+		},
+		Response: []gov.Proposal{},
+	}, queryProposalsWithParameterFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}", RestProposalID),
+		Summary:  "Query a proposal",
+		Response: (*gov.Proposal)(nil),
+	}, queryProposalHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/deposits", RestProposalID),
+		Summary:  "Query the deposits on a proposal",
+		Response: []gov.Deposit{},
+	}, queryDepositsHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/deposits/{%s}", RestProposalID, RestDepositer),
+		Summary:  "Query the deposit of an address on a proposal",
+		Response: gov.Deposit{},
+	}, queryDepositHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/votes", RestProposalID),
+		Summary:  "Query the votes on a proposal",
+		Response: []gov.Vote{},
+	}, queryVotesOnProposalHandlerFn(cdc, cliCtx))
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/gov/proposals/{%s}/votes/{%s}", RestProposalID, RestVoter),
+		Summary:  "Query the vote of an address on a proposal",
+		Response: gov.Vote{},
+	}, queryVoteHandlerFn(cdc, cliCtx))
 }
 
 type postProposalReq struct {
@@ -66,7 +125,6 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 		var req postProposalReq
 		err := utils.ReadRESTReq(w, r, cdc, &req)
 		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
@@ -193,17 +251,7 @@ func queryProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Ha
 			return
 		}
 
-		params := gov.QueryProposalParams{
-			ProposalID: proposalID,
-		}
-
-		bz, err := cdc.MarshalJSON(params)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		res, err := cliCtx.QueryWithData("custom/gov/proposal", bz)
+		res, err := client.QueryProposalByID(proposalID, cliCtx, cdc, storeName)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -272,18 +320,7 @@ func queryDepositHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 			return
 		}
 
-		params := gov.QueryDepositParams{
-			ProposalID: proposalID,
-			Depositer:  depositerAddr,
-		}
-
-		bz, err := cdc.MarshalJSON(params)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		res, err := cliCtx.QueryWithData("custom/gov/deposit", bz)
+		res, err := client.QueryDepositByDepositer(proposalID, depositerAddr, cliCtx, cdc, storeName)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -292,7 +329,7 @@ func queryDepositHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 		var deposit gov.Deposit
 		cdc.UnmarshalJSON(res, &deposit)
 		if deposit.Empty() {
-			res, err := cliCtx.QueryWithData("custom/gov/proposal", cdc.MustMarshalBinary(gov.QueryProposalParams{params.ProposalID}))
+			res, err := client.QueryProposalByID(proposalID, cliCtx, cdc, storeName)
 			if err != nil || len(res) == 0 {
 				err := errors.Errorf("proposalID [%d] does not exist", proposalID)
 				utils.WriteErrorResponse(w, http.StatusNotFound, err.Error())
@@ -336,17 +373,7 @@ func queryVoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handle
 			return
 		}
 
-		params := gov.QueryVoteParams{
-			Voter:      voterAddr,
-			ProposalID: proposalID,
-		}
-		bz, err := cdc.MarshalJSON(params)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		res, err := cliCtx.QueryWithData("custom/gov/vote", bz)
+		res, err := client.QueryVoteByVoter(proposalID, voterAddr, cliCtx, cdc, storeName)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -355,12 +382,7 @@ func queryVoteHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Handle
 		var vote gov.Vote
 		cdc.UnmarshalJSON(res, &vote)
 		if vote.Empty() {
-			bz, err := cdc.MarshalJSON(gov.QueryProposalParams{params.ProposalID})
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			res, err := cliCtx.QueryWithData("custom/gov/proposal", bz)
+			res, err := client.QueryProposalByID(proposalID, cliCtx, cdc, storeName)
 			if err != nil || len(res) == 0 {
 				err := errors.Errorf("proposalID [%d] does not exist", proposalID)
 				utils.WriteErrorResponse(w, http.StatusNotFound, err.Error())
@@ -477,9 +499,8 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		strProposalID := vars[RestProposalID]
 
 		if len(strProposalID) == 0 {
-			w.WriteHeader(http.StatusBadRequest)
 			err := errors.New("proposalId required but not specified")
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 
 			return
 		}
@@ -494,15 +515,13 @@ func queryTallyOnProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext)
 		}
 		bz, err := cdc.MarshalJSON(params)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
 		res, err := cliCtx.QueryWithData("custom/gov/tally", bz)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(err.Error()))
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
 		}
 
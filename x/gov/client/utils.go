@@ -1,5 +1,14 @@
 package client
 
+import (
+	"fmt"
+
+	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+)
+
 // NormalizeVoteOption - normalize user specified vote option
 func NormalizeVoteOption(option string) string {
 	switch option {
@@ -42,3 +51,80 @@ func NormalizeProposalStatus(status string) string {
 	}
 	return ""
 }
+
+// QueryProposalByID queries a proposal and returns it as JSON, like the
+// custom/gov/proposal query. If the node isn't trusted, the proposal is read
+// from the store with a proof instead, as the querier response can't be
+// verified. The gov store must be mounted under the name of its query route.
+func QueryProposalByID(proposalID int64, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	if cliCtx.TrustNode {
+		bz, err := cdc.MarshalJSON(gov.QueryProposalParams{ProposalID: proposalID})
+		if err != nil {
+			return nil, err
+		}
+		return cliCtx.QueryWithData(fmt.Sprintf("custom/%s/proposal", queryRoute), bz)
+	}
+
+	res, err := cliCtx.QueryStore(gov.KeyProposal(proposalID), queryRoute)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, gov.ErrUnknownProposal(gov.DefaultCodespace, proposalID)
+	}
+	var proposal gov.Proposal
+	if err := cdc.UnmarshalBinary(res, &proposal); err != nil {
+		return nil, err
+	}
+	return codec.MarshalJSONIndent(cdc, proposal)
+}
+
+// QueryDepositByDepositer queries a deposit and returns it as JSON, like the
+// custom/gov/deposit query, reading it with a proof if the node isn't trusted.
+// An empty deposit is returned if there is none.
+func QueryDepositByDepositer(proposalID int64, depositer sdk.AccAddress, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	if cliCtx.TrustNode {
+		bz, err := cdc.MarshalJSON(gov.QueryDepositParams{ProposalID: proposalID, Depositer: depositer})
+		if err != nil {
+			return nil, err
+		}
+		return cliCtx.QueryWithData(fmt.Sprintf("custom/%s/deposit", queryRoute), bz)
+	}
+
+	res, err := cliCtx.QueryStore(gov.KeyDeposit(proposalID, depositer), queryRoute)
+	if err != nil {
+		return nil, err
+	}
+	var deposit gov.Deposit
+	if len(res) != 0 {
+		if err := cdc.UnmarshalBinary(res, &deposit); err != nil {
+			return nil, err
+		}
+	}
+	return codec.MarshalJSONIndent(cdc, deposit)
+}
+
+// QueryVoteByVoter queries a vote and returns it as JSON, like the
+// custom/gov/vote query, reading it with a proof if the node isn't trusted.
+// An empty vote is returned if there is none.
+func QueryVoteByVoter(proposalID int64, voter sdk.AccAddress, cliCtx context.CLIContext, cdc *codec.Codec, queryRoute string) ([]byte, error) {
+	if cliCtx.TrustNode {
+		bz, err := cdc.MarshalJSON(gov.QueryVoteParams{ProposalID: proposalID, Voter: voter})
+		if err != nil {
+			return nil, err
+		}
+		return cliCtx.QueryWithData(fmt.Sprintf("custom/%s/vote", queryRoute), bz)
+	}
+
+	res, err := cliCtx.QueryStore(gov.KeyVote(proposalID, voter), queryRoute)
+	if err != nil {
+		return nil, err
+	}
+	var vote gov.Vote
+	if len(res) != 0 {
+		if err := cdc.UnmarshalBinary(res, &vote); err != nil {
+			return nil, err
+		}
+	}
+	return codec.MarshalJSONIndent(cdc, vote)
+}
@@ -42,9 +42,17 @@ func TestTickExpiredDepositPeriod(t *testing.T) {
 
 	require.NotNil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.True(t, shouldPopInactiveProposalQueue(ctx, keeper))
+	proposalID := keeper.GetLastProposalID(ctx)
+	_, found := keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.True(t, found)
 	EndBlocker(ctx, keeper)
 	require.Nil(t, keeper.InactiveProposalQueuePeek(ctx))
 	require.False(t, shouldPopInactiveProposalQueue(ctx, keeper))
+
+	// the deposits of the dropped proposal are deleted with it
+	require.Nil(t, keeper.GetProposal(ctx, proposalID))
+	_, found = keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.False(t, found)
 }
 
 func TestTickMultipleExpiredDepositPeriod(t *testing.T) {
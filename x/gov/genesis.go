@@ -1,6 +1,7 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
@@ -56,7 +57,7 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 
 // WriteGenesis - output genesis parameters
 func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
-	startingProposalID, _ := k.getNewProposalID(ctx)
+	startingProposalID, _ := k.peekCurrentProposalID(ctx)
 	depositProcedure := k.GetDepositProcedure(ctx)
 	votingProcedure := k.GetVotingProcedure(ctx)
 	tallyingProcedure := k.GetTallyingProcedure(ctx)
@@ -68,3 +69,30 @@ func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		TallyingProcedure:  tallyingProcedure,
 	}
 }
+
+// ValidateGenesis validates the provided governance genesis state to ensure
+// the expected invariants holds (i.e. procedures in correct bounds)
+func ValidateGenesis(data GenesisState) error {
+	threshold := data.TallyingProcedure.Threshold
+	if !threshold.GT(sdk.ZeroDec()) || threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance vote threshold should be positive and less or equal to one, is %s", threshold.String())
+	}
+	veto := data.TallyingProcedure.Veto
+	if !veto.GT(sdk.ZeroDec()) || veto.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance vote veto threshold should be positive and less or equal to one, is %s", veto.String())
+	}
+	penalty := data.TallyingProcedure.GovernancePenalty
+	if penalty.LT(sdk.ZeroDec()) || penalty.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance penalty should be between zero and one, is %s", penalty.String())
+	}
+	if !data.DepositProcedure.MinDeposit.IsValid() {
+		return fmt.Errorf("governance deposit amount must be a valid sdk.Coins amount, is %s", data.DepositProcedure.MinDeposit.String())
+	}
+	if data.DepositProcedure.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("governance deposit period should be positive, is %s", data.DepositProcedure.MaxDepositPeriod)
+	}
+	if data.VotingProcedure.VotingPeriod <= 0 {
+		return fmt.Errorf("governance voting period should be positive, is %s", data.VotingProcedure.VotingPeriod)
+	}
+	return nil
+}
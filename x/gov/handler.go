@@ -111,6 +111,7 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 
 		proposalIDBytes := keeper.cdc.MustMarshalBinaryBare(inactiveProposal.GetProposalID())
 		keeper.DeleteProposal(ctx, inactiveProposal)
+		keeper.DeleteDeposits(ctx, inactiveProposal.GetProposalID())
 		resTags.AppendTag(tags.Action, tags.ActionProposalDropped)
 		resTags.AppendTag(tags.ProposalID, proposalIDBytes)
 
@@ -2,6 +2,7 @@ package gov
 
 import (
 	codec "github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/store"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/bank"
 	"github.com/yukimochizuki/cosmos-sdk/x/params"
@@ -73,6 +74,36 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, paramsKeeper params.Keeper, p
 	}
 }
 
+// =====================================================
+// Collections
+
+// proposals by proposalID
+func (keeper Keeper) proposals(ctx sdk.Context) store.Map {
+	return store.NewMap(keeper.cdc, ctx.KVStore(keeper.storeKey).Prefix(PrefixProposals), proposalKeyCodec)
+}
+
+// deposits by (proposalID, depositer), indexed by depositer
+func (keeper Keeper) deposits(ctx sdk.Context) store.IndexedMap {
+	return store.NewIndexedMap(keeper.cdc, ctx.KVStore(keeper.storeKey).Prefix(PrefixDeposits),
+		depositKeyCodec,
+		func() interface{} { return &Deposit{} },
+		store.NewIndex(store.BytesKey, func(value interface{}) interface{} { return value.(*Deposit).Depositer }),
+	)
+}
+
+// votes by (proposalID, voter), indexed by voter
+func (keeper Keeper) votes(ctx sdk.Context) store.IndexedMap {
+	return store.NewIndexedMap(keeper.cdc, ctx.KVStore(keeper.storeKey).Prefix(PrefixVotes),
+		voteKeyCodec,
+		func() interface{} { return &Vote{} },
+		store.NewIndex(store.BytesKey, func(value interface{}) interface{} { return value.(*Vote).Voter }),
+	)
+}
+
+func (keeper Keeper) proposalIDSequence(ctx sdk.Context) store.Sequence {
+	return store.NewSequence(keeper.cdc, ctx.KVStore(keeper.storeKey), KeyNextProposalID)
+}
+
 // =====================================================
 // Proposals
 
@@ -99,32 +130,26 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyProposal(proposalID))
-	if bz == nil {
+	var proposal Proposal
+	if !keeper.proposals(ctx).Get(proposalID, &proposal) {
 		return nil
 	}
-
-	var proposal Proposal
-	keeper.cdc.MustUnmarshalBinary(bz, &proposal)
-
 	return proposal
 }
 
 // Implements sdk.AccountKeeper.
 func (keeper Keeper) SetProposal(ctx sdk.Context, proposal Proposal) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(proposal)
-	store.Set(KeyProposal(proposal.GetProposalID()), bz)
+	keeper.proposals(ctx).Set(proposal.GetProposalID(), proposal)
 }
 
 // Implements sdk.AccountKeeper.
 func (keeper Keeper) DeleteProposal(ctx sdk.Context, proposal Proposal) {
-	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyProposal(proposal.GetProposalID()))
+	keeper.proposals(ctx).Delete(proposal.GetProposalID())
 }
 
-// Get Proposal from store by ProposalID
+// Get the latest proposals, filtered by voter, depositer and status. The
+// voter and depositer filters are served by the indexes of the votes and
+// deposits.
 func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddress, depositerAddr sdk.AccAddress, status ProposalStatus, numLatest int64) []Proposal {
 
 	maxProposalID, err := keeper.peekCurrentProposalID(ctx)
@@ -132,21 +157,26 @@ func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddr
 		return nil
 	}
 
-	matchingProposals := []Proposal{}
-
 	if numLatest <= 0 {
 		numLatest = maxProposalID
 	}
-
-	for proposalID := maxProposalID - numLatest; proposalID < maxProposalID; proposalID++ {
-		if voterAddr != nil && len(voterAddr) != 0 {
-			_, found := keeper.GetVote(ctx, proposalID, voterAddr)
-			if !found {
-				continue
-			}
+	minProposalID := maxProposalID - numLatest
+
+	var proposalIDs []int64
+	switch {
+	case len(voterAddr) != 0:
+		proposalIDs = proposalIDsByAddress(keeper.votes(ctx), voterAddr, minProposalID)
+	case len(depositerAddr) != 0:
+		proposalIDs = proposalIDsByAddress(keeper.deposits(ctx), depositerAddr, minProposalID)
+	default:
+		for proposalID := minProposalID; proposalID < maxProposalID; proposalID++ {
+			proposalIDs = append(proposalIDs, proposalID)
 		}
+	}
 
-		if depositerAddr != nil && len(depositerAddr) != 0 {
+	matchingProposals := []Proposal{}
+	for _, proposalID := range proposalIDs {
+		if len(voterAddr) != 0 && len(depositerAddr) != 0 {
 			_, found := keeper.GetDeposit(ctx, proposalID, depositerAddr)
 			if !found {
 				continue
@@ -169,14 +199,27 @@ func (keeper Keeper) GetProposalsFiltered(ctx sdk.Context, voterAddr sdk.AccAddr
 	return matchingProposals
 }
 
+// proposalIDsByAddress returns the IDs, starting from minProposalID, of the
+// proposals voted or deposited on by addr, from the address index of the
+// votes or deposits.
+func proposalIDsByAddress(m store.IndexedMap, addr sdk.AccAddress, minProposalID int64) (proposalIDs []int64) {
+	iter := m.IterateIndex(0, store.NewPrefixRange(store.BytesKey.EncodeKey(addr)))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		proposalID := iter.DecodePrimaryKey().(store.Pair).K1.(int64)
+		if proposalID >= minProposalID {
+			proposalIDs = append(proposalIDs, proposalID)
+		}
+	}
+	return proposalIDs
+}
+
 func (keeper Keeper) setInitialProposalID(ctx sdk.Context, proposalID int64) sdk.Error {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyNextProposalID)
-	if bz != nil {
+	seq := keeper.proposalIDSequence(ctx)
+	if seq.Has() {
 		return ErrInvalidGenesis(keeper.codespace, "Initial ProposalID already set")
 	}
-	bz = keeper.cdc.MustMarshalBinary(proposalID)
-	store.Set(KeyNextProposalID, bz)
+	seq.Set(uint64(proposalID))
 	return nil
 }
 
@@ -192,26 +235,20 @@ func (keeper Keeper) GetLastProposalID(ctx sdk.Context) (proposalID int64) {
 
 // Gets the next available ProposalID and increments it
 func (keeper Keeper) getNewProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyNextProposalID)
-	if bz == nil {
+	seq := keeper.proposalIDSequence(ctx)
+	if !seq.Has() {
 		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
 	}
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
-	bz = keeper.cdc.MustMarshalBinary(proposalID + 1)
-	store.Set(KeyNextProposalID, bz)
-	return proposalID, nil
+	return int64(seq.Next()), nil
 }
 
 // Peeks the next available ProposalID without incrementing it
 func (keeper Keeper) peekCurrentProposalID(ctx sdk.Context) (proposalID int64, err sdk.Error) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyNextProposalID)
-	if bz == nil {
+	seq := keeper.proposalIDSequence(ctx)
+	if !seq.Has() {
 		return -1, ErrInvalidGenesis(keeper.codespace, "InitialProposalID never set")
 	}
-	keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
-	return proposalID, nil
+	return int64(seq.Peek()), nil
 }
 
 func (keeper Keeper) activateVotingPeriod(ctx sdk.Context, proposal Proposal) {
@@ -292,31 +329,22 @@ func (keeper Keeper) AddVote(ctx sdk.Context, proposalID int64, voterAddr sdk.Ac
 
 // Gets the vote of a specific voter on a specific proposal
 func (keeper Keeper) GetVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) (Vote, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyVote(proposalID, voterAddr))
-	if bz == nil {
-		return Vote{}, false
-	}
 	var vote Vote
-	keeper.cdc.MustUnmarshalBinary(bz, &vote)
-	return vote, true
+	found := keeper.votes(ctx).Get(store.Pair{K1: proposalID, K2: voterAddr}, &vote)
+	return vote, found
 }
 
 func (keeper Keeper) setVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress, vote Vote) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(vote)
-	store.Set(KeyVote(proposalID, voterAddr), bz)
+	keeper.votes(ctx).Set(store.Pair{K1: proposalID, K2: voterAddr}, vote)
 }
 
 // Gets all the votes on a specific proposal
 func (keeper Keeper) GetVotes(ctx sdk.Context, proposalID int64) sdk.Iterator {
-	store := ctx.KVStore(keeper.storeKey)
-	return sdk.KVStorePrefixIterator(store, KeyVotesSubspace(proposalID))
+	return keeper.votes(ctx).Iterate(store.NewPrefixRange(store.Int64Key.EncodeKey(proposalID)))
 }
 
 func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk.AccAddress) {
-	store := ctx.KVStore(keeper.storeKey)
-	store.Delete(KeyVote(proposalID, voterAddr))
+	keeper.votes(ctx).Delete(store.Pair{K1: proposalID, K2: voterAddr})
 }
 
 // =====================================================
@@ -324,20 +352,13 @@ func (keeper Keeper) deleteVote(ctx sdk.Context, proposalID int64, voterAddr sdk
 
 // Gets the deposit of a specific depositer on a specific proposal
 func (keeper Keeper) GetDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.AccAddress) (Deposit, bool) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := store.Get(KeyDeposit(proposalID, depositerAddr))
-	if bz == nil {
-		return Deposit{}, false
-	}
 	var deposit Deposit
-	keeper.cdc.MustUnmarshalBinary(bz, &deposit)
-	return deposit, true
+	found := keeper.deposits(ctx).Get(store.Pair{K1: proposalID, K2: depositerAddr}, &deposit)
+	return deposit, found
 }
 
 func (keeper Keeper) setDeposit(ctx sdk.Context, proposalID int64, depositerAddr sdk.AccAddress, deposit Deposit) {
-	store := ctx.KVStore(keeper.storeKey)
-	bz := keeper.cdc.MustMarshalBinary(deposit)
-	store.Set(KeyDeposit(proposalID, depositerAddr), bz)
+	keeper.deposits(ctx).Set(store.Pair{K1: proposalID, K2: depositerAddr}, deposit)
 }
 
 // Adds or updates a deposit of a specific depositer on a specific proposal
@@ -387,13 +408,11 @@ func (keeper Keeper) AddDeposit(ctx sdk.Context, proposalID int64, depositerAddr
 
 // Gets all the deposits on a specific proposal
 func (keeper Keeper) GetDeposits(ctx sdk.Context, proposalID int64) sdk.Iterator {
-	store := ctx.KVStore(keeper.storeKey)
-	return sdk.KVStorePrefixIterator(store, KeyDepositsSubspace(proposalID))
+	return keeper.deposits(ctx).Iterate(store.NewPrefixRange(store.Int64Key.EncodeKey(proposalID)))
 }
 
 // Returns and deletes all the deposits on a specific proposal
 func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
 	depositsIterator := keeper.GetDeposits(ctx, proposalID)
 
 	for ; depositsIterator.Valid(); depositsIterator.Next() {
@@ -404,23 +423,15 @@ func (keeper Keeper) RefundDeposits(ctx sdk.Context, proposalID int64) {
 		if err != nil {
 			panic("should not happen")
 		}
-
-		store.Delete(depositsIterator.Key())
 	}
 
 	depositsIterator.Close()
+	keeper.DeleteDeposits(ctx, proposalID)
 }
 
 // Deletes all the deposits on a specific proposal without refunding them
 func (keeper Keeper) DeleteDeposits(ctx sdk.Context, proposalID int64) {
-	store := ctx.KVStore(keeper.storeKey)
-	depositsIterator := keeper.GetDeposits(ctx, proposalID)
-
-	for ; depositsIterator.Valid(); depositsIterator.Next() {
-		store.Delete(depositsIterator.Key())
-	}
-
-	depositsIterator.Close()
+	keeper.deposits(ctx).DeleteRange(store.NewPrefixRange(store.Int64Key.EncodeKey(proposalID)))
 }
 
 // =====================================================
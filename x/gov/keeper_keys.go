@@ -1,8 +1,7 @@
 package gov
 
 import (
-	"fmt"
-
+	"github.com/yukimochizuki/cosmos-sdk/store"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 )
 
@@ -10,32 +9,50 @@ import (
 
 // Key for getting a the next available proposalID from the store
 var (
-	KeyNextProposalID        = []byte("newProposalID")
+	KeyNextProposalID        = []byte{0x03}
 	KeyActiveProposalQueue   = []byte("activeProposalQueue")
 	KeyInactiveProposalQueue = []byte("inactiveProposalQueue")
 )
 
-// Key for getting a specific proposal from the store
+// Prefixes of the proposals, deposits and votes collections
+var (
+	PrefixProposals = []byte{0x00}
+	PrefixDeposits  = []byte{0x01}
+	PrefixVotes     = []byte{0x02}
+)
+
+// Keys of the store layout preceding the collections, moved by MigrateStore
+var (
+	legacyKeyNextProposalID = []byte("newProposalID")
+	legacyPrefixProposals   = []byte("proposals:")
+	legacyPrefixDeposits    = []byte("deposits:")
+	legacyPrefixVotes       = []byte("votes:")
+)
+
+// Key codecs of the proposals, deposits and votes collections
+var (
+	proposalKeyCodec = store.Int64Key
+	depositKeyCodec  = store.PairKey(store.Int64Key, store.BytesKey)
+	voteKeyCodec     = store.PairKey(store.Int64Key, store.BytesKey)
+)
+
+// KeyProposal returns the store key of a proposal, for raw store queries
 func KeyProposal(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("proposals:%d", proposalID))
+	return append(copyBytes(PrefixProposals), proposalKeyCodec.EncodeKey(proposalID)...)
 }
 
-// Key for getting a specific deposit from the store
+// KeyDeposit returns the store key of a deposit, for raw store queries
 func KeyDeposit(proposalID int64, depositerAddr sdk.AccAddress) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:%d", proposalID, depositerAddr))
+	key := store.Pair{K1: proposalID, K2: depositerAddr}
+	return append(copyBytes(PrefixDeposits), store.IndexedMapKey(depositKeyCodec, key)...)
 }
 
-// Key for getting a specific vote from the store
+// KeyVote returns the store key of a vote, for raw store queries
 func KeyVote(proposalID int64, voterAddr sdk.AccAddress) []byte {
-	return []byte(fmt.Sprintf("votes:%d:%d", proposalID, voterAddr))
-}
-
-// Key for getting all deposits on a proposal from the store
-func KeyDepositsSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("deposits:%d:", proposalID))
+	key := store.Pair{K1: proposalID, K2: voterAddr}
+	return append(copyBytes(PrefixVotes), store.IndexedMapKey(voteKeyCodec, key)...)
 }
 
-// Key for getting all votes on a proposal from the store
-func KeyVotesSubspace(proposalID int64) []byte {
-	return []byte(fmt.Sprintf("votes:%d:", proposalID))
+func copyBytes(bz []byte) []byte {
+	return append([]byte{}, bz...)
 }
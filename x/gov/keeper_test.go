@@ -201,6 +201,54 @@ func TestVotes(t *testing.T) {
 	votesIterator.Close()
 }
 
+func TestGetProposalsFiltered(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	var proposalIDs []int64
+	for i := 0; i < 3; i++ {
+		proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+		proposal.SetStatus(StatusVotingPeriod)
+		keeper.SetProposal(ctx, proposal)
+		proposalIDs = append(proposalIDs, proposal.GetProposalID())
+	}
+
+	keeper.AddVote(ctx, proposalIDs[0], addrs[0], OptionYes)
+	keeper.AddVote(ctx, proposalIDs[2], addrs[0], OptionNo)
+	keeper.AddVote(ctx, proposalIDs[1], addrs[1], OptionYes)
+	keeper.AddDeposit(ctx, proposalIDs[2], addrs[1], sdk.Coins{sdk.NewInt64Coin("steak", 1)})
+
+	proposals := keeper.GetProposalsFiltered(ctx, nil, nil, StatusNil, 0)
+	require.Len(t, proposals, 3)
+
+	proposals = keeper.GetProposalsFiltered(ctx, addrs[0], nil, StatusNil, 0)
+	require.Len(t, proposals, 2)
+	require.Equal(t, proposalIDs[0], proposals[0].GetProposalID())
+	require.Equal(t, proposalIDs[2], proposals[1].GetProposalID())
+
+	// the latest proposal only
+	proposals = keeper.GetProposalsFiltered(ctx, addrs[0], nil, StatusNil, 1)
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposalIDs[2], proposals[0].GetProposalID())
+
+	proposals = keeper.GetProposalsFiltered(ctx, nil, addrs[1], StatusNil, 0)
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposalIDs[2], proposals[0].GetProposalID())
+
+	proposals = keeper.GetProposalsFiltered(ctx, addrs[0], addrs[1], StatusNil, 0)
+	require.Len(t, proposals, 1)
+	require.Equal(t, proposalIDs[2], proposals[0].GetProposalID())
+
+	proposals = keeper.GetProposalsFiltered(ctx, addrs[1], addrs[1], StatusNil, 0)
+	require.Len(t, proposals, 0)
+
+	// changing a vote keeps a single index entry
+	keeper.AddVote(ctx, proposalIDs[0], addrs[0], OptionNo)
+	proposals = keeper.GetProposalsFiltered(ctx, addrs[0], nil, StatusNil, 0)
+	require.Len(t, proposals, 2)
+}
+
 func TestProposalQueues(t *testing.T) {
 	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
 	mapp.BeginBlock(abci.RequestBeginBlock{})
@@ -248,3 +296,38 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
 	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
 }
+
+func TestRawStoreKeys(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	store := ctx.KVStore(keeper.storeKey)
+
+	proposal := keeper.NewTextProposal(ctx, "Test", "description", ProposalTypeText)
+	proposalID := proposal.GetProposalID()
+	err, _ := keeper.AddDeposit(ctx, proposalID, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 4)})
+	require.Nil(t, err)
+	proposal = keeper.GetProposal(ctx, proposalID)
+	proposal.SetStatus(StatusVotingPeriod)
+	keeper.SetProposal(ctx, proposal)
+	require.Nil(t, keeper.AddVote(ctx, proposalID, addrs[0], OptionYes))
+
+	// clients read the raw keys to prove them
+	var gotProposal Proposal
+	keeper.cdc.MustUnmarshalBinary(store.Get(KeyProposal(proposalID)), &gotProposal)
+	require.True(t, ProposalEqual(proposal, gotProposal))
+
+	var deposit Deposit
+	keeper.cdc.MustUnmarshalBinary(store.Get(KeyDeposit(proposalID, addrs[0])), &deposit)
+	expDeposit, _ := keeper.GetDeposit(ctx, proposalID, addrs[0])
+	require.Equal(t, expDeposit, deposit)
+
+	var vote Vote
+	keeper.cdc.MustUnmarshalBinary(store.Get(KeyVote(proposalID, addrs[0])), &vote)
+	expVote, _ := keeper.GetVote(ctx, proposalID, addrs[0])
+	require.Equal(t, expVote, vote)
+
+	require.Nil(t, store.Get(KeyProposal(proposalID+1)))
+	require.Nil(t, store.Get(KeyDeposit(proposalID, addrs[1])))
+	require.Nil(t, store.Get(KeyVote(proposalID, addrs[1])))
+}
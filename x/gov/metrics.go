@@ -0,0 +1,11 @@
+package gov
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// RegisterGauges registers the gauges of the proposals
+func RegisterGauges(registry sdk.GaugeRegistry, keeper Keeper) {
+	registry.RegisterGauge("gov", "active_proposals", "Number of proposals in their voting period.",
+		func(ctx sdk.Context) float64 { return float64(len(keeper.getActiveProposalQueue(ctx))) })
+}
@@ -0,0 +1,53 @@
+package gov
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// MigrateStore moves the proposals, deposits and votes stored under the keys
+// of the previous versions into their collections, and the next proposal ID,
+// which was encoded as an int64, into its sequence. Chains upgrading in place
+// must run it once before the first block processed by the new version, e.g.
+// in the BeginBlocker of the upgrade height. It does nothing on a store which
+// was already migrated or created by the new version.
+func MigrateStore(ctx sdk.Context, keeper Keeper) {
+	store := ctx.KVStore(keeper.storeKey)
+
+	if bz := store.Get(legacyKeyNextProposalID); bz != nil {
+		var proposalID int64
+		keeper.cdc.MustUnmarshalBinary(bz, &proposalID)
+		keeper.proposalIDSequence(ctx).Set(uint64(proposalID))
+		store.Delete(legacyKeyNextProposalID)
+	}
+
+	migrateLegacySubspace(store, legacyPrefixProposals, func(bz []byte) {
+		var proposal Proposal
+		keeper.cdc.MustUnmarshalBinary(bz, &proposal)
+		keeper.SetProposal(ctx, proposal)
+	})
+	migrateLegacySubspace(store, legacyPrefixDeposits, func(bz []byte) {
+		var deposit Deposit
+		keeper.cdc.MustUnmarshalBinary(bz, &deposit)
+		keeper.setDeposit(ctx, deposit.ProposalID, deposit.Depositer, deposit)
+	})
+	migrateLegacySubspace(store, legacyPrefixVotes, func(bz []byte) {
+		var vote Vote
+		keeper.cdc.MustUnmarshalBinary(bz, &vote)
+		keeper.setVote(ctx, vote.ProposalID, vote.Voter, vote)
+	})
+}
+
+// deletes all the values stored under prefix and passes them to migrate
+func migrateLegacySubspace(store sdk.KVStore, prefix []byte, migrate func(bz []byte)) {
+	var values [][]byte
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		values = append(values, iter.Value())
+	}
+	iter.Close()
+
+	store.DeleteRange(prefix, sdk.PrefixEndBytes(prefix))
+	for _, bz := range values {
+		migrate(bz)
+	}
+}
@@ -0,0 +1,66 @@
+package gov
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+func TestMigrateStore(t *testing.T) {
+	mapp, keeper, _, addrs, _, _ := getMockApp(t, 2)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// write the proposals, deposits and votes as the previous versions did
+	store := ctx.KVStore(keeper.storeKey)
+	store.Delete(KeyNextProposalID)
+	store.Set(legacyKeyNextProposalID, keeper.cdc.MustMarshalBinary(int64(3)))
+
+	proposals := []Proposal{
+		&TextProposal{ProposalID: 1, Title: "Test", Status: StatusVotingPeriod, TallyResult: EmptyTallyResult()},
+		&TextProposal{ProposalID: 2, Title: "Test", Status: StatusDepositPeriod, TallyResult: EmptyTallyResult()},
+	}
+	for _, proposal := range proposals {
+		key := fmt.Sprintf("proposals:%d", proposal.GetProposalID())
+		store.Set([]byte(key), keeper.cdc.MustMarshalBinary(proposal))
+	}
+	deposit := Deposit{addrs[0], 2, sdk.Coins{sdk.NewInt64Coin("steak", 5)}}
+	store.Set([]byte(fmt.Sprintf("deposits:%d:%d", deposit.ProposalID, deposit.Depositer)),
+		keeper.cdc.MustMarshalBinary(deposit))
+	vote := Vote{addrs[1], 1, OptionYes}
+	store.Set([]byte(fmt.Sprintf("votes:%d:%d", vote.ProposalID, vote.Voter)),
+		keeper.cdc.MustMarshalBinary(vote))
+
+	MigrateStore(ctx, keeper)
+
+	require.Equal(t, int64(2), keeper.GetLastProposalID(ctx))
+	for _, proposal := range proposals {
+		require.True(t, ProposalEqual(proposal, keeper.GetProposal(ctx, proposal.GetProposalID())))
+	}
+	gotDeposit, found := keeper.GetDeposit(ctx, 2, addrs[0])
+	require.True(t, found)
+	require.Equal(t, deposit, gotDeposit)
+	gotVote, found := keeper.GetVote(ctx, 1, addrs[1])
+	require.True(t, found)
+	require.Equal(t, vote, gotVote)
+
+	// the indexes are built along
+	require.Len(t, keeper.GetProposalsFiltered(ctx, addrs[1], nil, StatusNil, 0), 1)
+	require.Len(t, keeper.GetProposalsFiltered(ctx, nil, addrs[0], StatusNil, 0), 1)
+
+	// no legacy key is left, and migrating again changes nothing
+	for _, prefix := range [][]byte{legacyPrefixProposals, legacyPrefixDeposits, legacyPrefixVotes} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		require.False(t, iter.Valid())
+		iter.Close()
+	}
+	require.False(t, store.Has(legacyKeyNextProposalID))
+	MigrateStore(ctx, keeper)
+	require.Equal(t, int64(2), keeper.GetLastProposalID(ctx))
+	require.True(t, ProposalEqual(proposals[0], keeper.GetProposal(ctx, 1)))
+}
@@ -129,6 +129,7 @@ func queryDeposits(ctx sdk.Context, path []string, req abci.RequestQuery, keeper
 		keeper.cdc.MustUnmarshalBinary(depositsIterator.Value(), &deposit)
 		deposits = append(deposits, deposit)
 	}
+	depositsIterator.Close()
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, deposits)
 	if err2 != nil {
@@ -158,6 +159,7 @@ func queryVotes(ctx sdk.Context, path []string, req abci.RequestQuery, keeper Ke
 		keeper.cdc.MustUnmarshalBinary(votesIterator.Value(), &vote)
 		votes = append(votes, vote)
 	}
+	votesIterator.Close()
 
 	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, votes)
 	if err2 != nil {
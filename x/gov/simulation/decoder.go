@@ -0,0 +1,58 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the governance store and
+// returns it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kv.Key, gov.KeyNextProposalID):
+		var proposalID uint64
+		cdc.MustUnmarshalBinary(kv.Value, &proposalID)
+		return fmt.Sprintf("next proposal ID: %d", proposalID)
+
+	case bytes.Equal(kv.Key, gov.KeyActiveProposalQueue),
+		bytes.Equal(kv.Key, gov.KeyInactiveProposalQueue):
+		var proposalQueue gov.ProposalQueue
+		cdc.MustUnmarshalBinary(kv.Value, &proposalQueue)
+		return fmt.Sprintf("%v", proposalQueue)
+
+	case bytes.HasPrefix(kv.Key, gov.PrefixProposals):
+		var proposal gov.Proposal
+		cdc.MustUnmarshalBinary(kv.Value, &proposal)
+		return fmt.Sprintf("%v", proposal)
+
+	case bytes.HasPrefix(kv.Key, gov.PrefixDeposits):
+		if isIndexEntry(kv.Key, gov.PrefixDeposits) {
+			return "deposit index entry"
+		}
+		var deposit gov.Deposit
+		cdc.MustUnmarshalBinary(kv.Value, &deposit)
+		return fmt.Sprintf("%v", deposit)
+
+	case bytes.HasPrefix(kv.Key, gov.PrefixVotes):
+		if isIndexEntry(kv.Key, gov.PrefixVotes) {
+			return "vote index entry"
+		}
+		var vote gov.Vote
+		cdc.MustUnmarshalBinary(kv.Value, &vote)
+		return fmt.Sprintf("%v", vote)
+
+	default:
+		panic(fmt.Sprintf("invalid governance key %X", kv.Key))
+	}
+}
+
+// the values of an indexed map are stored under 0x00 after its prefix,
+// everything else is an entry of one of its indexes
+func isIndexEntry(key, prefix []byte) bool {
+	return len(key) > len(prefix) && key[len(prefix)] != 0x00
+}
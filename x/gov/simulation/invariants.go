@@ -1,16 +1,58 @@
 package simulation
 
 import (
+	"fmt"
+
 	"github.com/yukimochizuki/cosmos-sdk/baseapp"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 // AllInvariants tests all governance invariants
-func AllInvariants() simulation.Invariant {
-	return func(app *baseapp.BaseApp, _ abci.Header) error {
-		// TODO Add some invariants!
-		// Checking proposal queues, no passed-but-unexecuted proposals, etc.
+// Currently: deposits
+func AllInvariants(k gov.Keeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp, header abci.Header) error {
+		// TODO Check the proposal queues, no passed-but-unexecuted proposals, etc.
+		return DepositsInvariant(k)(app, header)
+	}
+}
+
+// DepositsInvariant checks that the deposits held by every proposal which
+// is still open equal the sum of its deposit records, and that there are no
+// deposit records left for the closed or deleted proposals
+func DepositsInvariant(k gov.Keeper) simulation.Invariant {
+	cdc := codec.New()
+	return func(app *baseapp.BaseApp, header abci.Header) error {
+		ctx := app.NewContext(false, header)
+
+		for proposalID := int64(0); proposalID <= k.GetLastProposalID(ctx); proposalID++ {
+			deposits := sdk.Coins{}
+			iter := k.GetDeposits(ctx, proposalID)
+			for ; iter.Valid(); iter.Next() {
+				var deposit gov.Deposit
+				cdc.MustUnmarshalBinary(iter.Value(), &deposit)
+				deposits = deposits.Plus(deposit.Amount)
+			}
+			iter.Close()
+
+			proposal := k.GetProposal(ctx, proposalID)
+			if proposal == nil ||
+				(proposal.GetStatus() != gov.StatusDepositPeriod && proposal.GetStatus() != gov.StatusVotingPeriod) {
+				if !deposits.IsZero() {
+					return fmt.Errorf("deposits invariance:\n\tproposal %d is closed"+
+						"\n\tsum of deposits: %v", proposalID, deposits)
+				}
+				continue
+			}
+
+			if !proposal.GetTotalDeposit().IsEqual(deposits) {
+				return fmt.Errorf("deposits invariance:\n\tproposal %d total deposit: %v"+
+					"\n\tsum of deposits: %v", proposalID, proposal.GetTotalDeposit(), deposits)
+			}
+		}
 		return nil
 	}
 }
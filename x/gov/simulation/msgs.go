@@ -23,7 +23,7 @@ const (
 // TODO: Vote more intelligently, so we can actually do some checks regarding votes passing or failing
 // TODO: Actually check that validator slashings happened
 func SimulateSubmittingVotingAndSlashingForProposal(k gov.Keeper, sk stake.Keeper) simulation.Operation {
-	handler := gov.NewHandler(k)
+	handler := NewHandler(k, sk)
 	// The states are:
 	// column 1: All validators vote
 	// column 2: 90% vote
@@ -43,17 +43,18 @@ func SimulateSubmittingVotingAndSlashingForProposal(k gov.Keeper, sk stake.Keepe
 	})
 	statePercentageArray := []float64{1, .9, .75, .4, .15, 0}
 	curNumVotesState := 1
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOps []simulation.FutureOperation, err error) {
-		// 1) submit proposal now
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
+		// 1) submit proposal now, with the minimum deposit so that it enters
+		// the voting period
 		sender := simulation.RandomAcc(r, accs)
-		msg, err := simulationCreateMsgSubmitProposal(r, sender)
+		msg, err := simulationCreateMsgSubmitProposal(r, sender, k.GetDepositProcedure(ctx).MinDeposit)
 		if err != nil {
-			return "", nil, err
+			return simulation.NoOpMsg("gov"), nil, err
 		}
-		action, ok := simulateHandleMsgSubmitProposal(msg, sk, handler, ctx, event)
+		opMsg = simulateHandleMsgSubmitProposal(msg, handler, ctx, event)
 		// don't schedule votes if proposal failed
-		if !ok {
-			return action, nil, nil
+		if !opMsg.OK {
+			return opMsg, nil, nil
 		}
 		proposalID := k.GetLastProposalID(ctx)
 		// 2) Schedule operations for votes
@@ -74,43 +75,85 @@ func SimulateSubmittingVotingAndSlashingForProposal(k gov.Keeper, sk stake.Keepe
 		// TODO: Find a way to check if a validator was slashed other than just checking their balance a block
 		// before and after.
 
-		return action, fops, nil
+		return opMsg, fops, nil
+	}
+}
+
+// NewHandler returns the governance handler of the simulation, which removes
+// the deposited tokens from the loose tokens of the stake pool to keep the
+// supply invariants, as deposits are burned unless their proposal passes (see
+// RefundedDeposits). Replays of operation logs must deliver the governance
+// msgs with it too.
+func NewHandler(k gov.Keeper, sk stake.Keeper) sdk.Handler {
+	handler := gov.NewHandler(k)
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		result := handler(ctx, msg)
+		if !result.IsOK() {
+			return result
+		}
+
+		var deposit sdk.Coins
+		switch msg := msg.(type) {
+		case gov.MsgSubmitProposal:
+			deposit = msg.InitialDeposit
+		case gov.MsgDeposit:
+			deposit = msg.Amount
+		}
+		pool := sk.GetPool(ctx)
+		pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewDecFromInt(deposit.AmountOf(denom)))
+		sk.SetPool(ctx, pool)
+		return result
+	}
+}
+
+// RefundedDeposits returns the amount of the deposits refunded by the passed
+// proposals, which the handler of the simulation removed from the loose tokens.
+func RefundedDeposits(k gov.Keeper, ctx sdk.Context) sdk.Int {
+	return totalDeposits(k, ctx, gov.StatusPassed)
+}
+
+// HeldDeposits returns the amount of the deposits held by the open proposals,
+// which the handler of the simulation removed from the loose tokens.
+func HeldDeposits(k gov.Keeper, ctx sdk.Context) sdk.Int {
+	return totalDeposits(k, ctx, gov.StatusDepositPeriod, gov.StatusVotingPeriod)
+}
+
+func totalDeposits(k gov.Keeper, ctx sdk.Context, statuses ...gov.ProposalStatus) sdk.Int {
+	total := sdk.ZeroInt()
+	for _, status := range statuses {
+		for _, proposal := range k.GetProposalsFiltered(ctx, nil, nil, status, 0) {
+			total = total.Add(proposal.GetTotalDeposit().AmountOf(denom))
+		}
 	}
+	return total
 }
 
 // SimulateMsgSubmitProposal simulates a msg Submit Proposal
 // Note: Currently doesn't ensure that the proposal txt is in JSON form
 func SimulateMsgSubmitProposal(k gov.Keeper, sk stake.Keeper) simulation.Operation {
-	handler := gov.NewHandler(k)
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOps []simulation.FutureOperation, err error) {
+	handler := NewHandler(k, sk)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOps []simulation.FutureOperation, err error) {
 		sender := simulation.RandomAcc(r, accs)
-		msg, err := simulationCreateMsgSubmitProposal(r, sender)
+		msg, err := simulationCreateMsgSubmitProposal(r, sender, randomDeposit(r))
 		if err != nil {
-			return "", nil, err
+			return simulation.NoOpMsg("gov"), nil, err
 		}
-		action, _ = simulateHandleMsgSubmitProposal(msg, sk, handler, ctx, event)
-		return action, nil, nil
+		opMsg = simulateHandleMsgSubmitProposal(msg, handler, ctx, event)
+		return opMsg, nil, nil
 	}
 }
 
-func simulateHandleMsgSubmitProposal(msg gov.MsgSubmitProposal, sk stake.Keeper, handler sdk.Handler, ctx sdk.Context, event func(string)) (action string, ok bool) {
+func simulateHandleMsgSubmitProposal(msg gov.MsgSubmitProposal, handler sdk.Handler, ctx sdk.Context, event func(string)) simulation.OperationMsg {
 	ctx, write := ctx.CacheContext()
 	result := handler(ctx, msg)
-	ok = result.IsOK()
-	if ok {
-		// Update pool to keep invariants
-		pool := sk.GetPool(ctx)
-		pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewDecFromInt(msg.InitialDeposit.AmountOf(denom)))
-		sk.SetPool(ctx, pool)
+	if result.IsOK() {
 		write()
 	}
-	event(fmt.Sprintf("gov/MsgSubmitProposal/%v", ok))
-	action = fmt.Sprintf("TestMsgSubmitProposal: ok %v, msg %s", ok, msg.GetSignBytes())
-	return
+	event(fmt.Sprintf("gov/MsgSubmitProposal/%v", result.IsOK()))
+	return simulation.NewOperationMsg(msg, result.IsOK(), "")
 }
 
-func simulationCreateMsgSubmitProposal(r *rand.Rand, sender simulation.Account) (msg gov.MsgSubmitProposal, err error) {
-	deposit := randomDeposit(r)
+func simulationCreateMsgSubmitProposal(r *rand.Rand, sender simulation.Account, deposit sdk.Coins) (msg gov.MsgSubmitProposal, err error) {
 	msg = gov.NewMsgSubmitProposal(
 		simulation.RandStringOfLength(r, 5),
 		simulation.RandStringOfLength(r, 5),
@@ -126,29 +169,26 @@ func simulationCreateMsgSubmitProposal(r *rand.Rand, sender simulation.Account)
 
 // SimulateMsgDeposit
 func SimulateMsgDeposit(k gov.Keeper, sk stake.Keeper) simulation.Operation {
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOp []simulation.FutureOperation, err error) {
+	handler := NewHandler(k, sk)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOp []simulation.FutureOperation, err error) {
 		acc := simulation.RandomAcc(r, accs)
-		proposalID, ok := randomProposalID(r, k, ctx)
+		proposalID, ok := randomProposalID(r, k, ctx, gov.StatusDepositPeriod, gov.StatusVotingPeriod)
 		if !ok {
-			return "no-operation", nil, nil
+			return simulation.NoOpMsg("gov"), nil, nil
 		}
 		deposit := randomDeposit(r)
 		msg := gov.NewMsgDeposit(acc.Address, proposalID, deposit)
 		if msg.ValidateBasic() != nil {
-			return "", nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+			return simulation.NoOpMsg("gov"), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
 		ctx, write := ctx.CacheContext()
-		result := gov.NewHandler(k)(ctx, msg)
+		result := handler(ctx, msg)
 		if result.IsOK() {
-			// Update pool to keep invariants
-			pool := sk.GetPool(ctx)
-			pool.LooseTokens = pool.LooseTokens.Sub(sdk.NewDecFromInt(deposit.AmountOf(denom)))
-			sk.SetPool(ctx, pool)
 			write()
 		}
 		event(fmt.Sprintf("gov/MsgDeposit/%v", result.IsOK()))
-		action = fmt.Sprintf("TestMsgDeposit: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
-		return action, nil, nil
+		opMsg = simulation.NewOperationMsg(msg, result.IsOK(), "")
+		return opMsg, nil, nil
 	}
 }
 
@@ -160,24 +200,30 @@ func SimulateMsgVote(k gov.Keeper, sk stake.Keeper) simulation.Operation {
 
 // nolint: unparam
 func operationSimulateMsgVote(k gov.Keeper, sk stake.Keeper, acc simulation.Account, proposalID int64) simulation.Operation {
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOp []simulation.FutureOperation, err error) {
-		if acc.Equals(simulation.Account{}) {
-			acc = simulation.RandomAcc(r, accs)
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOp []simulation.FutureOperation, err error) {
+		// the operation may run several times, so it picks its own voter and
+		// proposal each time if none are given
+		voter := acc
+		if voter.Equals(simulation.Account{}) {
+			voter = simulation.RandomAcc(r, accs)
 		}
 
-		var ok bool
-
-		if proposalID < 0 {
-			proposalID, ok = randomProposalID(r, k, ctx)
+		votedProposalID := proposalID
+		if votedProposalID < 0 {
+			var ok bool
+			votedProposalID, ok = randomProposalID(r, k, ctx, gov.StatusVotingPeriod)
 			if !ok {
-				return "no-operation", nil, nil
+				return simulation.NoOpMsg("gov"), nil, nil
 			}
+		} else if proposal := k.GetProposal(ctx, votedProposalID); proposal == nil || proposal.GetStatus() != gov.StatusVotingPeriod {
+			// the proposal has been tallied
+			return simulation.NoOpMsg("gov"), nil, nil
 		}
 		option := randomVotingOption(r)
 
-		msg := gov.NewMsgVote(acc.Address, proposalID, option)
+		msg := gov.NewMsgVote(voter.Address, votedProposalID, option)
 		if msg.ValidateBasic() != nil {
-			return "", nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+			return simulation.NoOpMsg("gov"), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
 
 		ctx, write := ctx.CacheContext()
@@ -187,8 +233,8 @@ func operationSimulateMsgVote(k gov.Keeper, sk stake.Keeper, acc simulation.Acco
 		}
 
 		event(fmt.Sprintf("gov/MsgVote/%v", result.IsOK()))
-		action = fmt.Sprintf("TestMsgVote: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
-		return action, nil, nil
+		opMsg = simulation.NewOperationMsg(msg, result.IsOK(), "")
+		return opMsg, nil, nil
 	}
 }
 
@@ -199,14 +245,16 @@ func randomDeposit(r *rand.Rand) sdk.Coins {
 	return sdk.Coins{sdk.NewInt64Coin(denom, amount)}
 }
 
-// Pick a random proposal ID
-func randomProposalID(r *rand.Rand, k gov.Keeper, ctx sdk.Context) (proposalID int64, ok bool) {
-	lastProposalID := k.GetLastProposalID(ctx)
-	if lastProposalID < 1 {
+// Pick a random proposal ID among the proposals with one of the given statuses
+func randomProposalID(r *rand.Rand, k gov.Keeper, ctx sdk.Context, statuses ...gov.ProposalStatus) (proposalID int64, ok bool) {
+	var proposals []gov.Proposal
+	for _, status := range statuses {
+		proposals = append(proposals, k.GetProposalsFiltered(ctx, nil, nil, status, 0)...)
+	}
+	if len(proposals) == 0 {
 		return 0, false
 	}
-	proposalID = int64(r.Intn(int(lastProposalID)))
-	return proposalID, true
+	return proposals[r.Intn(len(proposals))].GetProposalID(), true
 }
 
 // Pick a random voting option
@@ -0,0 +1,28 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+)
+
+// RandomParams returns random governance procedures, with deposit and voting
+// periods short enough for proposals to end during a simulation
+func RandomParams(r *rand.Rand) (gov.DepositProcedure, gov.VotingProcedure, gov.TallyingProcedure) {
+	depositProcedure := gov.DepositProcedure{
+		MinDeposit:       sdk.Coins{sdk.NewInt64Coin("steak", int64(simulation.RandIntBetween(r, 1, 50)))},
+		MaxDepositPeriod: simulation.RandDurationBetween(r, time.Hour, 48*time.Hour),
+	}
+	votingProcedure := gov.VotingProcedure{
+		VotingPeriod: simulation.RandDurationBetween(r, time.Hour, 48*time.Hour),
+	}
+	tallyingProcedure := gov.TallyingProcedure{
+		Threshold:         simulation.RandDecBetween(r, 40, 60, 2),
+		Veto:              simulation.RandDecBetween(r, 25, 40, 2),
+		GovernancePenalty: simulation.RandDecBetween(r, 0, 5, 2),
+	}
+	return depositProcedure, votingProcedure, tallyingProcedure
+}
@@ -65,7 +65,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		}, []simulation.RandSetup{
 			setup,
 		}, []simulation.Invariant{
-			AllInvariants(),
+			AllInvariants(govKeeper),
 		}, 10, 100,
 		false,
 	)
@@ -79,7 +79,7 @@ func TestGovWithRandomMessages(t *testing.T) {
 		}, []simulation.RandSetup{
 			setup,
 		}, []simulation.Invariant{
-			AllInvariants(),
+			AllInvariants(govKeeper),
 		}, 10, 100,
 		false,
 	)
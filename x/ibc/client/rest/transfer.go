@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
@@ -11,11 +12,19 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/ibc"
 
 	"github.com/gorilla/mux"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
 // RegisterRoutes - Central function to define routes that get registered by the main application
-func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
-	r.HandleFunc("/ibc/{destchain}/{address}/send", TransferRequestHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+func RegisterRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec, kb keys.Keybase) {
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/ibc/{destchain}/{address}/send",
+		Summary:  "Transfer coins to an address on another chain",
+		Query:    utils.TxQueryParams,
+		Request:  transferReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, TransferRequestHandlerFn(cdc, kb, cliCtx))
 }
 
 type transferReq struct {
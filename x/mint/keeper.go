@@ -32,7 +32,7 @@ func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
 // Keys
 
 var (
-	minterKey = []byte{0x00} // the one key to use for the keeper store
+	MinterKey = []byte{0x00} // the one key to use for the keeper store
 
 	// params store for inflation params
 	ParamStoreKeyParams = []byte("params")
@@ -55,7 +55,7 @@ const (
 // get the minter
 func (k Keeper) GetMinter(ctx sdk.Context) (minter Minter) {
 	store := ctx.KVStore(k.storeKey)
-	b := store.Get(minterKey)
+	b := store.Get(MinterKey)
 	if b == nil {
 		panic("Stored fee pool should not have been nil")
 	}
@@ -67,7 +67,7 @@ func (k Keeper) GetMinter(ctx sdk.Context) (minter Minter) {
 func (k Keeper) SetMinter(ctx sdk.Context, minter Minter) {
 	store := ctx.KVStore(k.storeKey)
 	b := k.cdc.MustMarshalBinary(minter)
-	store.Set(minterKey, b)
+	store.Set(MinterKey, b)
 }
 
 //______________________________________________________________________
@@ -0,0 +1,11 @@
+package mint
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// RegisterGauges registers the gauges of the minter
+func RegisterGauges(registry sdk.GaugeRegistry, k Keeper) {
+	registry.RegisterGauge("mint", "inflation", "Current annual inflation rate.",
+		func(ctx sdk.Context) float64 { return k.GetMinter(ctx).Inflation.Float64() })
+}
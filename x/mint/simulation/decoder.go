@@ -0,0 +1,25 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the mint store and returns
+// it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kv.Key, mint.MinterKey):
+		var minter mint.Minter
+		cdc.MustUnmarshalBinary(kv.Value, &minter)
+		return fmt.Sprintf("%v", minter)
+
+	default:
+		panic(fmt.Sprintf("invalid mint key %X", kv.Key))
+	}
+}
@@ -0,0 +1,21 @@
+package simulation
+
+import (
+	"math/rand"
+
+	"github.com/yukimochizuki/cosmos-sdk/x/mint"
+	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+)
+
+// RandomParams returns random mint params, the maximum inflation being never
+// lower than the minimum one
+func RandomParams(r *rand.Rand) mint.Params {
+	inflationMin := simulation.RandDecBetween(r, 0, 10, 2)
+	return mint.Params{
+		MintDenom:           "steak",
+		InflationRateChange: simulation.RandDecBetween(r, 1, 50, 2),
+		InflationMax:        inflationMin.Add(simulation.RandDecBetween(r, 0, 30, 2)),
+		InflationMin:        inflationMin,
+		GoalBonded:          simulation.RandDecBetween(r, 30, 90, 2),
+	}
+}
@@ -23,5 +23,11 @@ the weightings for each, the invariants you want to test, and how long to run it
 Then run simulation.Simulate!
 The simulator will handle things like ensuring that validators periodically double signing,
 or go offline.
+
+Modules should also provide a RandomParams function, generating their params
+within the ranges they are expected to work with, so that the genesis state of
+a simulation exercises the parameter dependent code paths. As the params are
+drawn from the simulation's source of randomness, they are reproduced by the
+seed.
 */
 package simulation
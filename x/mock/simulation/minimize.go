@@ -0,0 +1,101 @@
+package simulation
+
+// MinimizeOperationLog shrinks a failing operation log down to a minimal set
+// of operations which still reproduces the failure, using delta debugging.
+// Only operation entries are removed: the chain initialization and the blocks
+// are kept, so that the heights and times of the replays don't change.
+// fails must replay the given entries on a fresh app, and report whether the
+// failure still occurs. The returned log is 1-minimal: removing any single
+// one of its operations makes the failure disappear.
+func MinimizeOperationLog(entries []OperationEntry, fails func([]OperationEntry) bool) []OperationEntry {
+	var ops []int
+	for i, entry := range entries {
+		if entry.EntryKind == EntryOperation {
+			ops = append(ops, i)
+		}
+	}
+
+	// keep the entries which are not operations, and the given operations
+	build := func(ops []int) []OperationEntry {
+		kept := make(map[int]bool, len(ops))
+		for _, i := range ops {
+			kept[i] = true
+		}
+		var res []OperationEntry
+		for i, entry := range entries {
+			if entry.EntryKind != EntryOperation || kept[i] {
+				res = append(res, entry)
+			}
+		}
+		return res
+	}
+
+	if fails(build(nil)) {
+		return build(nil)
+	}
+	return build(ddmin(ops, func(ops []int) bool { return fails(build(ops)) }))
+}
+
+// ddmin returns a 1-minimal subset of the items for which fails holds, fails
+// holding for all the items
+func ddmin(items []int, fails func([]int) bool) []int {
+	n := 2
+	for len(items) >= 2 {
+		chunks := split(items, n)
+		reduced := false
+
+		// try to reduce to a single chunk
+		for _, chunk := range chunks {
+			if fails(chunk) {
+				items, n, reduced = chunk, 2, true
+				break
+			}
+		}
+
+		// try to reduce to the complement of a chunk
+		if !reduced && n > 2 {
+			for i := range chunks {
+				complement := complementOf(chunks, i)
+				if fails(complement) {
+					items, n, reduced = complement, n-1, true
+					break
+				}
+			}
+		}
+
+		// refine the granularity
+		if !reduced {
+			if n >= len(items) {
+				break
+			}
+			n *= 2
+			if n > len(items) {
+				n = len(items)
+			}
+		}
+	}
+	return items
+}
+
+// split the items in n chunks of almost equal sizes
+func split(items []int, n int) [][]int {
+	chunks := make([][]int, 0, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		end := start + (len(items)-start)/(n-i)
+		chunks = append(chunks, items[start:end])
+		start = end
+	}
+	return chunks
+}
+
+// the items of all the chunks but the i-th
+func complementOf(chunks [][]int, i int) []int {
+	var res []int
+	for j, chunk := range chunks {
+		if j != i {
+			res = append(res, chunk...)
+		}
+	}
+	return res
+}
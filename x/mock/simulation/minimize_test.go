@@ -0,0 +1,51 @@
+package simulation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimizeOperationLog(t *testing.T) {
+	entries := []OperationEntry{{EntryKind: EntryInitChain}}
+	for height := int64(1); height <= 4; height++ {
+		entries = append(entries, OperationEntry{EntryKind: EntryBeginBlock, Height: height})
+		for i := 0; i < 5; i++ {
+			name := fmt.Sprintf("op%d", (height-1)*5+int64(i))
+			entries = append(entries, OperationEntry{EntryKind: EntryOperation, Height: height, Name: name})
+		}
+		entries = append(entries, OperationEntry{EntryKind: EntryEndBlock, Height: height})
+	}
+
+	// the failure needs op3 to run before op16
+	replays := 0
+	fails := func(entries []OperationEntry) bool {
+		replays++
+		seen3 := false
+		for _, entry := range entries {
+			switch entry.Name {
+			case "op3":
+				seen3 = true
+			case "op16":
+				return seen3
+			}
+		}
+		return false
+	}
+
+	minimized := MinimizeOperationLog(entries, fails)
+	var ops []string
+	for _, entry := range minimized {
+		if entry.EntryKind == EntryOperation {
+			ops = append(ops, entry.Name)
+		}
+	}
+	require.Equal(t, []string{"op3", "op16"}, ops)
+	require.Equal(t, len(entries)-18, len(minimized))
+	require.True(t, replays < 50, "too many replays: %d", replays)
+
+	// failures which need no operation at all
+	minimized = MinimizeOperationLog(entries, func([]OperationEntry) bool { return true })
+	require.Equal(t, 9, len(minimized))
+}
@@ -0,0 +1,217 @@
+package simulation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/yukimochizuki/cosmos-sdk/baseapp"
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// Kinds of the entries of an operation log
+const (
+	EntryInitChain  = "init_chain"
+	EntryBeginBlock = "begin_block"
+	EntryOperation  = "operation"
+	EntryEndBlock   = "end_block"
+	EntryCommit     = "commit"
+)
+
+// OperationEntry is an entry of an operation log. Besides the operations,
+// the log records the initial app state and the ABCI requests of every block,
+// so that a simulation can be replayed without its seed.
+type OperationEntry struct {
+	EntryKind string          `json:"entry_kind"`
+	Height    int64           `json:"height"`
+	Route     string          `json:"route,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	OK        bool            `json:"ok,omitempty"`
+	Msg       json.RawMessage `json:"msg,omitempty"`
+	Tx        json.RawMessage `json:"tx,omitempty"`
+	Request   json.RawMessage `json:"request,omitempty"`
+	AppState  json.RawMessage `json:"app_state,omitempty"`
+}
+
+// OperationLog records the entries of a simulation to a writer as they
+// happen, one JSON entry per line, so that the log of a simulation which
+// panicked is complete. A nil OperationLog records nothing.
+type OperationLog struct {
+	cdc *codec.Codec
+	enc *json.Encoder
+}
+
+// NewOperationLog returns an OperationLog writing to w. The codec must have
+// the msgs and the txs of all the operations registered.
+func NewOperationLog(cdc *codec.Codec, w io.Writer) *OperationLog {
+	return &OperationLog{
+		cdc: cdc,
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (log *OperationLog) record(entry OperationEntry) {
+	if log == nil {
+		return
+	}
+	err := log.enc.Encode(entry)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func (log *OperationLog) recordInitChain(appState json.RawMessage) {
+	log.record(OperationEntry{EntryKind: EntryInitChain, AppState: appState})
+}
+
+func (log *OperationLog) recordBeginBlock(request abci.RequestBeginBlock) {
+	if log == nil {
+		return
+	}
+	bz, err := json.Marshal(request)
+	if err != nil {
+		panic(err)
+	}
+	log.record(OperationEntry{EntryKind: EntryBeginBlock, Height: request.Header.Height, Request: bz})
+}
+
+func (log *OperationLog) recordOperation(height int64, opMsg OperationMsg) {
+	if log == nil {
+		return
+	}
+	entry := OperationEntry{
+		EntryKind: EntryOperation,
+		Height:    height,
+		Route:     opMsg.Route,
+		Name:      opMsg.Name,
+		OK:        opMsg.OK,
+	}
+	var err error
+	switch {
+	case opMsg.Tx != nil:
+		entry.Tx, err = log.cdc.MarshalJSON(opMsg.Tx)
+	case opMsg.Msg != nil:
+		entry.Msg, err = log.cdc.MarshalJSON(opMsg.Msg)
+	}
+	if err != nil {
+		panic(err)
+	}
+	log.record(entry)
+}
+
+func (log *OperationLog) recordEndBlock(height int64) {
+	log.record(OperationEntry{EntryKind: EntryEndBlock, Height: height})
+}
+
+func (log *OperationLog) recordCommit(height int64) {
+	log.record(OperationEntry{EntryKind: EntryCommit, Height: height})
+}
+
+// ReadOperationLog reads all the entries of an operation log
+func ReadOperationLog(r io.Reader) (entries []OperationEntry, err error) {
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var entry OperationEntry
+		err = dec.Decode(&entry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// WriteOperationLog writes the entries of an operation log, in the format
+// read by ReadOperationLog
+func WriteOperationLog(w io.Writer, entries []OperationEntry) error {
+	enc := json.NewEncoder(w)
+	for _, entry := range entries {
+		err := enc.Encode(entry)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MsgDeliverer delivers a msg of a replayed operation log, the way the
+// operation which recorded it did
+type MsgDeliverer func(ctx sdk.Context, msg sdk.Msg) sdk.Result
+
+// ReplayOperationLog replays an operation log on a fresh app: the chain is
+// initialized with the recorded app state, then the recorded blocks, txs and
+// msgs are delivered in order. Txs are delivered through app.Deliver, like
+// the operations which recorded them did. Msgs are delivered by deliver, and
+// only written to the state if they succeed. Operations which changed the
+// state without a msg must record one standing for the change, e.g. a fee
+// deduction, for deliver to apply it. Operations which recorded neither are
+// not replayed. The invariants are asserted at the end of every block. The
+// first broken invariant, or a panic, is returned as an error.
+func ReplayOperationLog(app *baseapp.BaseApp, cdc *codec.Codec, entries []OperationEntry,
+	deliver MsgDeliverer, invariants []Invariant) (err error) {
+
+	var header abci.Header
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("replay panicked at height %d: %v", header.Height, r)
+		}
+	}()
+
+	for i, entry := range entries {
+		switch entry.EntryKind {
+		case EntryInitChain:
+			app.InitChain(abci.RequestInitChain{AppStateBytes: entry.AppState})
+
+		case EntryBeginBlock:
+			var request abci.RequestBeginBlock
+			err = json.Unmarshal(entry.Request, &request)
+			if err != nil {
+				return fmt.Errorf("invalid request of entry %d: %v", i, err)
+			}
+			header = request.Header
+			app.BeginBlock(request)
+
+		case EntryOperation:
+			if entry.Tx != nil {
+				var tx sdk.Tx
+				err = cdc.UnmarshalJSON(entry.Tx, &tx)
+				if err != nil {
+					return fmt.Errorf("invalid tx of entry %d: %v", i, err)
+				}
+				app.Deliver(tx)
+				continue
+			}
+			if entry.Msg == nil {
+				continue
+			}
+			var msg sdk.Msg
+			err = cdc.UnmarshalJSON(entry.Msg, &msg)
+			if err != nil {
+				return fmt.Errorf("invalid msg of entry %d: %v", i, err)
+			}
+			ctx, write := app.NewContext(false, header).CacheContext()
+			if deliver(ctx, msg).IsOK() {
+				write()
+			}
+
+		case EntryEndBlock:
+			app.EndBlock(abci.RequestEndBlock{})
+			for _, invariant := range invariants {
+				err = invariant(app, header)
+				if err != nil {
+					return fmt.Errorf("invariant broken at the end of block %d: %v", header.Height, err)
+				}
+			}
+
+		case EntryCommit:
+			app.Commit()
+
+		default:
+			return fmt.Errorf("unknown kind %q of entry %d", entry.EntryKind, i)
+		}
+	}
+	return nil
+}
@@ -3,7 +3,6 @@ package simulation
 import (
 	"encoding/json"
 	"fmt"
-	"math"
 	"math/rand"
 	"os"
 	"os/signal"
@@ -33,8 +32,10 @@ func Simulate(t *testing.T, app *baseapp.BaseApp,
 }
 
 func initChain(r *rand.Rand, accounts []Account, setups []RandSetup, app *baseapp.BaseApp,
-	appStateFn func(r *rand.Rand, accounts []Account) json.RawMessage) (validators map[string]mockValidator) {
-	res := app.InitChain(abci.RequestInitChain{AppStateBytes: appStateFn(r, accounts)})
+	appStateFn func(r *rand.Rand, accounts []Account) json.RawMessage, opLog *OperationLog) (validators map[string]mockValidator) {
+	appState := appStateFn(r, accounts)
+	opLog.recordInitChain(appState)
+	res := app.InitChain(abci.RequestInitChain{AppStateBytes: appState})
 	validators = make(map[string]mockValidator)
 	for _, validator := range res.Validators {
 		str := fmt.Sprintf("%v", validator.PubKey)
@@ -48,8 +49,11 @@ func initChain(r *rand.Rand, accounts []Account, setups []RandSetup, app *baseap
 	return
 }
 
+// randTimestamp returns a time before the year 9999, a year ahead of the
+// last one which can be marshalled to JSON, so that the state of the
+// simulation can be exported
 func randTimestamp(r *rand.Rand) time.Time {
-	unixTime := r.Int63n(int64(math.Pow(2, 40)))
+	unixTime := r.Int63n(253373529600)
 	return time.Unix(unixTime, 0)
 }
 
@@ -60,6 +64,18 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 	seed int64, ops []WeightedOperation, setups []RandSetup, invariants []Invariant,
 	numBlocks int, blockSize int, commit bool) (simError error) {
 
+	return SimulateFromSeedWithOperationLog(tb, app, appStateFn, seed, ops, setups, invariants,
+		numBlocks, blockSize, commit, nil)
+}
+
+// SimulateFromSeedWithOperationLog is SimulateFromSeed recording the blocks
+// and the operations to the operation log, so that the simulation can be
+// replayed with ReplayOperationLog.
+func SimulateFromSeedWithOperationLog(tb testing.TB, app *baseapp.BaseApp,
+	appStateFn func(r *rand.Rand, accs []Account) json.RawMessage,
+	seed int64, ops []WeightedOperation, setups []RandSetup, invariants []Invariant,
+	numBlocks int, blockSize int, commit bool, opLog *OperationLog) (simError error) {
+
 	// in case we have to end early, don't os.Exit so that we can run cleanup code.
 	stopEarly := false
 	testingMode, t, b := getTestingMode(tb)
@@ -77,7 +93,14 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 		events[what]++
 	}
 
-	validators := initChain(r, accs, setups, app, appStateFn)
+	// Setup operation stats
+	opStats := make(OperationStats)
+	recordOp := func(height int64, opMsg OperationMsg) {
+		opLog.recordOperation(height, opMsg)
+		opStats.Record(opMsg)
+	}
+
+	validators := initChain(r, accs, setups, app, appStateFn, opLog)
 	// Second variable to keep pending validator set (delayed one block since TM 0.24)
 	// Initially this is the same as the initial validator set
 	nextValidators := validators
@@ -108,7 +131,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 		blockLogBuilders = make([]*strings.Builder, numBlocks)
 	}
 	displayLogs := logPrinter(testingMode, blockLogBuilders)
-	blockSimulator := createBlockSimulator(testingMode, tb, t, event, invariants, ops, operationQueue, timeOperationQueue, numBlocks, displayLogs)
+	blockSimulator := createBlockSimulator(testingMode, tb, t, event, invariants, ops, operationQueue, timeOperationQueue, numBlocks, displayLogs, recordOp)
 	if !testingMode {
 		b.ResetTimer()
 	} else {
@@ -134,6 +157,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 
 		// Run the BeginBlock handler
 		logWriter("BeginBlock")
+		opLog.recordBeginBlock(request)
 		app.BeginBlock(request)
 
 		if testingMode {
@@ -146,8 +170,8 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 
 		// Run queued operations. Ignores blocksize if blocksize is too small
 		logWriter("Queued operations")
-		numQueuedOpsRan := runQueuedOperations(operationQueue, int(header.Height), tb, r, app, ctx, accs, logWriter, displayLogs, event)
-		numQueuedTimeOpsRan := runQueuedTimeOperations(timeOperationQueue, header.Time, tb, r, app, ctx, accs, logWriter, displayLogs, event)
+		numQueuedOpsRan := runQueuedOperations(operationQueue, header.Height, tb, r, app, ctx, accs, logWriter, displayLogs, event, recordOp)
+		numQueuedTimeOpsRan := runQueuedTimeOperations(timeOperationQueue, header, tb, r, app, ctx, accs, logWriter, displayLogs, event, recordOp)
 		if testingMode && onOperation {
 			// Make sure invariants hold at end of queued operations
 			assertAllInvariants(t, app, header, invariants, "QueuedOperations", displayLogs)
@@ -163,6 +187,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 		}
 
 		res := app.EndBlock(abci.RequestEndBlock{})
+		opLog.recordEndBlock(header.Height)
 		header.Height++
 		header.Time = header.Time.Add(time.Duration(minTimePerBlock) * time.Second).Add(time.Duration(int64(r.Intn(int(timeDiff)))) * time.Second)
 		header.ProposerAddress = randomProposer(r, validators)
@@ -174,6 +199,7 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 		}
 		if commit {
 			app.Commit()
+			opLog.recordCommit(header.Height - 1)
 		}
 
 		if header.ProposerAddress == nil {
@@ -191,16 +217,18 @@ func SimulateFromSeed(tb testing.TB, app *baseapp.BaseApp,
 	}
 	if stopEarly {
 		DisplayEvents(events)
+		DisplayOperationStats(opStats)
 		return
 	}
 	fmt.Printf("\nSimulation complete. Final height (blocks): %d, final time (seconds), : %v, operations ran %d\n", header.Height, header.Time, opCount)
 	DisplayEvents(events)
+	DisplayOperationStats(opStats)
 	return nil
 }
 
 // Returns a function to simulate blocks. Written like this to avoid constant parameters being passed everytime, to minimize
 // memory overhead
-func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, event func(string), invariants []Invariant, ops []WeightedOperation, operationQueue map[int][]Operation, timeOperationQueue []FutureOperation, totalNumBlocks int, displayLogs func()) func(
+func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, event func(string), invariants []Invariant, ops []WeightedOperation, operationQueue map[int][]Operation, timeOperationQueue []FutureOperation, totalNumBlocks int, displayLogs func(), recordOp func(int64, OperationMsg)) func(
 	blocksize int, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accounts []Account, header abci.Header, logWriter func(string)) (opCount int) {
 	totalOpWeight := 0
 	for i := 0; i < len(ops); i++ {
@@ -220,17 +248,18 @@ func createBlockSimulator(testingMode bool, tb testing.TB, t *testing.T, event f
 	return func(blocksize int, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 		accounts []Account, header abci.Header, logWriter func(string)) (opCount int) {
 		for j := 0; j < blocksize; j++ {
-			logUpdate, futureOps, err := selectOp(r)(r, app, ctx, accounts, event)
+			opMsg, futureOps, err := selectOp(r)(r, app, ctx, accounts, event)
+			recordOp(header.Height, opMsg)
 			if err != nil {
 				displayLogs()
 				tb.Fatalf("error on operation %d within block %d, %v", header.Height, opCount, err)
 			}
-			logWriter(logUpdate)
+			logWriter(opMsg.String())
 
 			queueOperations(operationQueue, timeOperationQueue, futureOps)
 			if testingMode {
 				if onOperation {
-					assertAllInvariants(t, app, header, invariants, fmt.Sprintf("operation: %v", logUpdate), displayLogs)
+					assertAllInvariants(t, app, header, invariants, fmt.Sprintf("operation: %v", opMsg), displayLogs)
 				}
 				if opCount%50 == 0 {
 					fmt.Printf("\rSimulating... block %d/%d, operation %d/%d. ", header.Height, totalNumBlocks, opCount, blocksize)
@@ -288,37 +317,39 @@ func queueOperations(queuedOperations map[int][]Operation, queuedTimeOperations
 }
 
 // nolint: errcheck
-func runQueuedOperations(queueOperations map[int][]Operation, height int, tb testing.TB, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
-	accounts []Account, logWriter func(string), displayLogs func(), event func(string)) (numOpsRan int) {
-	if queuedOps, ok := queueOperations[height]; ok {
+func runQueuedOperations(queueOperations map[int][]Operation, height int64, tb testing.TB, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+	accounts []Account, logWriter func(string), displayLogs func(), event func(string), recordOp func(int64, OperationMsg)) (numOpsRan int) {
+	if queuedOps, ok := queueOperations[int(height)]; ok {
 		numOps := len(queuedOps)
 		for i := 0; i < numOps; i++ {
 			// For now, queued operations cannot queue more operations.
 			// If a need arises for us to support queued messages to queue more messages, this can
 			// be changed.
-			logUpdate, _, err := queuedOps[i](r, app, ctx, accounts, event)
-			logWriter(logUpdate)
+			opMsg, _, err := queuedOps[i](r, app, ctx, accounts, event)
+			recordOp(height, opMsg)
+			logWriter(opMsg.String())
 			if err != nil {
 				displayLogs()
 				tb.FailNow()
 			}
 		}
-		delete(queueOperations, height)
+		delete(queueOperations, int(height))
 		return numOps
 	}
 	return 0
 }
 
-func runQueuedTimeOperations(queueOperations []FutureOperation, currentTime time.Time, tb testing.TB, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
-	accounts []Account, logWriter func(string), displayLogs func(), event func(string)) (numOpsRan int) {
+func runQueuedTimeOperations(queueOperations []FutureOperation, header abci.Header, tb testing.TB, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+	accounts []Account, logWriter func(string), displayLogs func(), event func(string), recordOp func(int64, OperationMsg)) (numOpsRan int) {
 
 	numOpsRan = 0
-	for len(queueOperations) > 0 && currentTime.After(queueOperations[0].BlockTime) {
+	for len(queueOperations) > 0 && header.Time.After(queueOperations[0].BlockTime) {
 		// For now, queued operations cannot queue more operations.
 		// If a need arises for us to support queued messages to queue more messages, this can
 		// be changed.
-		logUpdate, _, err := queueOperations[0].Op(r, app, ctx, accounts, event)
-		logWriter(logUpdate)
+		opMsg, _, err := queueOperations[0].Op(r, app, ctx, accounts, event)
+		recordOp(header.Height, opMsg)
+		logWriter(opMsg.String())
 		if err != nil {
 			displayLogs()
 			tb.FailNow()
@@ -1,6 +1,7 @@
 package simulation
 
 import (
+	"fmt"
 	"math/rand"
 	"time"
 
@@ -16,15 +17,15 @@ type (
 	// The operation could be running and testing a fuzzed transaction,
 	// or doing the same for a message.
 	//
-	// For ease of debugging,
-	// an operation returns a descriptive message "action",
+	// For ease of debugging, and for the operation log,
+	// an operation returns an OperationMsg,
 	// which details what this fuzzed state machine transition actually did.
 	//
 	// Operations can optionally provide a list of "FutureOperations" to run later
 	// These will be ran at the beginning of the corresponding block.
 	Operation func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
 		accounts []Account, event func(string),
-	) (action string, futureOperations []FutureOperation, err error)
+	) (opMsg OperationMsg, futureOperations []FutureOperation, err error)
 
 	// RandSetup performs the random setup the mock module needs.
 	RandSetup func(r *rand.Rand, accounts []Account)
@@ -68,6 +69,104 @@ type (
 	}
 )
 
+// OperationMsg describes what an operation did: the msg or the signed tx it
+// delivered, if any, and whether it succeeded
+type OperationMsg struct {
+	Route   string
+	Name    string
+	Comment string
+	OK      bool
+
+	// nil for operations which don't deliver a msg
+	Msg sdk.Msg
+	// the signed tx, for operations which deliver their msg through
+	// app.Deliver rather than a handler
+	Tx sdk.Tx
+}
+
+// NewOperationMsg returns the OperationMsg of an operation which delivered
+// the msg
+func NewOperationMsg(msg sdk.Msg, ok bool, comment string) OperationMsg {
+	return OperationMsg{
+		Route:   msg.Route(),
+		Name:    msg.Type(),
+		Comment: comment,
+		OK:      ok,
+		Msg:     msg,
+	}
+}
+
+// NewOperationMsgTx returns the OperationMsg of an operation which delivered
+// the signed tx through app.Deliver
+func NewOperationMsgTx(tx sdk.Tx, ok bool, comment string) OperationMsg {
+	opMsg := NewOperationMsg(tx.GetMsgs()[0], ok, comment)
+	opMsg.Tx = tx
+	return opMsg
+}
+
+// NewOperationMsgBasic returns the OperationMsg of an operation which didn't
+// deliver a msg
+func NewOperationMsgBasic(route, name, comment string, ok bool) OperationMsg {
+	return OperationMsg{
+		Route:   route,
+		Name:    name,
+		Comment: comment,
+		OK:      ok,
+	}
+}
+
+// name of the OperationMsgs returned by NoOpMsg
+const noOpName = "no-operation"
+
+// NoOpMsg returns the OperationMsg of an operation which did nothing
+func NoOpMsg(route string) OperationMsg {
+	return NewOperationMsgBasic(route, noOpName, "", false)
+}
+
+// IsNoOp returns true for the OperationMsg of an operation which did nothing
+func (opMsg OperationMsg) IsNoOp() bool {
+	return opMsg.Name == noOpName && opMsg.Msg == nil && opMsg.Tx == nil
+}
+
+// String implements fmt.Stringer
+func (opMsg OperationMsg) String() string {
+	str := fmt.Sprintf("%s/%s: ok %v", opMsg.Route, opMsg.Name, opMsg.OK)
+	if opMsg.Msg != nil {
+		str += fmt.Sprintf(", msg %s", opMsg.Msg.GetSignBytes())
+	}
+	if opMsg.Comment != "" {
+		str += fmt.Sprintf(", %s", opMsg.Comment)
+	}
+	return str
+}
+
+// OperationStat counts the runs of an operation which succeeded, failed and
+// did nothing
+type OperationStat struct {
+	OK     uint
+	Failed uint
+	NoOp   uint
+}
+
+// OperationStats are the statistics of the operations of a simulation, by
+// route and name
+type OperationStats map[string]OperationStat
+
+// Record counts the run of the operation which returned the OperationMsg
+func (stats OperationStats) Record(opMsg OperationMsg) {
+	key := fmt.Sprintf("%s/%s", opMsg.Route, opMsg.Name)
+	stat := stats[key]
+	switch {
+	case opMsg.OK:
+		stat.OK++
+	case opMsg.IsNoOp():
+		stat.NoOp++
+	default:
+		stat.Failed++
+	}
+	stats[key] = stat
+}
+
 // TODO remove? not being called anywhere
 // PeriodicInvariant returns an Invariant function closure that asserts
 // a given invariant if the mock application's last block modulo the given
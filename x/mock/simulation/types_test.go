@@ -0,0 +1,18 @@
+package simulation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationStatsRecord(t *testing.T) {
+	stats := make(OperationStats)
+	stats.Record(NewOperationMsgBasic("gov", "vote", "", true))
+	stats.Record(NewOperationMsgBasic("gov", "vote", "", false))
+	stats.Record(NoOpMsg("gov"))
+	stats.Record(NoOpMsg("gov"))
+
+	require.Equal(t, OperationStat{OK: 1, Failed: 1}, stats["gov/vote"])
+	require.Equal(t, OperationStat{NoOp: 2}, stats["gov/no-operation"])
+}
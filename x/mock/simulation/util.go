@@ -59,6 +59,19 @@ func DisplayEvents(events map[string]uint) {
 	}
 }
 
+// Pretty-print operation statistics as a table
+func DisplayOperationStats(stats OperationStats) {
+	var keys []string
+	for key := range stats {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Printf("Operation statistics: \n")
+	for _, key := range keys {
+		fmt.Printf("  % 60s => ok %d, failed %d, no-op %d\n", key, stats[key].OK, stats[key].Failed, stats[key].NoOp)
+	}
+}
+
 // RandomAcc pick a random account from an array
 func RandomAcc(r *rand.Rand, accs []Account) Account {
 	return accs[r.Intn(
@@ -71,6 +84,23 @@ func RandomAmount(r *rand.Rand, max sdk.Int) sdk.Int {
 	return sdk.NewInt(int64(r.Intn(int(max.Int64()))))
 }
 
+// RandIntBetween returns a random int between min (inclusive) and max (exclusive)
+func RandIntBetween(r *rand.Rand, min, max int) int {
+	return r.Intn(max-min) + min
+}
+
+// RandDecBetween returns a random decimal between min and max (inclusive),
+// both given as integers scaled down by prec decimal places
+func RandDecBetween(r *rand.Rand, min, max int64, prec int64) sdk.Dec {
+	return sdk.NewDecWithPrec(min+r.Int63n(max-min+1), prec)
+}
+
+// RandDurationBetween returns a random duration between min (inclusive) and
+// max (exclusive), rounded down to the second
+func RandDurationBetween(r *rand.Rand, min, max time.Duration) time.Duration {
+	return min + time.Duration(r.Int63n(int64((max-min)/time.Second)))*time.Second
+}
+
 // RandomAccounts generates n random accounts
 func RandomAccounts(r *rand.Rand, n int) []Account {
 	accs := make([]Account, n)
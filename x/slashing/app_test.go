@@ -85,7 +85,7 @@ func checkValidator(t *testing.T, mapp *mock.App, keeper stake.Keeper,
 func checkValidatorSigningInfo(t *testing.T, mapp *mock.App, keeper Keeper,
 	addr sdk.ConsAddress, expFound bool) ValidatorSigningInfo {
 	ctxCheck := mapp.BaseApp.NewContext(true, abci.Header{})
-	signingInfo, found := keeper.getValidatorSigningInfo(ctxCheck, addr)
+	signingInfo, found := keeper.GetValidatorSigningInfo(ctxCheck, addr)
 	require.Equal(t, expFound, found)
 	return signingInfo
 }
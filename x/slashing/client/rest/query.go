@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
@@ -11,11 +12,13 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
-	r.HandleFunc(
-		"/slashing/validators/{validatorPubKey}/signing_info",
-		signingInfoHandlerFn(cliCtx, "slashing", cdc),
-	).Methods("GET")
+func registerQueryRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/slashing/validators/{validatorPubKey}/signing_info",
+		Summary:  "Get the signing info of a validator",
+		Response: slashing.ValidatorSigningInfo{},
+	}, signingInfoHandlerFn(cliCtx, "slashing", cdc))
 }
 
 // http request handler to query signing info
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
@@ -12,13 +13,18 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
 
 	"github.com/gorilla/mux"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
-	r.HandleFunc(
-		"/slashing/validators/{validatorAddr}/unjail",
-		unjailRequestHandlerFn(cdc, kb, cliCtx),
-	).Methods("POST")
+func registerTxRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec, kb keys.Keybase) {
+	r.HandleFunc(openapi.Route{
+		Method:   "POST",
+		Path:     "/slashing/validators/{validatorAddr}/unjail",
+		Summary:  "Unjail a jailed validator",
+		Query:    utils.TxQueryParams,
+		Request:  UnjailReq{},
+		Response: ctypes.ResultBroadcastTxCommit{},
+	}, unjailRequestHandlerFn(cdc, kb, cliCtx))
 }
 
 // Unjail TX body
@@ -1,13 +1,36 @@
 package slashing
 
 import (
+	"fmt"
+
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake/types"
 )
 
 // GenesisState - all slashing state that must be provided at genesis
 type GenesisState struct {
-	Params Params
+	Params          Params                    `json:"params"`
+	SigningInfos    []SigningInfo             `json:"signing_infos"`
+	MissedBlocks    []ValidatorMissedBlocks   `json:"missed_blocks"`
+	SlashingPeriods []ValidatorSlashingPeriod `json:"slashing_periods"`
+}
+
+// SigningInfo - signing info of the validator with the given consensus address
+type SigningInfo struct {
+	Address              sdk.ConsAddress      `json:"address"`
+	ValidatorSigningInfo ValidatorSigningInfo `json:"validator_signing_info"`
+}
+
+// ValidatorMissedBlocks - missed block bit array entries of a validator
+type ValidatorMissedBlocks struct {
+	Address      sdk.ConsAddress `json:"address"`
+	MissedBlocks []MissedBlock   `json:"missed_blocks"`
+}
+
+// MissedBlock - single entry of a missed block bit array
+type MissedBlock struct {
+	Index  int64 `json:"index"`
+	Missed bool  `json:"missed"`
 }
 
 // HubDefaultGenesisState - default GenesisState used by Cosmos Hub
@@ -24,5 +47,86 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState, sdata types.
 		keeper.addPubkey(ctx, validator.GetConsPubKey())
 	}
 
+	for _, info := range data.SigningInfos {
+		keeper.setValidatorSigningInfo(ctx, info.Address, info.ValidatorSigningInfo)
+	}
+
+	for _, array := range data.MissedBlocks {
+		for _, missed := range array.MissedBlocks {
+			keeper.setValidatorMissedBlockBitArray(ctx, array.Address, missed.Index, missed.Missed)
+		}
+	}
+
+	for _, slashingPeriod := range data.SlashingPeriods {
+		keeper.addOrUpdateValidatorSlashingPeriod(ctx, slashingPeriod)
+	}
+
 	keeper.paramspace.SetParamSet(ctx, &data.Params)
 }
+
+// ValidateGenesis validates the provided slashing genesis state to ensure the
+// expected invariants holds (i.e. params in correct bounds)
+func ValidateGenesis(data GenesisState) error {
+	params := data.Params
+	if params.MaxEvidenceAge <= 0 {
+		return fmt.Errorf("slashing parameter MaxEvidenceAge should be positive, is %s", params.MaxEvidenceAge)
+	}
+	if params.SignedBlocksWindow <= 0 {
+		return fmt.Errorf("slashing parameter SignedBlocksWindow should be positive, is %d", params.SignedBlocksWindow)
+	}
+	if params.MinSignedPerWindow.LT(sdk.ZeroDec()) || params.MinSignedPerWindow.GT(sdk.OneDec()) {
+		return fmt.Errorf("slashing parameter MinSignedPerWindow should be between zero and one, is %s", params.MinSignedPerWindow.String())
+	}
+	if params.DoubleSignUnbondDuration < 0 {
+		return fmt.Errorf("slashing parameter DoubleSignUnbondDuration can't be negative, is %s", params.DoubleSignUnbondDuration)
+	}
+	if params.DowntimeUnbondDuration < 0 {
+		return fmt.Errorf("slashing parameter DowntimeUnbondDuration can't be negative, is %s", params.DowntimeUnbondDuration)
+	}
+	if params.SlashFractionDoubleSign.LT(sdk.ZeroDec()) || params.SlashFractionDoubleSign.GT(sdk.OneDec()) {
+		return fmt.Errorf("slashing parameter SlashFractionDoubleSign should be between zero and one, is %s", params.SlashFractionDoubleSign.String())
+	}
+	if params.SlashFractionDowntime.LT(sdk.ZeroDec()) || params.SlashFractionDowntime.GT(sdk.OneDec()) {
+		return fmt.Errorf("slashing parameter SlashFractionDowntime should be between zero and one, is %s", params.SlashFractionDowntime.String())
+	}
+	return nil
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper. The
+// GenesisState will contain the params, signing infos, missed block bit
+// arrays and slashing periods found in the keeper.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	var params Params
+	keeper.paramspace.GetParamSet(ctx, &params)
+
+	signingInfos := []SigningInfo{}
+	missedBlocks := []ValidatorMissedBlocks{}
+	keeper.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info ValidatorSigningInfo) (stop bool) {
+		signingInfos = append(signingInfos, SigningInfo{
+			Address:              address,
+			ValidatorSigningInfo: info,
+		})
+
+		array := ValidatorMissedBlocks{Address: address, MissedBlocks: []MissedBlock{}}
+		keeper.iterateValidatorMissedBlockBitArray(ctx, address, func(index int64, missed bool) (stop bool) {
+			array.MissedBlocks = append(array.MissedBlocks, MissedBlock{Index: index, Missed: missed})
+			return false
+		})
+		missedBlocks = append(missedBlocks, array)
+
+		return false
+	})
+
+	slashingPeriods := []ValidatorSlashingPeriod{}
+	keeper.iterateValidatorSlashingPeriods(ctx, func(slashingPeriod ValidatorSlashingPeriod) (stop bool) {
+		slashingPeriods = append(slashingPeriods, slashingPeriod)
+		return false
+	})
+
+	return GenesisState{
+		Params:          params,
+		SigningInfos:    signingInfos,
+		MissedBlocks:    missedBlocks,
+		SlashingPeriods: slashingPeriods,
+	}
+}
@@ -0,0 +1,44 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	stake "github.com/yukimochizuki/cosmos-sdk/x/stake/types"
+)
+
+func TestExportAndInitGenesis(t *testing.T) {
+	ctx, _, _, _, keeper := createTestInput(t, DefaultParams())
+	address := sdk.ConsAddress(addrs[0])
+
+	info := NewValidatorSigningInfo(4, 3, time.Unix(2, 0).UTC(), 1)
+	keeper.setValidatorSigningInfo(ctx, address, info)
+	keeper.setValidatorMissedBlockBitArray(ctx, address, 0, false)
+	keeper.setValidatorMissedBlockBitArray(ctx, address, 2, true)
+	slashingPeriod := ValidatorSlashingPeriod{
+		ValidatorAddr: address,
+		StartHeight:   4,
+		EndHeight:     0,
+		SlashedSoFar:  sdk.NewDecWithPrec(5, 2),
+	}
+	keeper.addOrUpdateValidatorSlashingPeriod(ctx, slashingPeriod)
+
+	genesis := WriteGenesis(ctx, keeper)
+	require.Equal(t, DefaultParams(), genesis.Params)
+	require.Equal(t, []SigningInfo{{Address: address, ValidatorSigningInfo: info}}, genesis.SigningInfos)
+	require.Equal(t, []ValidatorMissedBlocks{{
+		Address:      address,
+		MissedBlocks: []MissedBlock{{Index: 0, Missed: false}, {Index: 2, Missed: true}},
+	}}, genesis.MissedBlocks)
+	require.Equal(t, []ValidatorSlashingPeriod{slashingPeriod}, genesis.SlashingPeriods)
+
+	// import the exported state into a fresh keeper
+	ctx, _, _, _, keeper = createTestInput(t, DefaultParams())
+	InitGenesis(ctx, keeper, genesis, stake.DefaultGenesisState())
+	require.Equal(t, genesis, WriteGenesis(ctx, keeper))
+	require.True(t, keeper.getValidatorMissedBlockBitArray(ctx, address, 2))
+	require.Equal(t, slashingPeriod, keeper.getValidatorSlashingPeriodForHeight(ctx, address, 10))
+}
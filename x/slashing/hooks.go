@@ -8,7 +8,7 @@ import (
 
 func (k Keeper) onValidatorBonded(ctx sdk.Context, address sdk.ConsAddress, _ sdk.ValAddress) {
 	// Update the signing info start height or create a new signing info
-	_, found := k.getValidatorSigningInfo(ctx, address)
+	_, found := k.GetValidatorSigningInfo(ctx, address)
 	if !found {
 		signingInfo := ValidatorSigningInfo{
 			StartHeight:         ctx.BlockHeight(),
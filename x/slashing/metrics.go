@@ -0,0 +1,20 @@
+package slashing
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// RegisterGauges registers the gauges of the jailed validators
+func RegisterGauges(registry sdk.GaugeRegistry, k Keeper) {
+	registry.RegisterGauge("slashing", "jailed_validators", "Number of jailed validators.",
+		func(ctx sdk.Context) float64 {
+			jailed := 0
+			k.validatorSet.IterateValidators(ctx, func(_ int64, validator sdk.Validator) (stop bool) {
+				if validator.GetJailed() {
+					jailed++
+				}
+				return false
+			})
+			return float64(jailed)
+		})
+}
@@ -1,6 +1,7 @@
 package slashing
 
 import (
+	"encoding/binary"
 	"fmt"
 	"time"
 
@@ -8,7 +9,7 @@ import (
 )
 
 // Stored by *validator* address (not operator address)
-func (k Keeper) getValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress) (info ValidatorSigningInfo, found bool) {
+func (k Keeper) GetValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress) (info ValidatorSigningInfo, found bool) {
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get(GetValidatorSigningInfoKey(address))
 	if bz == nil {
@@ -27,6 +28,21 @@ func (k Keeper) setValidatorSigningInfo(ctx sdk.Context, address sdk.ConsAddress
 	store.Set(GetValidatorSigningInfoKey(address), bz)
 }
 
+// IterateValidatorSigningInfos iterates over the stored ValidatorSigningInfo
+func (k Keeper) IterateValidatorSigningInfos(ctx sdk.Context, handler func(address sdk.ConsAddress, info ValidatorSigningInfo) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		address := sdk.ConsAddress(iter.Key()[1:])
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &info)
+		if handler(address, info) {
+			break
+		}
+	}
+}
+
 // Stored by *validator* address (not operator address)
 func (k Keeper) getValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress, index int64) (missed bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -47,6 +63,22 @@ func (k Keeper) setValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.Con
 	store.Set(GetValidatorMissedBlockBitArrayKey(address, index), bz)
 }
 
+// Stored by *validator* address (not operator address)
+func (k Keeper) iterateValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress, handler func(index int64, missed bool) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetValidatorMissedBlockBitArrayPrefixKey(address)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		index := int64(binary.LittleEndian.Uint64(iter.Key()[len(prefix):]))
+		var missed bool
+		k.cdc.MustUnmarshalBinary(iter.Value(), &missed)
+		if handler(index, missed) {
+			break
+		}
+	}
+}
+
 // Stored by *validator* address (not operator address)
 func (k Keeper) clearValidatorMissedBlockBitArray(ctx sdk.Context, address sdk.ConsAddress) {
 	store := ctx.KVStore(k.storeKey)
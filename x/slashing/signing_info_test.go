@@ -11,7 +11,7 @@ import (
 
 func TestGetSetValidatorSigningInfo(t *testing.T) {
 	ctx, _, _, _, keeper := createTestInput(t, DefaultParams())
-	info, found := keeper.getValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[0]))
+	info, found := keeper.GetValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[0]))
 	require.False(t, found)
 	newInfo := ValidatorSigningInfo{
 		StartHeight:         int64(4),
@@ -20,7 +20,7 @@ func TestGetSetValidatorSigningInfo(t *testing.T) {
 		MissedBlocksCounter: int64(10),
 	}
 	keeper.setValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[0]), newInfo)
-	info, found = keeper.getValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[0]))
+	info, found = keeper.GetValidatorSigningInfo(ctx, sdk.ConsAddress(addrs[0]))
 	require.True(t, found)
 	require.Equal(t, info.StartHeight, int64(4))
 	require.Equal(t, info.IndexOffset, int64(3))
@@ -0,0 +1,41 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the slashing store and
+// returns it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.HasPrefix(kv.Key, slashing.ValidatorSigningInfoKey):
+		var info slashing.ValidatorSigningInfo
+		cdc.MustUnmarshalBinary(kv.Value, &info)
+		return info.HumanReadableString()
+
+	case bytes.HasPrefix(kv.Key, slashing.ValidatorMissedBlockBitArrayKey):
+		var missed bool
+		cdc.MustUnmarshalBinary(kv.Value, &missed)
+		return fmt.Sprintf("missed: %v", missed)
+
+	case bytes.HasPrefix(kv.Key, slashing.ValidatorSlashingPeriodKey):
+		var slashingPeriod slashing.ValidatorSlashingPeriodValue
+		cdc.MustUnmarshalBinary(kv.Value, &slashingPeriod)
+		return fmt.Sprintf("%v", slashingPeriod)
+
+	case bytes.HasPrefix(kv.Key, slashing.AddrPubkeyRelationKey):
+		var pubKey crypto.PubKey
+		cdc.MustUnmarshalBinary(kv.Value, &pubKey)
+		return fmt.Sprintf("%X", pubKey.Bytes())
+
+	default:
+		panic(fmt.Sprintf("invalid slashing key %X", kv.Key))
+	}
+}
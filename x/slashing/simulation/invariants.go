@@ -1,15 +1,39 @@
 package simulation
 
 import (
+	"fmt"
+
 	"github.com/yukimochizuki/cosmos-sdk/baseapp"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
 
-// TODO Any invariants to check here?
 // AllInvariants tests all slashing invariants
-func AllInvariants() simulation.Invariant {
-	return func(_ *baseapp.BaseApp, _ abci.Header) error {
-		return nil
+// Currently: signing infos
+func AllInvariants(k slashing.Keeper, sk stake.Keeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp, header abci.Header) error {
+		return SigningInfosInvariant(k, sk)(app, header)
+	}
+}
+
+// SigningInfosInvariant checks that every bonded validator has a signing info
+func SigningInfosInvariant(k slashing.Keeper, sk stake.Keeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp, header abci.Header) error {
+		ctx := app.NewContext(false, header)
+		var err error
+		sk.IterateValidatorsBonded(ctx, func(_ int64, validator sdk.Validator) bool {
+			consAddr := validator.GetConsAddr()
+			_, found := k.GetValidatorSigningInfo(ctx, consAddr)
+			if !found {
+				err = fmt.Errorf("signing info invariance:\n\tbonded validator %v"+
+					"\n\thas no signing info for %v", validator.GetOperator(), consAddr)
+				return true
+			}
+			return false
+		})
+		return err
 	}
 }
@@ -8,16 +8,32 @@ import (
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 )
 
-// SimulateMsgUnjail
-func SimulateMsgUnjail(k slashing.Keeper) simulation.Operation {
-	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (action string, fOp []simulation.FutureOperation, err error) {
-		acc := simulation.RandomAcc(r, accs)
-		address := sdk.ValAddress(acc.Address)
+// SimulateMsgUnjail simulates a validator unjailing itself after its
+// downtime jail period, picking a random jailed validator which is out of
+// jail
+func SimulateMsgUnjail(k slashing.Keeper, sk stake.Keeper) simulation.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accs []simulation.Account, event func(string)) (opMsg simulation.OperationMsg, fOp []simulation.FutureOperation, err error) {
+		var jailed []sdk.ValAddress
+		for _, validator := range sk.GetAllValidators(ctx) {
+			if !validator.GetJailed() {
+				continue
+			}
+			info, found := k.GetValidatorSigningInfo(ctx, validator.GetConsAddr())
+			if !found || ctx.BlockHeader().Time.Before(info.JailedUntil) {
+				continue
+			}
+			jailed = append(jailed, validator.GetOperator())
+		}
+		if len(jailed) == 0 {
+			return simulation.NoOpMsg("slashing"), nil, nil
+		}
+		address := jailed[r.Intn(len(jailed))]
 		msg := slashing.NewMsgUnjail(address)
 		if msg.ValidateBasic() != nil {
-			return "", nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+			return simulation.NoOpMsg("slashing"), nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
 		}
 		ctx, write := ctx.CacheContext()
 		result := slashing.NewHandler(k)(ctx, msg)
@@ -25,7 +41,7 @@ func SimulateMsgUnjail(k slashing.Keeper) simulation.Operation {
 			write()
 		}
 		event(fmt.Sprintf("slashing/MsgUnjail/%v", result.IsOK()))
-		action = fmt.Sprintf("TestMsgUnjail: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
-		return action, nil, nil
+		opMsg = simulation.NewOperationMsg(msg, result.IsOK(), "")
+		return opMsg, nil, nil
 	}
 }
@@ -0,0 +1,24 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+	"github.com/yukimochizuki/cosmos-sdk/x/slashing"
+)
+
+// RandomParams returns random slashing params, down to signed blocks windows
+// small enough for validators to be jailed within a few blocks
+func RandomParams(r *rand.Rand) slashing.Params {
+	return slashing.Params{
+		MaxEvidenceAge:           simulation.RandDurationBetween(r, time.Minute, 24*time.Hour),
+		SignedBlocksWindow:       int64(simulation.RandIntBetween(r, 5, 1000)),
+		MinSignedPerWindow:       simulation.RandDecBetween(r, 5, 95, 2),
+		DoubleSignUnbondDuration: simulation.RandDurationBetween(r, time.Minute, time.Hour),
+		DowntimeUnbondDuration:   simulation.RandDurationBetween(r, time.Minute, time.Hour),
+		SlashFractionDoubleSign:  sdk.OneDec().Quo(sdk.NewDec(int64(simulation.RandIntBetween(r, 10, 50)))),
+		SlashFractionDowntime:    sdk.OneDec().Quo(sdk.NewDec(int64(simulation.RandIntBetween(r, 10, 200)))),
+	}
+}
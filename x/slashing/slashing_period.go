@@ -65,6 +65,20 @@ func (k Keeper) addOrUpdateValidatorSlashingPeriod(ctx sdk.Context, slashingPeri
 	store.Set(GetValidatorSlashingPeriodKey(slashingPeriod.ValidatorAddr, slashingPeriod.StartHeight), bz)
 }
 
+// Iterate over all slashing periods of all validators, ordered by validator
+// address and start height
+func (k Keeper) iterateValidatorSlashingPeriods(ctx sdk.Context, handler func(slashingPeriod ValidatorSlashingPeriod) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorSlashingPeriodKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		slashingPeriod := k.unmarshalSlashingPeriodKeyValue(iterator.Key(), iterator.Value())
+		if handler(slashingPeriod) {
+			break
+		}
+	}
+}
+
 // Unmarshal key/value into a ValidatorSlashingPeriod
 func (k Keeper) unmarshalSlashingPeriodKeyValue(key []byte, value []byte) ValidatorSlashingPeriod {
 	var slashingPeriodValue ValidatorSlashingPeriodValue
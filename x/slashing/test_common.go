@@ -91,7 +91,7 @@ func createTestInput(t *testing.T, defaults Params) (sdk.Context, bank.Keeper, s
 	sk = sk.WithHooks(keeper.Hooks())
 
 	require.NotPanics(t, func() {
-		InitGenesis(ctx, keeper, GenesisState{defaults}, genesis)
+		InitGenesis(ctx, keeper, GenesisState{Params: defaults}, genesis)
 	})
 
 	return ctx, ck, sk, paramstore, keeper
@@ -5,10 +5,12 @@ import (
 	"strings"
 
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/tx"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake/tags"
 
 	"github.com/gorilla/mux"
@@ -16,92 +18,107 @@ import (
 
 const storeName = "stake"
 
-func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
-
-	// Get all delegations from a delegator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/delegations",
-		delegatorDelegationsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all unbonding delegations from a delegator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/unbonding_delegations",
-		delegatorUnbondingDelegationsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all redelegations from a delegator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/redelegations",
-		delegatorRedelegationsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all staking txs (i.e msgs) from a delegator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/txs",
-		delegatorTxsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Query all validators that a delegator is bonded to
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/validators",
-		delegatorValidatorsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Query a validator that a delegator is bonded to
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/validators/{validatorAddr}",
-		delegatorValidatorHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Query a delegation between a delegator and a validator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/delegations/{validatorAddr}",
-		delegationHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Query all unbonding delegations between a delegator and a validator
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/unbonding_delegations/{validatorAddr}",
-		unbondingDelegationHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all validators
-	r.HandleFunc(
-		"/stake/validators",
-		validatorsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get a single validator info
-	r.HandleFunc(
-		"/stake/validators/{validatorAddr}",
-		validatorHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all unbonding delegations from a validator
-	r.HandleFunc(
-		"/stake/validators/{validatorAddr}/unbonding_delegations",
-		validatorUnbondingDelegationsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get all outgoing redelegations from a validator
-	r.HandleFunc(
-		"/stake/validators/{validatorAddr}/redelegations",
-		validatorRedelegationsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get the current state of the staking pool
-	r.HandleFunc(
-		"/stake/pool",
-		poolHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
-	// Get the current staking parameter values
-	r.HandleFunc(
-		"/stake/parameters",
-		paramsHandlerFn(cliCtx, cdc),
-	).Methods("GET")
-
+func registerQueryRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec) {
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/delegations",
+		Summary:  "Get all delegations from a delegator",
+		Response: []stake.Delegation{},
+	}, delegatorDelegationsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/unbonding_delegations",
+		Summary:  "Get all unbonding delegations from a delegator",
+		Response: []stake.UnbondingDelegation{},
+	}, delegatorUnbondingDelegationsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/redelegations",
+		Summary:  "Get all redelegations from a delegator",
+		Response: []stake.Redelegation{},
+	}, delegatorRedelegationsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:  "GET",
+		Path:    "/stake/delegators/{delegatorAddr}/txs",
+		Summary: "Get all staking txs (i.e msgs) from a delegator",
+		Query: []openapi.Param{
+			{Name: "type", Description: "Space separated types of the txs: bond, unbond or redelegate"},
+		},
+		Response: []tx.Info{},
+	}, delegatorTxsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/validators",
+		Summary:  "Query all validators that a delegator is bonded to",
+		Response: []stake.Validator{},
+	}, delegatorValidatorsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/validators/{validatorAddr}",
+		Summary:  "Query a validator that a delegator is bonded to",
+		Response: stake.Validator{},
+	}, delegatorValidatorHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/delegations/{validatorAddr}",
+		Summary:  "Query a delegation between a delegator and a validator",
+		Response: stake.Delegation{},
+	}, delegationHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/delegators/{delegatorAddr}/unbonding_delegations/{validatorAddr}",
+		Summary:  "Query all unbonding delegations between a delegator and a validator",
+		Response: stake.UnbondingDelegation{},
+	}, unbondingDelegationHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/validators",
+		Summary:  "Get all validators",
+		Response: []stake.Validator{},
+	}, validatorsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/validators/{validatorAddr}",
+		Summary:  "Get a single validator info",
+		Response: stake.Validator{},
+	}, validatorHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/validators/{validatorAddr}/unbonding_delegations",
+		Summary:  "Get all unbonding delegations from a validator",
+		Response: []stake.UnbondingDelegation{},
+	}, validatorUnbondingDelegationsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/validators/{validatorAddr}/redelegations",
+		Summary:  "Get all outgoing redelegations from a validator",
+		Response: []stake.Redelegation{},
+	}, validatorRedelegationsHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/pool",
+		Summary:  "Get the current state of the staking pool",
+		Response: stake.Pool{},
+	}, poolHandlerFn(cliCtx, cdc))
+
+	r.HandleFunc(openapi.Route{
+		Method:   "GET",
+		Path:     "/stake/parameters",
+		Summary:  "Get the current staking parameter values",
+		Response: stake.Params{},
+	}, paramsHandlerFn(cliCtx, cdc))
 }
 
 // HTTP request handler to query a delegator delegations
@@ -2,28 +2,29 @@ package rest
 
 import (
 	"bytes"
-	"io/ioutil"
 	"net/http"
 
-	"github.com/yukimochizuki/cosmos-sdk/client"
 	"github.com/yukimochizuki/cosmos-sdk/client/context"
+	"github.com/yukimochizuki/cosmos-sdk/client/openapi"
 	"github.com/yukimochizuki/cosmos-sdk/client/utils"
 	"github.com/yukimochizuki/cosmos-sdk/codec"
 	"github.com/yukimochizuki/cosmos-sdk/crypto/keys"
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
-	authtxb "github.com/yukimochizuki/cosmos-sdk/x/auth/client/txbuilder"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 
-	"github.com/gorilla/mux"
-
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 )
 
-func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
-	r.HandleFunc(
-		"/stake/delegators/{delegatorAddr}/delegations",
-		delegationsRequestHandlerFn(cdc, kb, cliCtx),
-	).Methods("POST")
+func registerTxRoutes(cliCtx context.CLIContext, r *openapi.Router, cdc *codec.Codec, kb keys.Keybase) {
+	r.HandleFunc(openapi.Route{
+		Method:      "POST",
+		Path:        "/stake/delegators/{delegatorAddr}/delegations",
+		Summary:     "Submit delegations, unbondings and redelegations",
+		Description: "Each of the delegations, unbondings and redelegations is sent in its own tx.",
+		Query:       utils.TxQueryParams,
+		Request:     EditDelegationsReq{},
+		Response:    []ctypes.ResultBroadcastTxCommit{},
+	}, delegationsRequestHandlerFn(cdc, kb, cliCtx))
 }
 
 type (
@@ -58,20 +59,13 @@ type (
 // TODO: Split this up into several smaller functions, and remove the above nolint
 // TODO: use sdk.ValAddress instead of sdk.AccAddress for validators in messages
 // TODO: Seriously consider how to refactor...do we need to make it multiple txs?
-// If not, we can just use CompleteAndBroadcastTxREST.
+// If not, we can just use CompleteAndBroadcastTxREST instead of
+// CompleteAndBroadcastTxsREST.
 func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var req EditDelegationsReq
-
-		body, err := ioutil.ReadAll(r.Body)
+		err := utils.ReadRESTReq(w, r, cdc, &req)
 		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		err = cdc.UnmarshalJSON(body, &req)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
 			return
 		}
 
@@ -191,77 +185,11 @@ func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx conte
 			i++
 		}
 
-		simulateGas, gas, err := client.ReadGasFlag(baseReq.Gas)
-		if err != nil {
-			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		adjustment, ok := utils.ParseFloat64OrReturnBadRequest(w, baseReq.GasAdjustment, client.DefaultGasAdjustment)
-		if !ok {
-			return
-		}
-
-		txBldr := authtxb.TxBuilder{
-			Codec:         cdc,
-			Gas:           gas,
-			GasAdjustment: adjustment,
-			SimulateGas:   simulateGas,
-			ChainID:       baseReq.ChainID,
-		}
-
-		// sign messages
-		signedTxs := make([][]byte, len(messages[:]))
+		// each message is sent in its own tx
+		txsMsgs := make([][]sdk.Msg, len(messages))
 		for i, msg := range messages {
-			// increment sequence for each message
-			txBldr = txBldr.WithAccountNumber(baseReq.AccountNumber)
-			txBldr = txBldr.WithSequence(baseReq.Sequence)
-
-			baseReq.Sequence++
-
-			if utils.HasDryRunArg(r) || txBldr.SimulateGas {
-				newBldr, err := utils.EnrichCtxWithGas(txBldr, cliCtx, baseReq.Name, []sdk.Msg{msg})
-				if err != nil {
-					utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-					return
-				}
-
-				if utils.HasDryRunArg(r) {
-					utils.WriteSimulationResponse(w, newBldr.Gas)
-					return
-				}
-
-				txBldr = newBldr
-			}
-
-			if utils.HasGenerateOnlyArg(r) {
-				utils.WriteGenerateStdTxResponse(w, txBldr, []sdk.Msg{msg})
-				return
-			}
-
-			txBytes, err := txBldr.BuildAndSign(baseReq.Name, baseReq.Password, []sdk.Msg{msg})
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
-				return
-			}
-
-			signedTxs[i] = txBytes
+			txsMsgs[i] = []sdk.Msg{msg}
 		}
-
-		// send
-		// XXX the operation might not be atomic if a tx fails
-		//     should we have a sdk.MultiMsg type to make sending atomic?
-		results := make([]*ctypes.ResultBroadcastTxCommit, len(signedTxs[:]))
-		for i, txBytes := range signedTxs {
-			res, err := cliCtx.BroadcastTx(txBytes)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
-				return
-			}
-
-			results[i] = res
-		}
-
-		utils.PostProcessResponse(w, cdc, results, cliCtx.Indent)
+		utils.CompleteAndBroadcastTxsREST(w, r, cliCtx, baseReq, txsMsgs, cdc)
 	}
 }
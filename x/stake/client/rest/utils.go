@@ -11,6 +11,7 @@ import (
 	sdk "github.com/yukimochizuki/cosmos-sdk/types"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake/tags"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake/types"
 	"github.com/gorilla/mux"
 
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
@@ -73,7 +74,14 @@ func queryBonds(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string) ht
 			return
 		}
 
-		res, err := cliCtx.QueryWithData(endpoint, bz)
+		var res []byte
+		proved := false
+		if !cliCtx.TrustNode {
+			res, proved, err = queryProved(cliCtx, cdc, endpoint, delegatorAddr, validatorAddr)
+		}
+		if !proved {
+			res, err = cliCtx.QueryWithData(endpoint, bz)
+		}
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -103,7 +111,14 @@ func queryDelegator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			return
 		}
 
-		res, err := cliCtx.QueryWithData(endpoint, bz)
+		var res []byte
+		proved := false
+		if !cliCtx.TrustNode {
+			res, proved, err = queryProved(cliCtx, cdc, endpoint, delegatorAddr, nil)
+		}
+		if !proved {
+			res, err = cliCtx.QueryWithData(endpoint, bz)
+		}
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -133,7 +148,14 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 			return
 		}
 
-		res, err := cliCtx.QueryWithData(endpoint, bz)
+		var res []byte
+		proved := false
+		if !cliCtx.TrustNode {
+			res, proved, err = queryProved(cliCtx, cdc, endpoint, nil, validatorAddr)
+		}
+		if !proved {
+			res, err = cliCtx.QueryWithData(endpoint, bz)
+		}
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
 			return
@@ -141,3 +163,87 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+// queryProved rebuilds the response of a custom stake query from store reads,
+// which are verified against the app hash, so that an untrusted node can't lie
+// about it. ok is false if the query can't be rebuilt.
+func queryProved(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string,
+	delegatorAddr sdk.AccAddress, validatorAddr sdk.ValAddress) (res []byte, ok bool, err error) {
+
+	var result interface{}
+	switch endpoint {
+	case "custom/stake/delegation":
+		key := stake.GetDelegationKey(delegatorAddr, validatorAddr)
+		bz, err := cliCtx.QueryStore(key, storeName)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(bz) == 0 {
+			return nil, true, stake.ErrNoDelegation(stake.DefaultCodespace)
+		}
+		if result, err = types.UnmarshalDelegation(cdc, key, bz); err != nil {
+			return nil, true, err
+		}
+
+	case "custom/stake/unbondingDelegation":
+		key := stake.GetUBDKey(delegatorAddr, validatorAddr)
+		bz, err := cliCtx.QueryStore(key, storeName)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(bz) == 0 {
+			return nil, true, stake.ErrNoUnbondingDelegation(stake.DefaultCodespace)
+		}
+		if result, err = types.UnmarshalUBD(cdc, key, bz); err != nil {
+			return nil, true, err
+		}
+
+	case "custom/stake/delegatorDelegations":
+		kvs, err := cliCtx.QuerySubspace(stake.GetDelegationsKey(delegatorAddr), storeName)
+		if err != nil {
+			return nil, true, err
+		}
+		var delegations []stake.Delegation
+		for _, kv := range kvs {
+			delegation, err := types.UnmarshalDelegation(cdc, kv.Key, kv.Value)
+			if err != nil {
+				return nil, true, err
+			}
+			delegations = append(delegations, delegation)
+		}
+		result = delegations
+
+	case "custom/stake/delegatorUnbondingDelegations":
+		kvs, err := cliCtx.QuerySubspace(stake.GetUBDsKey(delegatorAddr), storeName)
+		if err != nil {
+			return nil, true, err
+		}
+		var ubds []stake.UnbondingDelegation
+		for _, kv := range kvs {
+			ubd, err := types.UnmarshalUBD(cdc, kv.Key, kv.Value)
+			if err != nil {
+				return nil, true, err
+			}
+			ubds = append(ubds, ubd)
+		}
+		result = ubds
+
+	case "custom/stake/validator":
+		bz, err := cliCtx.QueryStore(stake.GetValidatorKey(validatorAddr), storeName)
+		if err != nil {
+			return nil, true, err
+		}
+		if len(bz) == 0 {
+			return nil, true, stake.ErrNoValidatorFound(stake.DefaultCodespace)
+		}
+		if result, err = types.UnmarshalValidator(cdc, validatorAddr, bz); err != nil {
+			return nil, true, err
+		}
+
+	default:
+		return nil, false, nil
+	}
+
+	res, err = codec.MarshalJSONIndent(cdc, result)
+	return res, true, err
+}
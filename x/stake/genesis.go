@@ -15,7 +15,8 @@ import (
 // initializes the IntraTxCounter. For each validator in data, it sets that
 // validator in the keeper along with manually setting the indexes. In
 // addition, it also sets any delegations found in data. Finally, it updates
-// the bonded validators.
+// the bonded validators, unless the state was exported from a running chain,
+// in which case the validator set of its last block is restored.
 // Returns final validator set after applying all declaration and delegations
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res []abci.ValidatorUpdate, err error) {
 
@@ -26,22 +27,35 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 
 	keeper.SetPool(ctx, data.Pool)
 	keeper.SetParams(ctx, data.Params)
+	keeper.SetIntraTxCounter(ctx, data.IntraTxCounter)
 
 	for i, validator := range data.Validators {
-		validator.BondIntraTxCounter = int16(i) // set the intra-tx counter to the order the validators are presented
+		// set the intra-tx counter to the order the validators are presented, if necessary
+		if !data.Exported {
+			validator.BondIntraTxCounter = int16(i)
+		}
 		keeper.SetValidator(ctx, validator)
 
-		if validator.Tokens.IsZero() {
-			return res, errors.Errorf("genesis validator cannot have zero pool shares, validator: %v", validator)
-		}
-		if validator.DelegatorShares.IsZero() {
-			return res, errors.Errorf("genesis validator cannot have zero delegator shares, validator: %v", validator)
+		// unbonding validators of an exported state may have been fully
+		// unbonded from while waiting for their unbonding period to end
+		if validator.Status != sdk.Unbonding {
+			if validator.Tokens.IsZero() {
+				return res, errors.Errorf("genesis validator cannot have zero pool shares, validator: %v", validator)
+			}
+			if validator.DelegatorShares.IsZero() {
+				return res, errors.Errorf("genesis validator cannot have zero delegator shares, validator: %v", validator)
+			}
 		}
 
 		// Manually set indices for the first time
 		keeper.SetValidatorByConsAddr(ctx, validator)
 		keeper.SetValidatorByPowerIndex(ctx, validator, data.Pool)
 		keeper.OnValidatorCreated(ctx, validator.OperatorAddr)
+
+		// Set timeslice if necessary
+		if validator.Status == sdk.Unbonding {
+			keeper.InsertValidatorQueue(ctx, validator)
+		}
 	}
 
 	for _, delegation := range data.Bonds {
@@ -49,24 +63,74 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		keeper.OnDelegationCreated(ctx, delegation.DelegatorAddr, delegation.ValidatorAddr)
 	}
 
+	for _, ubd := range data.UnbondingDelegations {
+		keeper.SetUnbondingDelegation(ctx, ubd)
+		keeper.InsertUnbondingQueue(ctx, ubd)
+	}
+
+	for _, red := range data.Redelegations {
+		keeper.SetRedelegation(ctx, red)
+		keeper.InsertRedelegationQueue(ctx, red)
+	}
+
+	// an exported state holds the validator set of its last block, which
+	// mustn't be updated as the updates were already applied
+	if data.Exported {
+		keeper.SetLastTotalPower(ctx, data.LastTotalPower)
+		for _, lv := range data.LastValidatorPowers {
+			keeper.SetLastValidatorPower(ctx, lv.Address, lv.Power)
+			validator, found := keeper.GetValidator(ctx, lv.Address)
+			if !found {
+				return res, errors.Errorf("last validator power of an unknown validator: %s", lv.Address)
+			}
+			update := validator.ABCIValidatorUpdate()
+			update.Power = lv.Power.Int64() // keep the power of the last block
+			res = append(res, update)
+		}
+		return
+	}
+
 	res = keeper.ApplyAndReturnValidatorSetUpdates(ctx)
 	return
 }
 
 // WriteGenesis returns a GenesisState for a given context and keeper. The
-// GenesisState will contain the pool, params, validators, and bonds found in
-// the keeper.
+// GenesisState will contain the pool, params, last validator set, validators,
+// bonds, unbonding delegations and redelegations found in the keeper.
 func WriteGenesis(ctx sdk.Context, keeper Keeper) types.GenesisState {
 	pool := keeper.GetPool(ctx)
 	params := keeper.GetParams(ctx)
+	intraTxCounter := keeper.GetIntraTxCounter(ctx)
+	lastTotalPower := keeper.GetLastTotalPower(ctx)
+	var lastValidatorPowers []types.LastValidatorPower
+	keeper.IterateLastValidatorPowers(ctx, func(addr sdk.ValAddress, power sdk.Int) (stop bool) {
+		lastValidatorPowers = append(lastValidatorPowers, types.LastValidatorPower{Address: addr, Power: power})
+		return false
+	})
 	validators := keeper.GetAllValidators(ctx)
 	bonds := keeper.GetAllDelegations(ctx)
+	var unbondingDelegations []types.UnbondingDelegation
+	keeper.IterateUnbondingDelegations(ctx, func(_ int64, ubd types.UnbondingDelegation) (stop bool) {
+		unbondingDelegations = append(unbondingDelegations, ubd)
+		return false
+	})
+	var redelegations []types.Redelegation
+	keeper.IterateRedelegations(ctx, func(_ int64, red types.Redelegation) (stop bool) {
+		redelegations = append(redelegations, red)
+		return false
+	})
 
 	return types.GenesisState{
-		Pool:       pool,
-		Params:     params,
-		Validators: validators,
-		Bonds:      bonds,
+		Pool:                 pool,
+		Params:               params,
+		IntraTxCounter:       intraTxCounter,
+		LastTotalPower:       lastTotalPower,
+		LastValidatorPowers:  lastValidatorPowers,
+		Validators:           validators,
+		Bonds:                bonds,
+		UnbondingDelegations: unbondingDelegations,
+		Redelegations:        redelegations,
+		Exported:             true,
 	}
 }
 
@@ -118,10 +182,10 @@ func validateGenesisStateValidators(validators []types.Validator) (err error) {
 		if val.Jailed && val.Status == sdk.Bonded {
 			return fmt.Errorf("validator is bonded and jailed in genesis state: moniker %v, Address %v", val.Description.Moniker, val.ConsAddress())
 		}
-		if val.Tokens.IsZero() {
+		if val.Tokens.IsZero() && val.Status != sdk.Unbonding {
 			return fmt.Errorf("genesis validator cannot have zero pool shares, validator: %v", val)
 		}
-		if val.DelegatorShares.IsZero() {
+		if val.DelegatorShares.IsZero() && val.Status != sdk.Unbonding {
 			return fmt.Errorf("genesis validator cannot have zero delegator shares, validator: %v", val)
 		}
 		addrMap[strKey] = true
@@ -3,6 +3,7 @@ package stake
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
@@ -41,6 +42,25 @@ func TestInitGenesis(t *testing.T) {
 	validators[1].DelegatorShares = sdk.OneDec()
 
 	genesisState = types.NewGenesisState(pool, params, validators, delegations)
+	genesisState.UnbondingDelegations = []UnbondingDelegation{{
+		DelegatorAddr:  keep.Addrs[2],
+		ValidatorAddr:  sdk.ValAddress(keep.Addrs[0]),
+		CreationHeight: 10,
+		MinTime:        time.Unix(1000, 0).UTC(),
+		InitialBalance: sdk.NewInt64Coin(params.BondDenom, 5),
+		Balance:        sdk.NewInt64Coin(params.BondDenom, 5),
+	}}
+	genesisState.Redelegations = []Redelegation{{
+		DelegatorAddr:    keep.Addrs[2],
+		ValidatorSrcAddr: sdk.ValAddress(keep.Addrs[0]),
+		ValidatorDstAddr: sdk.ValAddress(keep.Addrs[1]),
+		CreationHeight:   10,
+		MinTime:          time.Unix(1000, 0).UTC(),
+		InitialBalance:   sdk.NewInt64Coin(params.BondDenom, 5),
+		Balance:          sdk.NewInt64Coin(params.BondDenom, 5),
+		SharesSrc:        sdk.NewDec(5),
+		SharesDst:        sdk.NewDec(5),
+	}}
 	vals, err := InitGenesis(ctx, keeper, genesisState)
 	require.NoError(t, err)
 
@@ -48,6 +68,10 @@ func TestInitGenesis(t *testing.T) {
 	require.Equal(t, genesisState.Pool, actualGenesis.Pool)
 	require.Equal(t, genesisState.Params, actualGenesis.Params)
 	require.Equal(t, genesisState.Bonds, actualGenesis.Bonds)
+	require.Equal(t, genesisState.UnbondingDelegations, actualGenesis.UnbondingDelegations)
+	require.Equal(t, genesisState.Redelegations, actualGenesis.Redelegations)
+	require.Equal(t, 1, len(keeper.GetUnbondingQueueTimeSlice(ctx, time.Unix(1000, 0).UTC())))
+	require.Equal(t, 1, len(keeper.GetRedelegationQueueTimeSlice(ctx, time.Unix(1000, 0).UTC())))
 	require.EqualValues(t, keeper.GetAllValidators(ctx), actualGenesis.Validators)
 
 	// now make sure the validators are bonded and intra-tx counters are correct
@@ -67,6 +91,15 @@ func TestInitGenesis(t *testing.T) {
 	}
 
 	require.Equal(t, abcivals, vals)
+
+	// importing the exported state restores the validator set as is
+	ctx2, _, keeper2 := keep.CreateTestInput(t, false, 1000)
+	exportedVals, err := InitGenesis(ctx2, keeper2, actualGenesis)
+	require.NoError(t, err)
+	require.Equal(t, vals, exportedVals)
+	require.Equal(t, keeper.GetLastTotalPower(ctx), keeper2.GetLastTotalPower(ctx2))
+	require.Equal(t, keeper.GetIntraTxCounter(ctx), keeper2.GetIntraTxCounter(ctx2))
+	require.Equal(t, actualGenesis, WriteGenesis(ctx2, keeper2))
 }
 
 func TestInitGenesisLargeValidatorSet(t *testing.T) {
@@ -134,6 +167,12 @@ func TestValidateGenesis(t *testing.T) {
 			(*data).Validators = genValidators1
 			(*data).Validators[0].DelegatorShares = sdk.ZeroDec()
 		}, true},
+		{"unbonding validator without shares", func(data *types.GenesisState) {
+			(*data).Validators = genValidators1
+			(*data).Validators[0].Status = sdk.Unbonding
+			(*data).Validators[0].Tokens = sdk.ZeroDec()
+			(*data).Validators[0].DelegatorShares = sdk.ZeroDec()
+		}, false},
 		{"jailed and bonded validator", func(data *types.GenesisState) {
 			(*data).Validators = genValidators1
 			(*data).Validators[0].Jailed = true
@@ -196,13 +196,16 @@ func (k Keeper) UnbondingQueueIterator(ctx sdk.Context, endTime time.Time) sdk.I
 func (k Keeper) DequeueAllMatureUnbondingQueue(ctx sdk.Context, currTime time.Time) (matureUnbonds []types.DVPair) {
 	store := ctx.KVStore(k.storeKey)
 	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	unbondingTimesliceIterator := k.UnbondingQueueIterator(ctx, ctx.BlockHeader().Time)
+	endTime := ctx.BlockHeader().Time
+	unbondingTimesliceIterator := k.UnbondingQueueIterator(ctx, endTime)
 	for ; unbondingTimesliceIterator.Valid(); unbondingTimesliceIterator.Next() {
 		timeslice := []types.DVPair{}
 		k.cdc.MustUnmarshalBinary(unbondingTimesliceIterator.Value(), &timeslice)
 		matureUnbonds = append(matureUnbonds, timeslice...)
-		store.Delete(unbondingTimesliceIterator.Key())
 	}
+	unbondingTimesliceIterator.Close()
+
+	store.DeleteRange(UnbondingQueueKey, sdk.InclusiveEndBytes(GetUnbondingDelegationTimeKey(endTime)))
 	return matureUnbonds
 }
 
@@ -273,6 +276,21 @@ func (k Keeper) HasReceivingRedelegation(ctx sdk.Context,
 	return found
 }
 
+// iterate through all redelegations
+func (k Keeper) IterateRedelegations(ctx sdk.Context, fn func(index int64, red types.Redelegation) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, RedelegationKey)
+	defer iterator.Close()
+
+	for i := int64(0); iterator.Valid(); iterator.Next() {
+		red := types.MustUnmarshalRED(k.cdc, iterator.Key(), iterator.Value())
+		if stop := fn(i, red); stop {
+			break
+		}
+		i++
+	}
+}
+
 // set a redelegation and associated index
 func (k Keeper) SetRedelegation(ctx sdk.Context, red types.Redelegation) {
 	store := ctx.KVStore(k.storeKey)
@@ -333,13 +351,16 @@ func (k Keeper) RedelegationQueueIterator(ctx sdk.Context, endTime time.Time) sd
 func (k Keeper) DequeueAllMatureRedelegationQueue(ctx sdk.Context, currTime time.Time) (matureRedelegations []types.DVVTriplet) {
 	store := ctx.KVStore(k.storeKey)
 	// gets an iterator for all timeslices from time 0 until the current Blockheader time
-	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, ctx.BlockHeader().Time)
+	endTime := ctx.BlockHeader().Time
+	redelegationTimesliceIterator := k.RedelegationQueueIterator(ctx, endTime)
 	for ; redelegationTimesliceIterator.Valid(); redelegationTimesliceIterator.Next() {
 		timeslice := []types.DVVTriplet{}
 		k.cdc.MustUnmarshalBinary(redelegationTimesliceIterator.Value(), &timeslice)
 		matureRedelegations = append(matureRedelegations, timeslice...)
-		store.Delete(redelegationTimesliceIterator.Key())
 	}
+	redelegationTimesliceIterator.Close()
+
+	store.DeleteRange(RedelegationQueueKey, sdk.InclusiveEndBytes(GetRedelegationTimeKey(endTime)))
 	return matureRedelegations
 }
 
@@ -118,6 +118,21 @@ func (k Keeper) DeleteLastValidatorPower(ctx sdk.Context, operator sdk.ValAddres
 	store.Delete(GetLastValidatorPowerKey(operator))
 }
 
+// Iterate over last validator powers.
+func (k Keeper) IterateLastValidatorPowers(ctx sdk.Context, handler func(operator sdk.ValAddress, power sdk.Int) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, LastValidatorPowerKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addr := sdk.ValAddress(AddressFromLastValidatorPowerKey(iter.Key()))
+		var power sdk.Int
+		k.cdc.MustUnmarshalBinary(iter.Value(), &power)
+		if handler(addr, power) {
+			break
+		}
+	}
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
@@ -328,27 +328,24 @@ func (k Keeper) GetAllMatureValidatorQueue(ctx sdk.Context, currTime time.Time)
 		k.cdc.MustUnmarshalBinary(validatorTimesliceIterator.Value(), &timeslice)
 		matureValsAddrs = append(matureValsAddrs, timeslice...)
 	}
+	validatorTimesliceIterator.Close()
 	return matureValsAddrs
 }
 
 // Unbonds all the unbonding validators that have finished their unbonding period
 func (k Keeper) UnbondAllMatureValidatorQueue(ctx sdk.Context) {
 	store := ctx.KVStore(k.storeKey)
-	validatorTimesliceIterator := k.ValidatorQueueIterator(ctx, ctx.BlockHeader().Time)
-	for ; validatorTimesliceIterator.Valid(); validatorTimesliceIterator.Next() {
-		timeslice := []sdk.ValAddress{}
-		k.cdc.MustUnmarshalBinary(validatorTimesliceIterator.Value(), &timeslice)
-		for _, valAddr := range timeslice {
-			val, found := k.GetValidator(ctx, valAddr)
-			if !found || val.GetStatus() != sdk.Unbonding {
-				continue
-			}
-			if val.GetDelegatorShares().IsZero() {
-				k.RemoveValidator(ctx, val.OperatorAddr)
-			} else {
-				k.unbondingToUnbonded(ctx, val)
-			}
+	endTime := ctx.BlockHeader().Time
+	for _, valAddr := range k.GetAllMatureValidatorQueue(ctx, endTime) {
+		val, found := k.GetValidator(ctx, valAddr)
+		if !found || val.GetStatus() != sdk.Unbonding {
+			continue
+		}
+		if val.GetDelegatorShares().IsZero() {
+			k.RemoveValidator(ctx, val.OperatorAddr)
+		} else {
+			k.unbondingToUnbonded(ctx, val)
 		}
-		store.Delete(validatorTimesliceIterator.Key())
 	}
+	store.DeleteRange(ValidatorQueueKey, sdk.InclusiveEndBytes(GetValidatorQueueTimeKey(endTime)))
 }
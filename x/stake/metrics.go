@@ -0,0 +1,15 @@
+package stake
+
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
+// RegisterGauges registers the gauges of the stake pool
+func RegisterGauges(registry sdk.GaugeRegistry, k Keeper) {
+	registry.RegisterGauge("stake", "bonded_tokens", "Tokens bonded to validators.",
+		func(ctx sdk.Context) float64 { return k.GetPool(ctx).BondedTokens.Float64() })
+	registry.RegisterGauge("stake", "loose_tokens", "Tokens not bonded to validators.",
+		func(ctx sdk.Context) float64 { return k.GetPool(ctx).LooseTokens.Float64() })
+	registry.RegisterGauge("stake", "bonded_ratio", "Ratio of the token supply bonded to validators.",
+		func(ctx sdk.Context) float64 { return k.GetPool(ctx).BondedRatio().Float64() })
+}
@@ -0,0 +1,82 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/yukimochizuki/cosmos-sdk/codec"
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake/keeper"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake/types"
+)
+
+// DecodeStore unmarshals the value of a KVPair of the stake store and returns
+// it in a human readable form
+func DecodeStore(cdc *codec.Codec, kv cmn.KVPair) string {
+	switch {
+	case bytes.Equal(kv.Key, keeper.PoolKey):
+		pool := types.MustUnmarshalPool(cdc, kv.Value)
+		return pool.HumanReadableString()
+
+	case bytes.Equal(kv.Key, keeper.IntraTxCounterKey):
+		var counter int16
+		cdc.MustUnmarshalBinary(kv.Value, &counter)
+		return fmt.Sprintf("intra tx counter: %d", counter)
+
+	case bytes.Equal(kv.Key, keeper.LastTotalPowerKey):
+		var power sdk.Int
+		cdc.MustUnmarshalBinary(kv.Value, &power)
+		return fmt.Sprintf("last total power: %v", power)
+
+	case bytes.HasPrefix(kv.Key, keeper.LastValidatorPowerKey):
+		var power sdk.Int
+		cdc.MustUnmarshalBinary(kv.Value, &power)
+		operator := sdk.ValAddress(keeper.AddressFromLastValidatorPowerKey(kv.Key))
+		return fmt.Sprintf("last power of %s: %v", operator, power)
+
+	case bytes.HasPrefix(kv.Key, keeper.ValidatorsKey):
+		validator := types.MustUnmarshalValidator(cdc, kv.Key[1:], kv.Value)
+		return fmt.Sprintf("%v", validator)
+
+	case bytes.HasPrefix(kv.Key, keeper.ValidatorsByConsAddrKey),
+		bytes.HasPrefix(kv.Key, keeper.ValidatorsByPowerIndexKey):
+		return fmt.Sprintf("validator: %s", sdk.ValAddress(kv.Value))
+
+	case bytes.HasPrefix(kv.Key, keeper.DelegationKey):
+		delegation := types.MustUnmarshalDelegation(cdc, kv.Key, kv.Value)
+		return fmt.Sprintf("%v", delegation)
+
+	case bytes.HasPrefix(kv.Key, keeper.UnbondingDelegationKey):
+		ubd := types.MustUnmarshalUBD(cdc, kv.Key, kv.Value)
+		return fmt.Sprintf("%v", ubd)
+
+	case bytes.HasPrefix(kv.Key, keeper.RedelegationKey):
+		red := types.MustUnmarshalRED(cdc, kv.Key, kv.Value)
+		return fmt.Sprintf("%v", red)
+
+	case bytes.HasPrefix(kv.Key, keeper.UnbondingDelegationByValIndexKey),
+		bytes.HasPrefix(kv.Key, keeper.RedelegationByValSrcIndexKey),
+		bytes.HasPrefix(kv.Key, keeper.RedelegationByValDstIndexKey):
+		return "index entry"
+
+	case bytes.HasPrefix(kv.Key, keeper.UnbondingQueueKey):
+		var dvPairs []types.DVPair
+		cdc.MustUnmarshalBinary(kv.Value, &dvPairs)
+		return fmt.Sprintf("%v", dvPairs)
+
+	case bytes.HasPrefix(kv.Key, keeper.RedelegationQueueKey):
+		var dvvTriplets []types.DVVTriplet
+		cdc.MustUnmarshalBinary(kv.Value, &dvvTriplets)
+		return fmt.Sprintf("%v", dvvTriplets)
+
+	case bytes.HasPrefix(kv.Key, keeper.ValidatorQueueKey):
+		var valAddrs []sdk.ValAddress
+		cdc.MustUnmarshalBinary(kv.Value, &valAddrs)
+		return fmt.Sprintf("%v", valAddrs)
+
+	default:
+		panic(fmt.Sprintf("invalid stake key %X", kv.Key))
+	}
+}
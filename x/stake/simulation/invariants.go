@@ -8,6 +8,8 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	"github.com/yukimochizuki/cosmos-sdk/x/bank"
 	"github.com/yukimochizuki/cosmos-sdk/x/distribution"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
+	govsim "github.com/yukimochizuki/cosmos-sdk/x/gov/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/stake"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -17,10 +19,10 @@ import (
 // Currently: total supply, positive power
 func AllInvariants(ck bank.Keeper, k stake.Keeper,
 	f auth.FeeCollectionKeeper, d distribution.Keeper,
-	am auth.AccountKeeper) simulation.Invariant {
+	am auth.AccountKeeper, gk gov.Keeper) simulation.Invariant {
 
 	return func(app *baseapp.BaseApp, header abci.Header) error {
-		err := SupplyInvariants(ck, k, f, d, am)(app, header)
+		err := SupplyInvariants(ck, k, f, d, am, gk)(app, header)
 		if err != nil {
 			return err
 		}
@@ -36,7 +38,7 @@ func AllInvariants(ck bank.Keeper, k stake.Keeper,
 // SupplyInvariants checks that the total supply reflects all held loose tokens, bonded tokens, and unbonding delegations
 // nolint: unparam
 func SupplyInvariants(ck bank.Keeper, k stake.Keeper,
-	f auth.FeeCollectionKeeper, d distribution.Keeper, am auth.AccountKeeper) simulation.Invariant {
+	f auth.FeeCollectionKeeper, d distribution.Keeper, am auth.AccountKeeper, gk gov.Keeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp, _ abci.Header) error {
 		ctx := app.NewContext(false, abci.Header{})
 		pool := k.GetPool(ctx)
@@ -83,6 +85,10 @@ func SupplyInvariants(ck bank.Keeper, k stake.Keeper,
 			},
 		)
 
+		// the gov simulation removed the refunded deposits from the loose
+		// tokens when they were deposited
+		loose = loose.Sub(sdk.NewDecFromInt(govsim.RefundedDeposits(gk, ctx)))
+
 		// Loose tokens should equal coin supply plus unbonding delegations
 		// plus tokens on unbonded validators
 		if !pool.LooseTokens.Equal(loose) {
@@ -0,0 +1,19 @@
+package simulation
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
+	"github.com/yukimochizuki/cosmos-sdk/x/stake"
+)
+
+// RandomParams returns random stake params, with unbonding times short
+// enough for unbondings and redelegations to mature during a simulation
+func RandomParams(r *rand.Rand) stake.Params {
+	return stake.Params{
+		UnbondingTime: simulation.RandDurationBetween(r, time.Minute, 48*time.Hour),
+		MaxValidators: uint16(simulation.RandIntBetween(r, 10, 100)),
+		BondDenom:     "steak",
+	}
+}
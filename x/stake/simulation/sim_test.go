@@ -11,6 +11,7 @@ import (
 	"github.com/yukimochizuki/cosmos-sdk/x/auth"
 	"github.com/yukimochizuki/cosmos-sdk/x/bank"
 	"github.com/yukimochizuki/cosmos-sdk/x/distribution"
+	"github.com/yukimochizuki/cosmos-sdk/x/gov"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock"
 	"github.com/yukimochizuki/cosmos-sdk/x/mock/simulation"
 	"github.com/yukimochizuki/cosmos-sdk/x/params"
@@ -30,11 +31,13 @@ func TestStakeWithRandomMessages(t *testing.T) {
 	paramsKey := sdk.NewKVStoreKey("params")
 	paramsTKey := sdk.NewTransientStoreKey("transient_params")
 	distrKey := sdk.NewKVStoreKey("distr")
+	govKey := sdk.NewKVStoreKey("gov")
 
 	feeCollectionKeeper := auth.NewFeeCollectionKeeper(mapp.Cdc, feeKey)
 	paramstore := params.NewKeeper(mapp.Cdc, paramsKey, paramsTKey)
 	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, stakeTKey, bankKeeper, paramstore.Subspace(stake.DefaultParamspace), stake.DefaultCodespace)
 	distrKeeper := distribution.NewKeeper(mapp.Cdc, distrKey, paramstore.Subspace(distribution.DefaultParamspace), bankKeeper, stakeKeeper, feeCollectionKeeper, distribution.DefaultCodespace)
+	govKeeper := gov.NewKeeper(mapp.Cdc, govKey, paramstore, paramstore.Subspace(gov.DefaultParamspace), bankKeeper, stakeKeeper, gov.DefaultCodespace)
 	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper))
 	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 		validatorUpdates := stake.EndBlocker(ctx, stakeKeeper)
@@ -43,7 +46,7 @@ func TestStakeWithRandomMessages(t *testing.T) {
 		}
 	})
 
-	err := mapp.CompleteSetup(stakeKey, stakeTKey, paramsKey, paramsTKey)
+	err := mapp.CompleteSetup(stakeKey, stakeTKey, paramsKey, paramsTKey, govKey)
 	if err != nil {
 		panic(err)
 	}
@@ -64,7 +67,7 @@ func TestStakeWithRandomMessages(t *testing.T) {
 		}, []simulation.RandSetup{
 			Setup(mapp, stakeKeeper),
 		}, []simulation.Invariant{
-			AllInvariants(bankKeeper, stakeKeeper, feeCollectionKeeper, distrKeeper, mapp.AccountKeeper),
+			AllInvariants(bankKeeper, stakeKeeper, feeCollectionKeeper, distrKeeper, mapp.AccountKeeper, govKeeper),
 		}, 10, 100,
 		false,
 	)
@@ -24,6 +24,7 @@ type (
 	MsgBeginUnbonding    = types.MsgBeginUnbonding
 	MsgBeginRedelegate   = types.MsgBeginRedelegate
 	GenesisState         = types.GenesisState
+	LastValidatorPower   = types.LastValidatorPower
 	QueryDelegatorParams = querier.QueryDelegatorParams
 	QueryValidatorParams = querier.QueryValidatorParams
 	QueryBondsParams     = querier.QueryBondsParams
@@ -56,6 +57,9 @@ var (
 	GetREDsFromValSrcIndexKey    = keeper.GetREDsFromValSrcIndexKey
 	GetREDsToValDstIndexKey      = keeper.GetREDsToValDstIndexKey
 	GetREDsByDelToValDstIndexKey = keeper.GetREDsByDelToValDstIndexKey
+	UnbondingQueueKey            = keeper.UnbondingQueueKey
+	RedelegationQueueKey         = keeper.RedelegationQueueKey
+	ValidatorQueueKey            = keeper.ValidatorQueueKey
 	TestingUpdateValidator       = keeper.TestingUpdateValidator
 
 	DefaultParamspace = keeper.DefaultParamspace
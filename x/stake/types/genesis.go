@@ -1,11 +1,27 @@
 package types
 
+import (
+	sdk "github.com/yukimochizuki/cosmos-sdk/types"
+)
+
 // GenesisState - all staking state that must be provided at genesis
 type GenesisState struct {
-	Pool       Pool         `json:"pool"`
-	Params     Params       `json:"params"`
-	Validators []Validator  `json:"validators"`
-	Bonds      []Delegation `json:"bonds"`
+	Pool                 Pool                  `json:"pool"`
+	Params               Params                `json:"params"`
+	IntraTxCounter       int16                 `json:"intra_tx_counter"`
+	LastTotalPower       sdk.Int               `json:"last_total_power"`
+	LastValidatorPowers  []LastValidatorPower  `json:"last_validator_powers"`
+	Validators           []Validator           `json:"validators"`
+	Bonds                []Delegation          `json:"bonds"`
+	UnbondingDelegations []UnbondingDelegation `json:"unbonding_delegations"`
+	Redelegations        []Redelegation        `json:"redelegations"`
+	Exported             bool                  `json:"exported"`
+}
+
+// LastValidatorPower - last validator power, needed for validator set update logic
+type LastValidatorPower struct {
+	Address sdk.ValAddress `json:"address"`
+	Power   sdk.Int        `json:"power"`
 }
 
 func NewGenesisState(pool Pool, params Params, validators []Validator, bonds []Delegation) GenesisState {